@@ -52,14 +52,15 @@ type ProgressUpdate struct {
 
 // ConfigRequest represents a configuration request from the client
 type ConfigRequest struct {
-	InputPath     string `json:"input_path"`
-	OutputPath    string `json:"output_path"`
-	Format        string `json:"format"`
-	Workers       int    `json:"workers,omitempty"`
-	BufferSize    int    `json:"buffer_size,omitempty"`
-	FilterPattern string `json:"filter_pattern,omitempty"`
-	Verbose       bool   `json:"verbose,omitempty"`
-	Silent        bool   `json:"silent,omitempty"`
+	InputPath          string   `json:"input_path"`
+	OutputPath         string   `json:"output_path"`
+	Format             string   `json:"format"`
+	Workers            int      `json:"workers,omitempty"`
+	BufferSize         int      `json:"buffer_size,omitempty"`
+	FilterPattern      string   `json:"filter_pattern,omitempty"`
+	TimestampQualities []string `json:"timestamp_qualities,omitempty"`
+	Verbose            bool     `json:"verbose,omitempty"`
+	Silent             bool     `json:"silent,omitempty"`
 }
 
 // StatusResponse represents the status response
@@ -355,15 +356,16 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 
 	// Update configuration
 	s.config = &app.Config{
-		InputPath:     configReq.InputPath,
-		OutputPath:    configReq.OutputPath,
-		Format:        configReq.Format,
-		Workers:       configReq.Workers,
-		BufferSize:    configReq.BufferSize,
-		FilterPattern: configReq.FilterPattern,
-		Verbose:       configReq.Verbose,
-		Silent:        configReq.Silent,
-		JSONStatus:    true, // Always use JSON status for API
+		InputPath:          configReq.InputPath,
+		OutputPath:         configReq.OutputPath,
+		Format:             configReq.Format,
+		Workers:            configReq.Workers,
+		BufferSize:         configReq.BufferSize,
+		FilterPattern:      configReq.FilterPattern,
+		TimestampQualities: configReq.TimestampQualities,
+		Verbose:            configReq.Verbose,
+		Silent:             configReq.Silent,
+		JSONStatus:         true, // Always use JSON status for API
 	}
 
 	// Validate configuration
@@ -67,6 +67,7 @@ func (a *App) Initialize() error {
 
 	// Create processor with configured number of workers
 	a.proc = processor.NewProcessor(a.writer, a.Config.Workers)
+	a.proc.SetTimestampQualities(a.Config.TimestampQualities)
 
 	return nil
 }
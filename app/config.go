@@ -2,8 +2,12 @@ package app
 
 import (
 	"errors"
+	"fmt"
 	"runtime"
+	"slices"
 	"strings"
+
+	"LogZero/core"
 )
 
 // Common errors
@@ -12,6 +16,7 @@ var (
 	ErrInvalidInput      = errors.New("invalid input path")
 	ErrInvalidOutput     = errors.New("invalid output path")
 	ErrProcessingFailed  = errors.New("processing failed")
+	ErrInvalidTimestampQuality = errors.New("invalid timestamp quality")
 )
 
 // SupportedFormats defines the output formats supported by LogZero
@@ -28,6 +33,7 @@ type Config struct {
 	Workers        int    // Number of worker goroutines
 	BufferSize     int    // Size of the buffer for file processing
 	FilterPattern  string // Pattern to filter events
+	TimestampQualities []string // Timestamp qualities of events to keep (empty keeps all)
 
 	// UI settings
 	Verbose        bool   // Enable verbose logging
@@ -73,5 +79,13 @@ func (c *Config) Validate() error {
 		c.BufferSize = 1000
 	}
 
+	// Validate timestamp qualities
+	for i, quality := range c.TimestampQualities {
+		c.TimestampQualities[i] = strings.ToLower(strings.TrimSpace(quality))
+		if !slices.Contains(core.TimestampQualities, c.TimestampQualities[i]) {
+			return fmt.Errorf("%w: %q", ErrInvalidTimestampQuality, quality)
+		}
+	}
+
 	return nil
 }
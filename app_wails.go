@@ -45,6 +45,8 @@ func (a *App) SelectInputFiles() ([]string, error) {
 			{DisplayName: "CSV Files", Pattern: "*.csv"},
 			{DisplayName: "XML Files", Pattern: "*.xml"},
 			{DisplayName: "SQLite Databases", Pattern: "*.sqlite;*.db"},
+			{DisplayName: "Packet Captures", Pattern: "*.pcap;*.pcapng;*.cap"},
+			{DisplayName: "NetFlow/IPFIX Exports", Pattern: "*.netflow;*.nflow;*.ipfix;*.nf5;*.nf9"},
 		},
 	})
 }
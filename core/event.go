@@ -14,12 +14,54 @@ type Event struct {
 	Host      string    `json:"host"`
 	Message   string    `json:"message"`
 	Path      string    `json:"path"`
+	// Zone a zone-less timestamp was read in, and how the timestamp's zone was determined
+	Timezone       string `json:"timezone,omitempty"`
+	TimezoneSource string `json:"timezone_source,omitempty"`
+	// How trustworthy the timestamp is; see the TimestampQuality* constants
+	TimestampQuality string `json:"timestamp_quality"`
+	// What the timestamp marks, in Plaso's terms; see the TimestampDesc* constants
+	TimestampDesc string `json:"timestamp_desc"`
 	// Additional fields for future AI use
 	Tags    []string `json:"tags,omitempty"`
 	Score   float64  `json:"score,omitempty"`
 	Summary string   `json:"summary,omitempty"`
 }
 
+// Timezone sources recorded in Event.TimezoneSource
+const (
+	TimezoneExplicit   = "explicit"   // The record carried its own offset or zone
+	TimezoneAssumed    = "assumed"    // Read in the parser's or the default timezone
+	TimezoneOverridden = "overridden" // Read in a timezone configured for the file's path
+)
+
+// Timestamp qualities recorded in Event.TimestampQuality
+const (
+	TimestampExact     = "exact"      // Taken from the record itself
+	TimestampInferred  = "inferred"   // Completed from context, such as a year-less syslog date
+	TimestampFileMtime = "file-mtime" // The source file's modification time; the record had none
+	TimestampMissing   = "missing"    // No timestamp could be determined; Timestamp is zero
+)
+
+// TimestampQualities lists the valid timestamp qualities, for filtering
+var TimestampQualities = []string{TimestampExact, TimestampInferred, TimestampFileMtime, TimestampMissing}
+
+// Common timestamp descriptions recorded in Event.TimestampDesc, named as Plaso names them
+const (
+	TimestampDescRecorded       = "Event Recorded"            // A log or audit record was written
+	TimestampDescCreation       = "Creation Time"             // An item was created
+	TimestampDescModification   = "Content Modification Time" // An item, usually the source file, was last written
+	TimestampDescLastAccess     = "Last Access Time"          // An item was last read
+	TimestampDescLastVisited    = "Last Visited Time"         // A URL was visited
+	TimestampDescLastUsed       = "Last Used Time"            // A saved item, such as a login or form entry, was last used
+	TimestampDescLastRun        = "Last Time Executed"        // A program was run
+	TimestampDescDeletion       = "Deletion Time"             // An item was deleted
+	TimestampDescDownloaded     = "File Downloaded"           // A download was saved
+	TimestampDescStart          = "Start Time"                // A session, connection or activity began
+	TimestampDescEnd            = "End Time"                  // A session, connection or activity ended
+	TimestampDescRegistration   = "Registration Time"         // A scheduled task or service was registered
+	TimestampDescScheduledStart = "Scheduled to Start"        // A scheduled task or trigger becomes active
+)
+
 // NewEvent creates a new timeline event with the given parameters
 func NewEvent(
 	timestamp time.Time,
@@ -32,18 +74,37 @@ func NewEvent(
 	path string,
 ) *Event {
 	return &Event{
-		Timestamp: timestamp,
-		Source:    source,
-		EventType: eventType,
-		EventID:   eventID,
-		User:      user,
-		Host:      host,
-		Message:   message,
-		Path:      path,
-		Tags:      []string{},
-		Score:     0.0,
-		Summary:   "",
+		Timestamp:        timestamp,
+		Source:           source,
+		EventType:        eventType,
+		EventID:          eventID,
+		User:             user,
+		Host:             host,
+		Message:          message,
+		Path:             path,
+		Tags:             []string{},
+		Score:            0.0,
+		Summary:          "",
+		TimestampQuality: timestampQuality(timestamp),
+	}
+}
+
+// timestampQuality is the initial quality of a timestamp taken from a record
+func timestampQuality(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return TimestampMissing
 	}
+	return TimestampExact
+}
+
+// WithTimestamp returns a copy of the event for another meaningful time of the same record
+func (e *Event) WithTimestamp(timestamp time.Time, desc string) *Event {
+	event := *e
+	event.Timestamp = timestamp
+	event.TimestampDesc = desc
+	event.TimestampQuality = timestampQuality(timestamp)
+	event.Tags = append([]string{}, e.Tags...)
+	return &event
 }
 
 // Events is a slice of Event pointers that can be sorted by timestamp
@@ -52,4 +113,4 @@ type Events []*Event
 // Implement sort.Interface for Events
 func (e Events) Len() int           { return len(e) }
 func (e Events) Less(i, j int) bool { return e[i].Timestamp.Before(e[j].Timestamp) }
-func (e Events) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
\ No newline at end of file
+func (e Events) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
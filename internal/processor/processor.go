@@ -30,7 +30,8 @@ const maxFileSizeBytes = 2 * 1024 * 1024 * 1024 // 2GB
 type Processor struct {
 	numWorkers           int
 	writer               output.Writer
-	totalEventsProcessed int64 // Total number of events processed
+	totalEventsProcessed int64           // Total number of events processed
+	timestampQualities   map[string]bool // Timestamp qualities of events to keep; nil keeps all
 }
 
 // NewProcessor creates a new processor with the specified number of workers
@@ -47,6 +48,33 @@ func NewProcessor(writer output.Writer, numWorkers int) *Processor {
 	}
 }
 
+// SetTimestampQualities restricts output to events whose timestamp has one of the given qualities
+// An empty list keeps all events.
+func (p *Processor) SetTimestampQualities(qualities []string) {
+	p.timestampQualities = nil
+	if len(qualities) == 0 {
+		return
+	}
+	p.timestampQualities = make(map[string]bool, len(qualities))
+	for _, quality := range qualities {
+		p.timestampQualities[quality] = true
+	}
+}
+
+// filterTimestampQuality drops events whose timestamp quality was not selected
+func (p *Processor) filterTimestampQuality(events []*core.Event) []*core.Event {
+	if p.timestampQualities == nil {
+		return events
+	}
+	kept := make([]*core.Event, 0, len(events))
+	for _, event := range events {
+		if p.timestampQualities[event.TimestampQuality] {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
 // ProcessPath processes a file or directory path
 func (p *Processor) ProcessPath(inputPath string) error {
 	// Use ProcessPathWithContext with a background context
@@ -118,6 +146,9 @@ func (p *Processor) processFileWithContext(ctx context.Context, filePath string,
 	if err != nil {
 		return fmt.Errorf("failed to parse file %s: %w", filePath, err)
 	}
+	parsers.NormalizeTimezones(filePath, parser, events)
+	parsers.DescribeTimestamps(parser, events)
+	events = p.filterTimestampQuality(events)
 
 	// Apply filter if specified (using pre-compiled regex)
 	if filterRegex != nil {
@@ -269,6 +300,9 @@ func (p *Processor) processDirectoryWithContext(ctx context.Context, dirPath str
 						processingErrors.Add(fmt.Errorf("failed to parse file %s: %w", filePath, err))
 						continue
 					}
+					parsers.NormalizeTimezones(filePath, parser, events)
+					parsers.DescribeTimestamps(parser, events)
+					events = p.filterTimestampQuality(events)
 
 					// Apply filter if specified (use pre-compiled regex)
 					if filterRegex != nil {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"embed"
 	"flag"
+	"fmt"
 	"io/fs"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"LogZero/internal/logrotate"
 	"LogZero/internal/retry"
 	"LogZero/internal/securestorage"
+	"LogZero/parsers"
 )
 
 //go:embed all:frontend/dist
@@ -66,6 +69,19 @@ var (
 	inputPath            = flag.String("input", "", "Path to input file or directory")
 	outputPath           = flag.String("output", "", "Path to output file")
 	format               = flag.String("format", "jsonl", "Output format (csv, jsonl, sqlite)")
+
+	// Parser flags
+	nginxLogFormat       = flag.String("nginx-log-format", "", "Custom nginx log_format string for access logs (e.g. '$remote_addr [$time_local] \"$request\" $status')")
+	postgresLogLinePrefix = flag.String("postgres-log-line-prefix", "", "PostgreSQL log_line_prefix of the server logs (e.g. '%m [%p] %q%u@%d '); common defaults are detected when empty")
+	multilineStart       = flag.String("multiline-start", "", "Regex matching the first line of a record in plain text logs; other lines are folded into it (default: timestamped lines)")
+	parserConfig         = flag.String("parser-config", "", "Comma-separated YAML/TOML files or directories with custom parser definitions")
+	jsonMapping          = flag.String("json-mapping", "", "Comma-separated YAML/TOML files mapping JSON paths (e.g. actor.alternateId) onto event fields for JSON/JSONL files")
+	listParsers          = flag.Bool("list-parsers", false, "List custom and built-in parsers in detection order and exit")
+	timezone             = flag.String("timezone", "UTC", "Timezone for timestamps recorded without an offset: IANA name (e.g. Europe/Berlin), Local, or an offset like +02:00")
+	timezoneOverride     = flag.String("timezone-override", "", "Comma-separated glob=Zone pairs assigning a timezone to matching files (e.g. 'dc01/*.log=America/New_York')")
+	parserTimezone       = flag.String("parser-timezone", "", "Comma-separated Parser=Zone pairs (names as shown by -list-parsers) assigning a timezone to a parser's files")
+	assumeYear           = flag.Int("assume-year", 0, "Year of the first year-less timestamp (RFC 3164 syslog, ASL) in every file; by default it is inferred from dated lines, rotation suffixes and file mtimes")
+	timestampQuality     = flag.String("timestamp-quality", "", "Comma-separated timestamp qualities of events to keep (exact, inferred, file-mtime, missing); all are kept by default")
 )
 
 func main() {
@@ -75,6 +91,58 @@ func main() {
 	// Initialize logger
 	initLogger()
 
+	// Apply parser options before any files are processed
+	if err := parsers.SetNginxLogFormat(*nginxLogFormat); err != nil {
+		logger.Error("Invalid -nginx-log-format: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetPostgresLogLinePrefix(*postgresLogLinePrefix); err != nil {
+		logger.Error("Invalid -postgres-log-line-prefix: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetMultilineStartPattern(*multilineStart); err != nil {
+		logger.Error("Invalid -multiline-start: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetDefaultTimezone(*timezone); err != nil {
+		logger.Error("Invalid -timezone: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetTimezoneOverrides(strings.Split(*timezoneOverride, ",")); err != nil {
+		logger.Error("Invalid -timezone-override: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetParserTimezones(strings.Split(*parserTimezone, ",")); err != nil {
+		logger.Error("Invalid -parser-timezone: %v", err)
+		os.Exit(1)
+	}
+	if err := parsers.SetAssumeYear(*assumeYear); err != nil {
+		logger.Error("Invalid -assume-year: %v", err)
+		os.Exit(1)
+	}
+	if *parserConfig != "" {
+		if err := parsers.LoadCustomParsers(strings.Split(*parserConfig, ",")); err != nil {
+			logger.Error("Invalid -parser-config: %v", err)
+			os.Exit(1)
+		}
+	}
+	if *jsonMapping != "" {
+		if err := parsers.LoadJSONMappings(strings.Split(*jsonMapping, ",")); err != nil {
+			logger.Error("Invalid -json-mapping: %v", err)
+			os.Exit(1)
+		}
+	}
+	if *listParsers {
+		for _, info := range parsers.ListParsers() {
+			if info.Custom {
+				fmt.Printf("%s (custom, %s)\n", info.Name, info.Origin)
+			} else {
+				fmt.Println(info.Name)
+			}
+		}
+		return
+	}
+
 	// Check if we should run in CLI mode (direct processing)
 	if *inputPath != "" && *outputPath != "" {
 		// Run in CLI mode (direct processing)
@@ -138,6 +206,9 @@ func runCLIMode() {
 	config.InputPath = *inputPath
 	config.OutputPath = *outputPath
 	config.Format = *format
+	if *timestampQuality != "" {
+		config.TimestampQualities = strings.Split(*timestampQuality, ",")
+	}
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -45,6 +45,10 @@ func NewCSVWriter(outputPath string) (*CSVWriter, error) {
 		"tags",
 		"score",
 		"summary",
+		"timezone",
+		"timezone_source",
+		"timestamp_quality",
+		"timestamp_desc",
 	}
 
 	if err := writer.Write(header); err != nil {
@@ -85,6 +89,10 @@ func (w *CSVWriter) Write(events []*core.Event) error {
 			formatTags(event.Tags),
 			strconv.FormatFloat(event.Score, 'f', 2, 64),
 			event.Summary,
+			event.Timezone,
+			event.TimezoneSource,
+			event.TimestampQuality,
+			event.TimestampDesc,
 		}
 
 		if err := w.writer.Write(record); err != nil {
@@ -61,7 +61,11 @@ func NewSQLiteWriter(outputPath string) (*SQLiteWriter, error) {
 		path TEXT,
 		tags TEXT,
 		score REAL,
-		summary TEXT
+		summary TEXT,
+		timezone TEXT,
+		timezone_source TEXT,
+		timestamp_quality TEXT,
+		timestamp_desc TEXT
 	);
 	`
 
@@ -75,8 +79,8 @@ func NewSQLiteWriter(outputPath string) (*SQLiteWriter, error) {
 	// Prepare insert statement at db level (reusable across transactions)
 	insertSQL := `
 	INSERT INTO events (
-		timestamp, source, event_type, event_id, user, host, message, path, tags, score, summary
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+		timestamp, source, event_type, event_id, user, host, message, path, tags, score, summary, timezone, timezone_source, timestamp_quality, timestamp_desc
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 	`
 
 	stmt, err := db.Prepare(insertSQL)
@@ -128,6 +132,10 @@ func (w *SQLiteWriter) Write(events []*core.Event) error {
 			tagsStr,
 			event.Score,
 			event.Summary,
+			event.Timezone,
+			event.TimezoneSource,
+			event.TimestampQuality,
+			event.TimestampDesc,
 		)
 
 		if err != nil {
@@ -0,0 +1,629 @@
+package parsers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// chromiumDownloadStates maps the downloads.state column to names
+var chromiumDownloadStates = map[int]string{
+	0: "in progress",
+	1: "complete",
+	2: "cancelled",
+	3: "interrupted",
+	4: "interrupted",
+}
+
+// chromiumDangerTypes maps the downloads.danger_type column to Chromium's DownloadDangerType names
+var chromiumDangerTypes = map[int]string{
+	0:  "NOT_DANGEROUS",
+	1:  "DANGEROUS_FILE",
+	2:  "DANGEROUS_URL",
+	3:  "DANGEROUS_CONTENT",
+	4:  "MAYBE_DANGEROUS_CONTENT",
+	5:  "UNCOMMON_CONTENT",
+	6:  "USER_VALIDATED",
+	7:  "DANGEROUS_HOST",
+	8:  "POTENTIALLY_UNWANTED",
+	9:  "ALLOWLISTED_BY_POLICY",
+	10: "ASYNC_SCANNING",
+	11: "BLOCKED_PASSWORD_PROTECTED",
+	12: "BLOCKED_TOO_LARGE",
+	13: "SENSITIVE_CONTENT_WARNING",
+	14: "SENSITIVE_CONTENT_BLOCK",
+	15: "DEEP_SCANNED_SAFE",
+	16: "DEEP_SCANNED_OPENED_DANGEROUS",
+	17: "PROMPT_FOR_SCANNING",
+	18: "BLOCKED_UNSUPPORTED_FILETYPE",
+	19: "DANGEROUS_ACCOUNT_COMPROMISE",
+}
+
+// chromiumDangerousTypes are danger types where the browser flagged the download as harmful
+var chromiumDangerousTypes = map[int]bool{1: true, 2: true, 3: true, 4: true, 7: true, 8: true, 16: true, 19: true}
+
+// firefoxDownloadStates maps Firefox download states (moz_downloads.state and downloads/metaData) to names
+var firefoxDownloadStates = map[int]string{
+	0: "in progress",
+	1: "complete",
+	2: "failed",
+	3: "cancelled",
+	4: "paused",
+	5: "queued",
+	6: "blocked (parental controls)",
+	7: "scanning",
+	8: "blocked (dirty)",
+	9: "blocked (policy)",
+}
+
+// tableColumns returns the set of column names of a table, or nil if the table does not exist
+// Browser schemas change between versions, so queries are built from the columns present
+func tableColumns(db *sql.DB, table string) map[string]bool {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var columns map[string]bool
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			continue
+		}
+		if columns == nil {
+			columns = make(map[string]bool)
+		}
+		columns[name] = true
+	}
+	return columns
+}
+
+// columnOr returns the column name if present, otherwise a literal fallback aliased to the name
+func columnOr(columns map[string]bool, name, fallback string) string {
+	if columns[name] {
+		return name
+	}
+	return fmt.Sprintf("%s AS %s", fallback, name)
+}
+
+// newBrowserEvent creates an event for one of the times of a browser artifact record
+func (p *BrowserHistoryParser) newBrowserEvent(timestamp time.Time, desc, eventType, user, message, filePath string) *core.Event {
+	event := core.NewEvent(
+		timestamp,
+		filepath.Base(filePath),
+		eventType,
+		0, // No specific event ID
+		user,
+		"", // Host unknown
+		message,
+		filePath,
+	)
+	event.TimestampDesc = desc
+	return event
+}
+
+// parseChromeDownloads parses the downloads table of a Chromium History database
+func (p *BrowserHistoryParser) parseChromeDownloads(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "downloads")
+	if columns == nil {
+		return nil, nil
+	}
+
+	// Older versions stored the URL on the downloads row instead of downloads_url_chains
+	urlExpr := "''"
+	if tableColumns(db, "downloads_url_chains") != nil {
+		urlExpr = "COALESCE((SELECT c.url FROM downloads_url_chains c WHERE c.id = d.id ORDER BY c.chain_index DESC LIMIT 1), '')"
+	} else if columns["url"] {
+		urlExpr = "d.url"
+	}
+	targetPath := columnOr(columns, "target_path", "''")
+	if !columns["target_path"] && columns["full_path"] {
+		targetPath = "full_path AS target_path"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s
+		FROM downloads d
+		ORDER BY start_time
+	`,
+		urlExpr,
+		targetPath,
+		columnOr(columns, "start_time", "0"),
+		columnOr(columns, "end_time", "0"),
+		columnOr(columns, "received_bytes", "0"),
+		columnOr(columns, "total_bytes", "0"),
+		columnOr(columns, "state", "0"),
+		columnOr(columns, "danger_type", "0"),
+		columnOr(columns, "referrer", "''"),
+		columnOr(columns, "tab_url", "''"),
+		columnOr(columns, "mime_type", "''"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Chrome downloads: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var url, target, referrer, tabURL, mimeType sql.NullString
+		var startTime, endTime, receivedBytes, totalBytes sql.NullInt64
+		var state, dangerType sql.NullInt64
+
+		if err := rows.Scan(&url, &target, &startTime, &endTime, &receivedBytes, &totalBytes, &state, &dangerType, &referrer, &tabURL, &mimeType); err != nil {
+			fmt.Printf("Warning: failed to scan Chrome download row: %v\n", err)
+			continue
+		}
+
+		var details []string
+		details = append(details, fmt.Sprintf("%s -> %s", url.String, target.String))
+		details = append(details, fmt.Sprintf("state=%s", chromiumDownloadStates[int(state.Int64)]))
+		details = append(details, fmt.Sprintf("bytes=%d/%d", receivedBytes.Int64, totalBytes.Int64))
+		if name, ok := chromiumDangerTypes[int(dangerType.Int64)]; ok && dangerType.Int64 != 0 {
+			details = append(details, fmt.Sprintf("danger=%s", name))
+		}
+		if mimeType.String != "" {
+			details = append(details, fmt.Sprintf("mime=%s", mimeType.String))
+		}
+		if referrer.String != "" {
+			details = append(details, fmt.Sprintf("referrer=%s", referrer.String))
+		}
+		if tabURL.String != "" && tabURL.String != referrer.String {
+			details = append(details, fmt.Sprintf("tab_url=%s", tabURL.String))
+		}
+		detail := strings.Join(details, " ")
+
+		for _, entry := range []struct {
+			micros int64
+			label  string
+			desc   string
+		}{
+			{startTime.Int64, "Download started", core.TimestampDescStart},
+			{endTime.Int64, "Download finished", core.TimestampDescEnd},
+		} {
+			timestamp := p.webkitToTime(entry.micros)
+			if timestamp.IsZero() {
+				continue
+			}
+			event := p.newBrowserEvent(timestamp, entry.desc, "BrowserDownload", "", fmt.Sprintf("%s: %s", entry.label, detail), filePath)
+			if chromiumDangerousTypes[int(dangerType.Int64)] {
+				event.Score = 0.8
+				event.Tags = append(event.Tags, "dangerous_download")
+			}
+			events = append(events, event)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Chrome download rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// parseChromeCookies parses a Chromium Cookies database
+// Cookie values are never read; they are encrypted and not needed for the timeline
+func (p *BrowserHistoryParser) parseChromeCookies(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "cookies")
+	if columns == nil {
+		return nil, fmt.Errorf("failed to query Chrome cookies: no cookies table")
+	}
+
+	secure := columnOr(columns, "is_secure", "0")
+	if !columns["is_secure"] && columns["secure"] {
+		secure = "secure AS is_secure"
+	}
+	httpOnly := columnOr(columns, "is_httponly", "0")
+	if !columns["is_httponly"] && columns["httponly"] {
+		httpOnly = "httponly AS is_httponly"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT host_key, name, path, creation_utc, %s, %s, %s, %s
+		FROM cookies
+		ORDER BY creation_utc
+	`,
+		columnOr(columns, "last_access_utc", "0"),
+		columnOr(columns, "expires_utc", "0"),
+		secure,
+		httpOnly,
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Chrome cookies: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var host, name, path sql.NullString
+		var created, lastAccess, expires, isSecure, isHTTPOnly sql.NullInt64
+
+		if err := rows.Scan(&host, &name, &path, &created, &lastAccess, &expires, &isSecure, &isHTTPOnly); err != nil {
+			fmt.Printf("Warning: failed to scan Chrome cookie row: %v\n", err)
+			continue
+		}
+
+		detail := p.buildCookieDetail(host.String, name.String, path.String, p.webkitToTime(expires.Int64), isSecure.Int64 != 0, isHTTPOnly.Int64 != 0)
+		events = p.appendCookieEvents(events, p.webkitToTime(created.Int64), p.webkitToTime(lastAccess.Int64), detail, filePath)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Chrome cookie rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// buildCookieDetail formats cookie metadata for an event message
+func (p *BrowserHistoryParser) buildCookieDetail(host, name, path string, expires time.Time, secure, httpOnly bool) string {
+	detail := fmt.Sprintf("%s%s name=%s", host, path, name)
+	if !expires.IsZero() {
+		detail += fmt.Sprintf(" expires=%s", expires.Format(time.RFC3339))
+	} else {
+		detail += " expires=session"
+	}
+	if secure {
+		detail += " secure"
+	}
+	if httpOnly {
+		detail += " httponly"
+	}
+	return detail
+}
+
+// appendCookieEvents adds creation and last access events for a cookie
+func (p *BrowserHistoryParser) appendCookieEvents(events []*core.Event, created, lastAccess time.Time, detail, filePath string) []*core.Event {
+	if !created.IsZero() {
+		events = append(events, p.newBrowserEvent(created, core.TimestampDescCreation, "BrowserCookie", "", "Cookie created: "+detail, filePath))
+	}
+	if !lastAccess.IsZero() && !lastAccess.Equal(created) {
+		events = append(events, p.newBrowserEvent(lastAccess, core.TimestampDescLastAccess, "BrowserCookie", "", "Cookie last accessed: "+detail, filePath))
+	}
+	return events
+}
+
+// parseChromeAutofill parses the autofill table of a Chromium Web Data database
+// Autofill dates are Unix seconds, unlike the WebKit timestamps used elsewhere
+func (p *BrowserHistoryParser) parseChromeAutofill(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "autofill")
+	if columns == nil {
+		return nil, fmt.Errorf("failed to query Chrome autofill: no autofill table")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT name, value, %s, %s, %s
+		FROM autofill
+		ORDER BY date_created
+	`,
+		columnOr(columns, "date_created", "0"),
+		columnOr(columns, "date_last_used", "0"),
+		columnOr(columns, "count", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Chrome autofill: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var name, value sql.NullString
+		var created, lastUsed, count sql.NullInt64
+
+		if err := rows.Scan(&name, &value, &created, &lastUsed, &count); err != nil {
+			fmt.Printf("Warning: failed to scan Chrome autofill row: %v\n", err)
+			continue
+		}
+
+		detail := fmt.Sprintf("field=%s value=%s (used %d times)", name.String, value.String, count.Int64)
+		events = p.appendFormEvents(events, "BrowserAutofill", unixSecondsToTime(created.Int64), unixSecondsToTime(lastUsed.Int64), detail, filePath)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Chrome autofill rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// appendFormEvents adds first and last use events for a form/autofill entry
+func (p *BrowserHistoryParser) appendFormEvents(events []*core.Event, eventType string, first, last time.Time, detail, filePath string) []*core.Event {
+	if !first.IsZero() {
+		events = append(events, p.newBrowserEvent(first, core.TimestampDescCreation, eventType, "", "Form entry first used: "+detail, filePath))
+	}
+	if !last.IsZero() && !last.Equal(first) {
+		events = append(events, p.newBrowserEvent(last, core.TimestampDescLastUsed, eventType, "", "Form entry last used: "+detail, filePath))
+	}
+	return events
+}
+
+// unixSecondsToTime converts Unix seconds to time.Time, mapping zero to the zero time
+func unixSecondsToTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// parseChromeLogins parses saved login metadata from a Chromium Login Data database
+// password_value is deliberately never selected
+func (p *BrowserHistoryParser) parseChromeLogins(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "logins")
+	if columns == nil {
+		return nil, fmt.Errorf("failed to query Chrome logins: no logins table")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT origin_url, %s, username_value, %s, %s, %s
+		FROM logins
+		ORDER BY date_created
+	`,
+		columnOr(columns, "action_url", "''"),
+		columnOr(columns, "date_created", "0"),
+		columnOr(columns, "date_last_used", "0"),
+		columnOr(columns, "times_used", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Chrome logins: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var origin, action, username sql.NullString
+		var created, lastUsed, timesUsed sql.NullInt64
+
+		if err := rows.Scan(&origin, &action, &username, &created, &lastUsed, &timesUsed); err != nil {
+			fmt.Printf("Warning: failed to scan Chrome login row: %v\n", err)
+			continue
+		}
+
+		detail := fmt.Sprintf("%s username=%s (used %d times)", origin.String, username.String, timesUsed.Int64)
+		if action.String != "" && action.String != origin.String {
+			detail += fmt.Sprintf(" action=%s", action.String)
+		}
+
+		createdTime := p.webkitToTime(created.Int64)
+		lastUsedTime := p.webkitToTime(lastUsed.Int64)
+		if !createdTime.IsZero() {
+			events = append(events, p.newBrowserEvent(createdTime, core.TimestampDescCreation, "BrowserLogin", username.String, "Login saved: "+detail, filePath))
+		}
+		if !lastUsedTime.IsZero() && !lastUsedTime.Equal(createdTime) {
+			events = append(events, p.newBrowserEvent(lastUsedTime, core.TimestampDescLastUsed, "BrowserLogin", username.String, "Login last used: "+detail, filePath))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Chrome login rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// firefoxDownloadMeta is the JSON stored in the downloads/metaData annotation
+type firefoxDownloadMeta struct {
+	State    *int  `json:"state"`
+	EndTime  int64 `json:"endTime"` // Milliseconds since Unix epoch
+	FileSize int64 `json:"fileSize"`
+}
+
+// parseFirefoxDownloads parses downloads recorded as moz_annos annotations in places.sqlite (Firefox 26+)
+func (p *BrowserHistoryParser) parseFirefoxDownloads(db *sql.DB, filePath string) ([]*core.Event, error) {
+	if tableColumns(db, "moz_annos") == nil || tableColumns(db, "moz_anno_attributes") == nil {
+		return nil, nil
+	}
+
+	query := `
+		SELECT moz_places.url, a.content, a.dateAdded,
+			COALESCE((SELECT m.content FROM moz_annos m
+				JOIN moz_anno_attributes mn ON m.anno_attribute_id = mn.id
+				WHERE m.place_id = a.place_id AND mn.name = 'downloads/metaData'), '')
+		FROM moz_annos a
+		JOIN moz_anno_attributes n ON a.anno_attribute_id = n.id
+		JOIN moz_places ON moz_places.id = a.place_id
+		WHERE n.name = 'downloads/destinationFileURI'
+		ORDER BY a.dateAdded
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Firefox downloads: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var url, target, metaJSON sql.NullString
+		var dateAdded sql.NullInt64
+
+		if err := rows.Scan(&url, &target, &dateAdded, &metaJSON); err != nil {
+			fmt.Printf("Warning: failed to scan Firefox download row: %v\n", err)
+			continue
+		}
+
+		var meta firefoxDownloadMeta
+		if metaJSON.String != "" {
+			_ = json.Unmarshal([]byte(metaJSON.String), &meta)
+		}
+
+		detail := fmt.Sprintf("%s -> %s", url.String, target.String)
+		if meta.State != nil {
+			detail += fmt.Sprintf(" state=%s", firefoxDownloadStates[*meta.State])
+		}
+		if meta.FileSize > 0 {
+			detail += fmt.Sprintf(" bytes=%d", meta.FileSize)
+		}
+
+		if started := p.prtimeToTime(dateAdded.Int64); !started.IsZero() {
+			events = append(events, p.newBrowserEvent(started, core.TimestampDescStart, "BrowserDownload", "", "Download started: "+detail, filePath))
+		}
+		if meta.EndTime > 0 {
+			events = append(events, p.newBrowserEvent(time.UnixMilli(meta.EndTime).UTC(), core.TimestampDescEnd, "BrowserDownload", "", "Download finished: "+detail, filePath))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Firefox download rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// parseFirefoxLegacyDownloads parses the moz_downloads table of downloads.sqlite (Firefox 25 and earlier)
+func (p *BrowserHistoryParser) parseFirefoxLegacyDownloads(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "moz_downloads")
+	if columns == nil {
+		return nil, fmt.Errorf("failed to query Firefox downloads: no moz_downloads table")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT source, target, startTime, endTime, state, %s, %s, %s
+		FROM moz_downloads
+		ORDER BY startTime
+	`,
+		columnOr(columns, "referrer", "''"),
+		columnOr(columns, "mimeType", "''"),
+		columnOr(columns, "maxBytes", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Firefox downloads: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var source, target, referrer, mimeType sql.NullString
+		var startTime, endTime, state, maxBytes sql.NullInt64
+
+		if err := rows.Scan(&source, &target, &startTime, &endTime, &state, &referrer, &mimeType, &maxBytes); err != nil {
+			fmt.Printf("Warning: failed to scan Firefox download row: %v\n", err)
+			continue
+		}
+
+		detail := fmt.Sprintf("%s -> %s state=%s", source.String, target.String, firefoxDownloadStates[int(state.Int64)])
+		if maxBytes.Int64 > 0 {
+			detail += fmt.Sprintf(" bytes=%d", maxBytes.Int64)
+		}
+		if mimeType.String != "" {
+			detail += fmt.Sprintf(" mime=%s", mimeType.String)
+		}
+		if referrer.String != "" {
+			detail += fmt.Sprintf(" referrer=%s", referrer.String)
+		}
+
+		if started := p.prtimeToTime(startTime.Int64); !started.IsZero() {
+			events = append(events, p.newBrowserEvent(started, core.TimestampDescStart, "BrowserDownload", "", "Download started: "+detail, filePath))
+		}
+		if ended := p.prtimeToTime(endTime.Int64); !ended.IsZero() {
+			events = append(events, p.newBrowserEvent(ended, core.TimestampDescEnd, "BrowserDownload", "", "Download finished: "+detail, filePath))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Firefox download rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// parseFirefoxCookies parses a Firefox cookies.sqlite database
+// Cookie values are never read
+func (p *BrowserHistoryParser) parseFirefoxCookies(db *sql.DB, filePath string) ([]*core.Event, error) {
+	columns := tableColumns(db, "moz_cookies")
+	if columns == nil {
+		return nil, fmt.Errorf("failed to query Firefox cookies: no moz_cookies table")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT host, name, path, creationTime, lastAccessed, %s, %s, %s
+		FROM moz_cookies
+		ORDER BY creationTime
+	`,
+		columnOr(columns, "expiry", "0"),
+		columnOr(columns, "isSecure", "0"),
+		columnOr(columns, "isHttpOnly", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Firefox cookies: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var host, name, path sql.NullString
+		var created, lastAccess, expiry, isSecure, isHTTPOnly sql.NullInt64
+
+		if err := rows.Scan(&host, &name, &path, &created, &lastAccess, &expiry, &isSecure, &isHTTPOnly); err != nil {
+			fmt.Printf("Warning: failed to scan Firefox cookie row: %v\n", err)
+			continue
+		}
+
+		// expiry is Unix seconds (milliseconds in Firefox 130+)
+		expires := unixSecondsToTime(expiry.Int64)
+		if expiry.Int64 > 1e11 {
+			expires = time.UnixMilli(expiry.Int64).UTC()
+		}
+
+		detail := p.buildCookieDetail(host.String, name.String, path.String, expires, isSecure.Int64 != 0, isHTTPOnly.Int64 != 0)
+		events = p.appendCookieEvents(events, p.prtimeToTime(created.Int64), p.prtimeToTime(lastAccess.Int64), detail, filePath)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Firefox cookie rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// parseFirefoxFormHistory parses a Firefox formhistory.sqlite database
+func (p *BrowserHistoryParser) parseFirefoxFormHistory(db *sql.DB, filePath string) ([]*core.Event, error) {
+	query := `
+		SELECT fieldname, value, timesUsed, firstUsed, lastUsed
+		FROM moz_formhistory
+		ORDER BY firstUsed
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Firefox form history: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	for rows.Next() {
+		var fieldName, value sql.NullString
+		var timesUsed, firstUsed, lastUsed sql.NullInt64
+
+		if err := rows.Scan(&fieldName, &value, &timesUsed, &firstUsed, &lastUsed); err != nil {
+			fmt.Printf("Warning: failed to scan Firefox form history row: %v\n", err)
+			continue
+		}
+
+		detail := fmt.Sprintf("field=%s value=%s (used %d times)", fieldName.String, value.String, timesUsed.Int64)
+		events = p.appendFormEvents(events, "BrowserFormHistory", p.prtimeToTime(firstUsed.Int64), p.prtimeToTime(lastUsed.Int64), detail, filePath)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Firefox form history rows: %w", err)
+	}
+
+	return events, nil
+}
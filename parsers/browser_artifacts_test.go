@@ -0,0 +1,152 @@
+package parsers
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// createTestDatabase creates a SQLite database at path and runs the given statements
+func createTestDatabase(t *testing.T, path string, statements ...string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create profile dir: %v", err)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to run %q: %v", stmt, err)
+		}
+	}
+}
+
+func TestBrowserArtifacts(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "Google", "Chrome", "User Data", "Default")
+
+	// 2023-04-21 15:30:45.123456 UTC as WebKit microseconds
+	const visitMicros = (1682091045+webkitEpochOffset)*1000000 + 123456
+
+	historyPath := filepath.Join(profile, "History")
+	createTestDatabase(t, historyPath,
+		`CREATE TABLE urls (id INTEGER PRIMARY KEY, url TEXT, title TEXT, visit_count INTEGER)`,
+		`CREATE TABLE visits (id INTEGER PRIMARY KEY, url INTEGER, visit_time INTEGER)`,
+		`CREATE TABLE downloads (id INTEGER PRIMARY KEY, target_path TEXT, start_time INTEGER, end_time INTEGER,
+			received_bytes INTEGER, total_bytes INTEGER, state INTEGER, danger_type INTEGER, referrer TEXT, tab_url TEXT, mime_type TEXT)`,
+		`CREATE TABLE downloads_url_chains (id INTEGER, chain_index INTEGER, url TEXT)`,
+		`INSERT INTO urls VALUES (1, 'https://example.com/', 'Example', 1)`,
+		fmt.Sprintf(`INSERT INTO visits VALUES (1, 1, %d)`, visitMicros),
+		fmt.Sprintf(`INSERT INTO downloads VALUES (1, 'C:\Users\jdoe\Downloads\setup.exe', %d, %d,
+			1024, 1024, 1, 1, 'https://example.com/', 'https://example.com/get', 'application/x-msdownload')`, visitMicros, visitMicros+5000000),
+		`INSERT INTO downloads_url_chains VALUES (1, 0, 'https://example.com/dl'), (1, 1, 'https://cdn.example.com/setup.exe')`,
+	)
+
+	loginPath := filepath.Join(profile, "Login Data")
+	createTestDatabase(t, loginPath,
+		`CREATE TABLE logins (origin_url TEXT, action_url TEXT, username_value TEXT, password_value BLOB,
+			date_created INTEGER, date_last_used INTEGER, times_used INTEGER)`,
+		fmt.Sprintf(`INSERT INTO logins VALUES ('https://mail.example.com/', 'https://mail.example.com/login', 'jdoe', X'DEADBEEF',
+			%d, 0, 3)`, visitMicros),
+	)
+
+	parser := &BrowserHistoryParser{}
+
+	events, err := parser.Parse(historyPath)
+	if err != nil {
+		t.Fatalf("Failed to parse History: %v", err)
+	}
+	wantVisit := time.Unix(1682091045, 123456000).UTC()
+
+	counts := make(map[string]int)
+	for _, event := range events {
+		counts[event.EventType]++
+		if event.EventType == "BrowserHistory" && !event.Timestamp.Equal(wantVisit) {
+			t.Errorf("Expected visit time %s with microseconds, got %s", wantVisit, event.Timestamp)
+		}
+		if event.EventType == "BrowserDownload" {
+			if !strings.Contains(event.Message, `https://cdn.example.com/setup.exe -> C:\Users\jdoe\Downloads\setup.exe`) ||
+				!strings.Contains(event.Message, "danger=DANGEROUS_FILE") ||
+				!strings.Contains(event.Message, "referrer=https://example.com/") {
+				t.Errorf("Unexpected download message: %s", event.Message)
+			}
+		}
+	}
+	if counts["BrowserHistory"] != 1 || counts["BrowserDownload"] != 2 {
+		t.Errorf("Expected 1 history and 2 download events, got %v", counts)
+	}
+
+	events, err = parser.Parse(loginPath)
+	if err != nil {
+		t.Fatalf("Failed to parse Login Data: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 login event, got %d", len(events))
+	}
+	if events[0].EventType != "BrowserLogin" || events[0].User != "jdoe" {
+		t.Errorf("Unexpected login event: %s user=%s", events[0].EventType, events[0].User)
+	}
+	if strings.Contains(strings.ToLower(events[0].Message), "deadbeef") {
+		t.Errorf("Login event must not contain password data: %s", events[0].Message)
+	}
+}
+
+func TestBrowserDetectionBySchema(t *testing.T) {
+	root := t.TempDir()
+	schema := []string{
+		`CREATE TABLE urls (id INTEGER PRIMARY KEY, url TEXT, title TEXT, visit_count INTEGER)`,
+		`CREATE TABLE visits (id INTEGER PRIMARY KEY, url INTEGER, visit_time INTEGER)`,
+		`INSERT INTO urls VALUES (1, 'https://example.com/', 'Example', 1)`,
+		`INSERT INTO visits VALUES (1, 1, 13325564645000000)`,
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantTags []string
+	}{
+		{"copied evidence", filepath.Join(root, "case123", "evidence", "History"), []string{"browser:Chromium-based"}},
+		{"Brave profile", filepath.Join(root, "BraveSoftware", "Brave-Browser", "User Data", "Profile 2", "History"), []string{"browser:Brave", "profile:Profile 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createTestDatabase(t, tt.path, schema...)
+
+			parser, err := GetParserForFile(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to get parser: %v", err)
+			}
+			if _, ok := parser.(*BrowserHistoryParser); !ok {
+				t.Fatalf("Expected BrowserHistoryParser, got %T", parser)
+			}
+
+			events, err := parser.Parse(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("Expected 1 event, got %d", len(events))
+			}
+			tags := strings.Join(events[0].Tags, ",")
+			for _, want := range tt.wantTags {
+				if !strings.Contains(tags, want) {
+					t.Errorf("Expected tag %q, got %v", want, events[0].Tags)
+				}
+			}
+		})
+	}
+
+	// A SQLite database without browser tables is left to other parsers
+	other := filepath.Join(root, "Google", "Chrome", "History")
+	createTestDatabase(t, other, `CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)`)
+	if (&BrowserHistoryParser{}).CanParse(other) {
+		t.Error("Expected non-browser SQLite database to be rejected")
+	}
+}
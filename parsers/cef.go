@@ -0,0 +1,649 @@
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for CEF, LEEF and key=value logs
+var (
+	// CEF/LEEF header start, optionally preceded by a syslog header
+	// Example: <134>Apr 21 15:30:45 fw01 CEF:0|Palo Alto Networks|PAN-OS|10.1|end|TRAFFIC|3|src=10.0.0.5 ...
+	cefHeaderPattern  = regexp.MustCompile(`CEF:\d+\|`)
+	leefHeaderPattern = regexp.MustCompile(`LEEF:\d(?:\.\d)?\|`)
+
+	// Syslog framing in front of CEF/LEEF/key=value payloads
+	syslogPriPattern       = regexp.MustCompile(`^<\d{1,3}>(?:\d\s+)?`)
+	syslogISOPrefixPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)(?:\s+(\S+))?`)
+	syslogBSDPrefixPattern = regexp.MustCompile(`^([A-Z][a-z]{2}\s+\d{1,2}(?:\s+\d{4})?\s+\d{2}:\d{2}:\d{2})(?:\s+(\S+))?`)
+
+	// Generic key=value pair with optional double-quoted value (FortiGate, Check Point, Sophos)
+	// Example: date=2023-04-21 time=15:30:45 devname="FGT60E" srcip=10.0.0.5 action="deny"
+	kvPairPattern = regexp.MustCompile(`(?:^|\s)([A-Za-z_][\w.\-]*)=("(?:[^"\\]|\\.)*"|\S*)`)
+
+	// CEF extension key immediately preceding an unescaped '='
+	cefExtKeyPattern = regexp.MustCompile(`[\w.\-\[\]]+$`)
+)
+
+// kvMinPairs is the number of key=value pairs a line needs to be treated as a key=value log
+const kvMinPairs = 4
+
+// kvVendorSignatures are key sets that identify headerless appliance key=value logs; a line must
+// carry every key of one set. Bare src=/user= pairs also appear in syslog and application logs,
+// so they are left to those parsers.
+var kvVendorSignatures = [][]string{
+	{"date", "time", "devname"}, // FortiGate
+	{"date", "time", "logid"},   // FortiGate
+}
+
+// cefSeverityScores maps CEF 0.1+ textual severities to normalized scores
+var cefSeverityScores = map[string]float64{
+	"unknown":   0.0,
+	"low":       0.3,
+	"medium":    0.5,
+	"high":      0.8,
+	"very-high": 1.0,
+}
+
+// cefTimestampFormats are the date formats allowed for CEF rt/start/end and LEEF devTime
+var cefTimestampFormats = []string{
+	"Jan 2 2006 15:04:05.000 MST",
+	"Jan 2 2006 15:04:05 MST",
+	"Jan 2 2006 15:04:05.000",
+	"Jan 2 2006 15:04:05",
+	"Jan 2 15:04:05.000 MST",
+	"Jan 2 15:04:05 MST",
+	"Jan 2 15:04:05.000",
+	"Jan 2 15:04:05",
+	time.RFC3339Nano,
+}
+
+// CEFParser implements the Parser interface for CEF and LEEF logs, with or without syslog
+// framing, and falls back to generic key=value extraction for FortiGate-style logs
+type CEFParser struct{}
+
+// kvRecord is a parsed CEF, LEEF or key=value line
+type kvRecord struct {
+	format   string // CEF, LEEF or KeyValue
+	vendor   string
+	product  string
+	version  string
+	sigID    string
+	name     string
+	severity string
+	keys     []string // Extension keys in file order
+	fields   map[string]string
+	sysTime  time.Time // Timestamp from the syslog header, if any
+	sysHost  string    // Hostname from the syslog header, if any
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *CEFParser) CanParse(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch ext {
+	case ".cef", ".leef":
+		return true
+	case ".csv", ".tsv", ".json", ".jsonl", ".ndjson", ".xml", ".evtx", ".db", ".sqlite", ".gz", ".zip":
+		return false
+	}
+
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+
+	nonEmpty := 0
+	kvLines := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		nonEmpty++
+		if cefHeaderPattern.MatchString(line) || leefHeaderPattern.MatchString(line) {
+			return true
+		}
+		if isKeyValueLine(line) {
+			kvLines++
+		}
+	}
+
+	// Key=value detection is weaker, so require it on most lines
+	return kvLines > 0 && kvLines*2 >= nonEmpty
+}
+
+// isKeyValueLine checks if a line looks like a security appliance key=value log
+func isKeyValueLine(line string) bool {
+	matches := kvPairPattern.FindAllStringSubmatch(syslogPriPattern.ReplaceAllString(line, ""), -1)
+	if len(matches) < kvMinPairs {
+		return false
+	}
+	keys := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		keys[m[1]] = true
+	}
+	for _, signature := range kvVendorSignatures {
+		matched := true
+		for _, key := range signature {
+			matched = matched && keys[key]
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a CEF/LEEF/key=value log file and returns a slice of events
+func (p *CEFParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	// Pre-allocate slice with estimated capacity (avg 400 bytes per CEF line)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 400))
+	lineNum := 0
+	source := filepath.Base(filePath)
+	years := newYearInferrer(filePath)
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Truncate line before regex matching to prevent ReDoS
+		lineForRegex := truncateLine(line)
+
+		var record *kvRecord
+		if loc := cefHeaderPattern.FindStringIndex(lineForRegex); loc != nil {
+			record = parseCEFLine(lineForRegex[loc[0]:])
+			record.sysTime, record.sysHost = parseSyslogPrefix(lineForRegex[:loc[0]], yearlessPlaceholder)
+		} else if loc := leefHeaderPattern.FindStringIndex(lineForRegex); loc != nil {
+			record = parseLEEFLine(lineForRegex[loc[0]:])
+			record.sysTime, record.sysHost = parseSyslogPrefix(lineForRegex[:loc[0]], yearlessPlaceholder)
+		} else if record = parseKeyValueLine(lineForRegex); record != nil {
+			record.sysTime, record.sysHost = parseSyslogPrefix(lineForRegex, yearlessPlaceholder)
+		}
+
+		if record == nil {
+			events = append(events, core.NewEvent(
+				time.Time{},
+				source,
+				"CEFRaw",
+				lineNum,
+				"",
+				"",
+				line,
+				filePath,
+			))
+			continue
+		}
+
+		event := record.toEvent(source, filePath, lineNum, yearlessPlaceholder)
+		years.add(event)
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	years.apply()
+
+	fmt.Printf("Parsed CEF/LEEF/key=value file: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// parseSyslogPrefix extracts the timestamp and hostname from a syslog header
+// Year-less RFC 3164 timestamps are given the year passed in (yearlessPlaceholder when a yearInferrer fills it in)
+func parseSyslogPrefix(prefix string, year int) (time.Time, string) {
+	prefix = strings.TrimSpace(syslogPriPattern.ReplaceAllString(prefix, ""))
+	if prefix == "" {
+		return time.Time{}, ""
+	}
+
+	var timestamp time.Time
+	var host string
+	if m := syslogISOPrefixPattern.FindStringSubmatch(prefix); m != nil {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999-0700", "2006-01-02T15:04:05.999999999"} {
+			if t, err := parseLogTime(layout, m[1]); err == nil {
+				timestamp = t
+				break
+			}
+		}
+		host = m[2]
+	} else if m := syslogBSDPrefixPattern.FindStringSubmatch(prefix); m != nil {
+		timestamp = parseBSDTimestamp(m[1], year)
+		host = m[2]
+	}
+
+	// A program tag (e.g., "CEF:" or "fortigate:") is not a hostname
+	if strings.HasSuffix(host, ":") || strings.Contains(host, "=") {
+		host = ""
+	}
+	return timestamp, host
+}
+
+// parseBSDTimestamp parses "Jan 2 15:04:05" or "Jan 2 2006 15:04:05" with collapsed spacing
+func parseBSDTimestamp(value string, year int) time.Time {
+	value = strings.Join(strings.Fields(value), " ")
+	if t, err := parseLogTime("Jan 2 2006 15:04:05", value); err == nil {
+		return t
+	}
+	if t, err := parseLogTime("2006 Jan 2 15:04:05", fmt.Sprintf("%04d %s", year, value)); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// splitEscaped splits s on sep, honoring backslash escapes, for at most n fields
+// The last field is returned unsplit and unescaped
+func splitEscaped(s string, sep byte, n int) []string {
+	var fields []string
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		if len(fields) == n-1 {
+			fields = append(fields, s[i:])
+			return fields
+		}
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && (s[i+1] == sep || s[i+1] == '\\') {
+			current.WriteByte(s[i+1])
+			i++
+			continue
+		}
+		if c == sep {
+			fields = append(fields, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	return append(fields, current.String())
+}
+
+// parseCEFLine parses a line starting at "CEF:"
+// Format: CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func parseCEFLine(line string) *kvRecord {
+	header := splitEscaped(strings.TrimPrefix(line, "CEF:"), '|', 8)
+	for len(header) < 8 {
+		header = append(header, "")
+	}
+
+	record := &kvRecord{
+		format:   "CEF",
+		version:  header[0],
+		vendor:   header[1],
+		product:  header[2],
+		sigID:    header[4],
+		name:     header[5],
+		severity: header[6],
+	}
+	record.keys, record.fields = parseCEFExtension(header[7])
+	return record
+}
+
+// parseCEFExtension parses space-separated key=value pairs whose values may contain spaces
+// A value ends where the next " key=" begins; '=' and '\' are escaped in values
+func parseCEFExtension(ext string) ([]string, map[string]string) {
+	var keys []string
+	fields := make(map[string]string)
+
+	// Locate unescaped '=' signs; each one ends a key
+	var eqPositions []int
+	for i := 0; i < len(ext); i++ {
+		if ext[i] == '\\' {
+			i++
+			continue
+		}
+		if ext[i] == '=' {
+			eqPositions = append(eqPositions, i)
+		}
+	}
+
+	type span struct{ keyStart, eq int }
+	var spans []span
+	for _, eq := range eqPositions {
+		loc := cefExtKeyPattern.FindStringIndex(ext[:eq])
+		if loc == nil {
+			continue
+		}
+		// Keys must start the extension or follow whitespace; otherwise '=' is part of a value
+		if loc[0] > 0 && ext[loc[0]-1] != ' ' && ext[loc[0]-1] != '\t' {
+			continue
+		}
+		spans = append(spans, span{loc[0], eq})
+	}
+
+	for i, s := range spans {
+		end := len(ext)
+		if i+1 < len(spans) {
+			end = spans[i+1].keyStart
+		}
+		key := ext[s.keyStart:s.eq]
+		value := unescapeCEFValue(strings.TrimRight(ext[s.eq+1:end], " \t"))
+		if _, exists := fields[key]; !exists {
+			keys = append(keys, key)
+		}
+		fields[key] = value
+	}
+
+	return keys, fields
+}
+
+// unescapeCEFValue resolves CEF extension escapes (\= \\ \n \r)
+func unescapeCEFValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// parseLEEFLine parses a line starting at "LEEF:"
+// LEEF 1.0: LEEF:1.0|Vendor|Product|Version|EventID|<tab-separated attributes>
+// LEEF 2.0: LEEF:2.0|Vendor|Product|Version|EventID|DelimiterChar|<attributes>
+func parseLEEFLine(line string) *kvRecord {
+	header := splitEscaped(strings.TrimPrefix(line, "LEEF:"), '|', 6)
+	for len(header) < 6 {
+		header = append(header, "")
+	}
+
+	record := &kvRecord{
+		format:  "LEEF",
+		version: header[0],
+		vendor:  header[1],
+		product: header[2],
+		sigID:   header[4],
+		fields:  make(map[string]string),
+	}
+
+	attrs := header[5]
+	delimiter := "\t"
+	if strings.HasPrefix(record.version, "2") {
+		// LEEF 2.0 carries the delimiter as a character or hex value (e.g., ^ or x5E)
+		delimField, rest, found := strings.Cut(attrs, "|")
+		if found {
+			attrs = rest
+			delimiter = parseLEEFDelimiter(delimField)
+		}
+	}
+
+	for _, attr := range strings.Split(attrs, delimiter) {
+		key, value, found := strings.Cut(attr, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			continue
+		}
+		if _, exists := record.fields[key]; !exists {
+			record.keys = append(record.keys, key)
+		}
+		record.fields[key] = value
+	}
+
+	record.severity = record.fields["sev"]
+	record.name = record.fields["cat"]
+	return record
+}
+
+// parseLEEFDelimiter decodes a LEEF 2.0 delimiter field
+func parseLEEFDelimiter(field string) string {
+	if field == "" {
+		return "\t"
+	}
+	lower := strings.ToLower(field)
+	if strings.HasPrefix(lower, "0x") || (strings.HasPrefix(lower, "x") && len(lower) > 1) {
+		if code, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(lower, "0x"), "x"), 16, 8); err == nil {
+			return string(rune(code))
+		}
+	}
+	return field
+}
+
+// parseKeyValueLine parses a generic key=value line, returning nil if it has too few pairs
+func parseKeyValueLine(line string) *kvRecord {
+	matches := kvPairPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) < 2 {
+		return nil
+	}
+
+	record := &kvRecord{format: "KeyValue", fields: make(map[string]string, len(matches))}
+	for _, m := range matches {
+		key, value := m[1], m[2]
+		if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+		}
+		if _, exists := record.fields[key]; !exists {
+			record.keys = append(record.keys, key)
+		}
+		record.fields[key] = value
+	}
+
+	record.sigID = record.fields["logid"]
+	record.severity = firstNonEmpty(record.fields, "level", "severity", "pri")
+	record.vendor = firstNonEmpty(record.fields, "devname", "product", "hostname")
+	if t := record.fields["type"]; t != "" {
+		record.name = t
+		if st := record.fields["subtype"]; st != "" {
+			record.name += "/" + st
+		}
+	}
+	return record
+}
+
+// firstNonEmpty returns the first non-empty value among the given keys
+func firstNonEmpty(fields map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := fields[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// timestamp determines the event time from the record's own fields, falling back to the syslog header
+func (r *kvRecord) timestamp(year int) time.Time {
+	// CEF receipt time / LEEF device time
+	if rt := firstNonEmpty(r.fields, "rt", "devTime", "start", "end"); rt != "" {
+		if layout := r.fields["devTimeFormat"]; layout != "" {
+			if t, err := parseLogTime(javaToGoLayout(layout), rt); err == nil {
+				return t
+			}
+		}
+		for _, layout := range cefTimestampFormats {
+			if t, err := parseLogTime(layout, rt); err == nil {
+				if t.Year() == 0 {
+					t = t.AddDate(year, 0, 0)
+				}
+				return t
+			}
+		}
+		if t, _ := parseTimestamp(rt, ""); !t.IsZero() {
+			return t
+		}
+	}
+
+	// FortiGate eventtime (seconds, microseconds or nanoseconds depending on firmware)
+	if et := r.fields["eventtime"]; isNumeric(et) {
+		if epoch, err := strconv.ParseInt(et, 10, 64); err == nil {
+			switch {
+			case epoch > 1e17:
+				return time.Unix(0, epoch).UTC()
+			case epoch > 1e14:
+				return time.UnixMicro(epoch).UTC()
+			case epoch > 1e11:
+				return time.UnixMilli(epoch).UTC()
+			default:
+				return time.Unix(epoch, 0).UTC()
+			}
+		}
+	}
+
+	// FortiGate/Sophos date= time= pair with optional tz=
+	if date, clock := r.fields["date"], r.fields["time"]; date != "" && clock != "" {
+		value := date + " " + clock
+		if tz := r.fields["tz"]; tz != "" {
+			if t, err := parseLogTime("2006-01-02 15:04:05 -0700", value+" "+tz); err == nil {
+				return t
+			}
+		}
+		if t, err := parseLogTime("2006-01-02 15:04:05", value); err == nil {
+			return t
+		}
+	}
+	if t := r.fields["time"]; t != "" {
+		if ts, _ := parseTimestamp(t, ""); !ts.IsZero() {
+			return ts
+		}
+	}
+
+	return r.sysTime
+}
+
+// javaToGoLayout converts the common Java SimpleDateFormat tokens used by LEEF devTimeFormat
+func javaToGoLayout(layout string) string {
+	replacer := strings.NewReplacer(
+		"yyyy", "2006", "MMM", "Jan", "MM", "01", "dd", "02",
+		"HH", "15", "mm", "04", "ss", "05", "SSS", "000", "z", "MST", "Z", "-0700",
+	)
+	return replacer.Replace(layout)
+}
+
+// score converts the record severity to a normalized 0.0-1.0 score
+func (r *kvRecord) score() float64 {
+	sev := strings.ToLower(strings.TrimSpace(r.severity))
+	if sev == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(sev); err == nil {
+		if n < 0 {
+			n = 0
+		} else if n > 10 {
+			n = 10
+		}
+		return float64(n) / 10.0
+	}
+	if s, ok := cefSeverityScores[sev]; ok {
+		return s
+	}
+	// Syslog-style levels used by FortiGate and others
+	switch sev {
+	case "emergency", "alert", "critical":
+		return 1.0
+	case "error":
+		return 0.7
+	case "warning":
+		return 0.5
+	case "notice":
+		return 0.3
+	}
+	return 0
+}
+
+// toEvent converts the record to a timeline event, mapping src, dst, suser, act and rt
+func (r *kvRecord) toEvent(source, filePath string, lineNum, year int) *core.Event {
+	f := r.fields
+	src := firstNonEmpty(f, "src", "srcip", "src_ip", "sourceAddress", "c-ip")
+	dst := firstNonEmpty(f, "dst", "dstip", "dst_ip", "destinationAddress")
+	srcPort, _ := strconv.Atoi(firstNonEmpty(f, "spt", "srcport", "srcPort", "src_port", "s_port"))
+	dstPort, _ := strconv.Atoi(firstNonEmpty(f, "dpt", "dstport", "dstPort", "dst_port", "service_port"))
+	user := firstNonEmpty(f, "suser", "duser", "usrName", "user", "srcuser", "dstuser", "src_user_name")
+	action := firstNonEmpty(f, "act", "action", "outcome")
+	proto := firstNonEmpty(f, "proto", "app")
+	host := firstNonEmpty(f, "shost", "src", "srcip", "src_ip", "dvchost", "devname")
+	if host == "" {
+		host = r.sysHost
+	}
+
+	// Keys already reflected in the summary part of the message
+	used := map[string]bool{
+		"rt": true, "devTime": true, "devTimeFormat": true, "date": true, "time": true, "tz": true, "eventtime": true,
+		"src": true, "srcip": true, "src_ip": true, "sourceAddress": true,
+		"dst": true, "dstip": true, "dst_ip": true, "destinationAddress": true,
+		"spt": true, "srcport": true, "srcPort": true, "src_port": true,
+		"dpt": true, "dstport": true, "dstPort": true, "dst_port": true,
+		"act": true, "action": true, "proto": true, "sev": true, "cat": true,
+		"logid": true, "type": true, "subtype": true, "level": true,
+	}
+
+	var msgParts []string
+	if r.vendor != "" || r.product != "" {
+		msgParts = append(msgParts, fmt.Sprintf("[%s]", strings.TrimSpace(r.vendor+" "+r.product)))
+	}
+	if r.name != "" {
+		msgParts = append(msgParts, r.name)
+	}
+	if action != "" {
+		msgParts = append(msgParts, fmt.Sprintf("act=%s", action))
+	}
+	if src != "" || dst != "" {
+		conn := formatConnection(src, srcPort, dst, dstPort)
+		if proto != "" {
+			conn += fmt.Sprintf(" [%s]", proto)
+		}
+		msgParts = append(msgParts, conn)
+	}
+	var sigParts []string
+	if r.sigID != "" {
+		sigParts = append(sigParts, "sig="+r.sigID)
+	}
+	if r.severity != "" {
+		sigParts = append(sigParts, "sev="+r.severity)
+	}
+	if len(sigParts) > 0 {
+		msgParts = append(msgParts, "("+strings.Join(sigParts, " ")+")")
+	}
+	for _, key := range r.keys {
+		if used[key] || f[key] == "" {
+			continue
+		}
+		msgParts = append(msgParts, fmt.Sprintf("%s=%s", key, f[key]))
+	}
+
+	eventID, err := strconv.Atoi(r.sigID)
+	if err != nil {
+		eventID = lineNum
+	}
+
+	event := core.NewEvent(
+		r.timestamp(year),
+		source,
+		r.format,
+		eventID,
+		user,
+		host,
+		strings.Join(msgParts, " "),
+		filePath,
+	)
+	event.Score = r.score()
+	return event
+}
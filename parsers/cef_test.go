@@ -0,0 +1,110 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseLEEFDelimiter(t *testing.T) {
+	tests := map[string]string{
+		"":     "\t",
+		"^":    "^",
+		"x5E":  "^",
+		"0x7c": "|",
+		"0x09": "\t",
+		"x":    "x",
+		"0x0":  "\x00",
+		"0x00": "\x00",
+		"x00":  "\x00",
+	}
+	for field, want := range tests {
+		if got := parseLEEFDelimiter(field); got != want {
+			t.Errorf("parseLEEFDelimiter(%q): expected %q, got %q", field, want, got)
+		}
+	}
+}
+
+func TestParseLEEFLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"LEEF 1.0 tab", "LEEF:1.0|IBM|QRadar|7.5|4625|cat=Logon\tsev=7\tsrc=10.0.0.5\tusrName=jdoe"},
+		{"LEEF 2.0 caret", "LEEF:2.0|IBM|QRadar|7.5|4625|^|cat=Logon^sev=7^src=10.0.0.5^usrName=jdoe"},
+		{"LEEF 2.0 hex", "LEEF:2.0|IBM|QRadar|7.5|4625|x5E|cat=Logon^sev=7^src=10.0.0.5^usrName=jdoe"},
+		{"LEEF 2.0 hex pipe", "LEEF:2.0|IBM|QRadar|7.5|4625|0x7c|cat=Logon|sev=7|src=10.0.0.5|usrName=jdoe"},
+	}
+	for _, tt := range tests {
+		record := parseLEEFLine(tt.line)
+		if record.vendor != "IBM" || record.sigID != "4625" || record.name != "Logon" || record.severity != "7" {
+			t.Errorf("%s: unexpected header vendor=%q sig=%q name=%q sev=%q", tt.name, record.vendor, record.sigID, record.name, record.severity)
+		}
+		if record.fields["src"] != "10.0.0.5" || record.fields["usrName"] != "jdoe" || len(record.keys) != 4 {
+			t.Errorf("%s: unexpected attributes %v", tt.name, record.fields)
+		}
+	}
+
+	// A LEEF 1.0 line is split on tabs only, even if the attributes contain '^'
+	record := parseLEEFLine("LEEF:1.0|IBM|QRadar|7.5|4625|cat=Logon^sev=7")
+	if record.fields["cat"] != "Logon^sev=7" {
+		t.Errorf("LEEF 1.0: expected cat to keep the caret, got %v", record.fields)
+	}
+}
+
+func TestParseCEFLineEscapes(t *testing.T) {
+	record := parseCEFLine(`CEF:0|Acme|Fire\|Wall|1.0|100|Blocked \| denied|5|src=10.0.0.5 msg=a\=b c\\d request=http://example.com/?q\=1 cs1=x`)
+
+	if record.product != "Fire|Wall" || record.name != "Blocked | denied" || record.severity != "5" {
+		t.Errorf("Unexpected header product=%q name=%q sev=%q", record.product, record.name, record.severity)
+	}
+	tests := map[string]string{
+		"src":     "10.0.0.5",
+		"msg":     `a=b c\d`,
+		"request": "http://example.com/?q=1",
+		"cs1":     "x",
+	}
+	for key, want := range tests {
+		if got := record.fields[key]; got != want {
+			t.Errorf("%s: expected %q, got %q", key, want, got)
+		}
+	}
+	if len(record.keys) != len(tests) {
+		t.Errorf("Expected %d keys, got %v", len(tests), record.keys)
+	}
+}
+
+func TestCEFReceiptTimeEpochMillis(t *testing.T) {
+	record := parseCEFLine("CEF:0|Acme|Fire|1.0|100|Blocked|5|rt=1682091045123 src=10.0.0.5")
+
+	want := time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC)
+	if got := record.timestamp(2023); !got.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestCEFParserKeyValueSignature(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"FortiGate", `date=2023-04-21 time=15:30:45 devname="FGT60E" logid="0000000013" type="traffic" srcip=10.0.0.5 dstip=192.0.2.10 action="deny"`, true},
+		{"FortiGate syslog", `<189>date=2023-04-21 time=15:30:45 logid="0000000013" type="traffic" srcip=10.0.0.5 dstip=192.0.2.10 action="deny"`, true},
+		{"Generic key=value", `user=jdoe action=login status=ok src=10.0.0.5 dst=192.0.2.10 duration=5`, false},
+		{"Date without vendor key", `date=2023-04-21 time=15:30:45 user=jdoe action=login status=ok src=10.0.0.5`, false},
+	}
+	dir := t.TempDir()
+	for i, tt := range tests {
+		// File headers are cached by path, so each case gets its own file
+		filePath := filepath.Join(dir, fmt.Sprintf("device%d.log", i))
+		if err := os.WriteFile(filePath, []byte(tt.content+"\n"+tt.content+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", filePath, err)
+		}
+		if got := (&CEFParser{}).CanParse(filePath); got != tt.want {
+			t.Errorf("%s: expected CanParse %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
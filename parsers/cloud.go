@@ -196,7 +196,7 @@ func (p *CloudTrailParser) processCloudTrailEvent(rawEvent map[string]interface{
 	// Extract timestamp (eventTime format: "2023-04-21T15:30:45Z")
 	timestamp := time.Time{}
 	if tsVal, ok := rawEvent["eventTime"].(string); ok {
-		if parsed, err := time.Parse(time.RFC3339, tsVal); err == nil {
+		if parsed, err := parseLogTime(time.RFC3339, tsVal); err == nil {
 			timestamp = parsed
 		}
 	}
@@ -460,12 +460,12 @@ func (p *AzureActivityParser) processAzureEvent(rawEvent map[string]interface{},
 	timestamp := time.Time{}
 	for _, tsField := range []string{"time", "eventTimestamp", "submissionTimestamp"} {
 		if tsVal, ok := rawEvent[tsField].(string); ok && tsVal != "" {
-			if parsed, err := time.Parse(time.RFC3339, tsVal); err == nil {
+			if parsed, err := parseLogTime(time.RFC3339, tsVal); err == nil {
 				timestamp = parsed
 				break
 			}
 			// Try alternate ISO8601 formats
-			if parsed, err := time.Parse("2006-01-02T15:04:05.9999999Z", tsVal); err == nil {
+			if parsed, err := parseLogTime("2006-01-02T15:04:05.9999999Z", tsVal); err == nil {
 				timestamp = parsed
 				break
 			}
@@ -737,16 +737,16 @@ func (p *GCPAuditParser) processGCPEvent(rawEvent map[string]interface{}, filePa
 	// Extract timestamp
 	timestamp := time.Time{}
 	if tsVal, ok := rawEvent["timestamp"].(string); ok && tsVal != "" {
-		if parsed, err := time.Parse(time.RFC3339, tsVal); err == nil {
+		if parsed, err := parseLogTime(time.RFC3339, tsVal); err == nil {
 			timestamp = parsed
-		} else if parsed, err := time.Parse(time.RFC3339Nano, tsVal); err == nil {
+		} else if parsed, err := parseLogTime(time.RFC3339Nano, tsVal); err == nil {
 			timestamp = parsed
 		}
 	}
 	// Fallback to receiveTimestamp
 	if timestamp.IsZero() {
 		if tsVal, ok := rawEvent["receiveTimestamp"].(string); ok && tsVal != "" {
-			if parsed, err := time.Parse(time.RFC3339, tsVal); err == nil {
+			if parsed, err := parseLogTime(time.RFC3339, tsVal); err == nil {
 				timestamp = parsed
 			}
 		}
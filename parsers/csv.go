@@ -6,7 +6,6 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -125,7 +124,7 @@ func (p *CSVArtifactParser) CanParse(filePath string) bool {
 // Parse parses a CSV file and returns a slice of events
 func (p *CSVArtifactParser) Parse(filePath string) ([]*core.Event, error) {
 	// Open the file
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -354,8 +353,8 @@ func parseTimestamp(value string, preferredFormat string) (time.Time, string) {
 
 	// Try preferred format first if we have one
 	if preferredFormat != "" {
-		if t, err := time.Parse(preferredFormat, value); err == nil {
-			return t.UTC(), preferredFormat
+		if t, err := parseLogTime(preferredFormat, value); err == nil {
+			return t, preferredFormat
 		}
 	}
 
@@ -389,8 +388,8 @@ func parseTimestamp(value string, preferredFormat string) (time.Time, string) {
 
 	// Try all known formats
 	for _, format := range csvTimestampFormats {
-		if t, err := time.Parse(format, value); err == nil {
-			return t.UTC(), format
+		if t, err := parseLogTime(format, value); err == nil {
+			return t, format
 		}
 	}
 
@@ -405,8 +404,8 @@ func parseTimestamp(value string, preferredFormat string) (time.Time, string) {
 			"2006-01-02 15:04:05.000 -0700",
 		}
 		for _, format := range formats {
-			if t, err := time.Parse(format, value); err == nil {
-				return t.UTC(), format
+			if t, err := parseLogTime(format, value); err == nil {
+				return t, format
 			}
 		}
 	}
@@ -0,0 +1,397 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"LogZero/core"
+)
+
+// CustomParserConfig declares a user-defined line parser in a YAML or TOML file
+//
+//	parsers:
+//	  - name: acme-billing
+//	    globs: ["billing-*.log"]
+//	    header_regex: ['^\d{4}-\d{2}-\d{2}T\S+ \[(INFO|WARN|ERROR)\]']
+//	    grok: '%{TIMESTAMP_ISO8601:ts} \[%{LOGLEVEL:level}\] %{USERNAME:user}@%{IP:client} %{GREEDYDATA:msg}'
+//	    timestamp_layout: "2006-01-02T15:04:05"
+//	    timezone: Europe/Berlin
+//	    multiline_start: '^\d{4}-\d{2}-\d{2}T'
+//	    fields: {timestamp: ts, user: user, host: client, message: msg}
+type CustomParserConfig struct {
+	Name             string            `yaml:"name" toml:"name"`
+	Globs            []string          `yaml:"globs" toml:"globs"`
+	HeaderRegex      []string          `yaml:"header_regex" toml:"header_regex"`
+	MinHeaderMatches int               `yaml:"min_header_matches" toml:"min_header_matches"`
+	LineRegex        string            `yaml:"line_regex" toml:"line_regex"`
+	Grok             string            `yaml:"grok" toml:"grok"`
+	MultilineStart   string            `yaml:"multiline_start" toml:"multiline_start"`
+	Patterns         map[string]string `yaml:"patterns" toml:"patterns"`
+	Fields           map[string]string `yaml:"fields" toml:"fields"`
+	TimestampLayout  string            `yaml:"timestamp_layout" toml:"timestamp_layout"`
+	Timezone         string            `yaml:"timezone" toml:"timezone"`
+	TimestampDesc    string            `yaml:"timestamp_desc" toml:"timestamp_desc"`
+	EventType        string            `yaml:"event_type" toml:"event_type"`
+	Tags             []string          `yaml:"tags" toml:"tags"`
+	SkipUnmatched    bool              `yaml:"skip_unmatched" toml:"skip_unmatched"`
+}
+
+// customParserFile is the top-level layout of a parser definition file
+type customParserFile struct {
+	Parsers []CustomParserConfig `yaml:"parsers" toml:"parsers"`
+}
+
+// customEventFields are the core.Event fields a capture can be mapped to
+var customEventFields = map[string]bool{
+	"timestamp": true, "source": true, "event_type": true, "event_id": true,
+	"user": true, "host": true, "message": true,
+}
+
+// CustomParser implements the Parser interface for a parser defined in a config file
+type CustomParser struct {
+	config   CustomParserConfig
+	origin   string // Definition file the parser was loaded from
+	headers  []*regexp.Regexp
+	pattern  *regexp.Regexp
+	isStart  func(line string) bool
+	location *time.Location
+}
+
+// customParsers is set once at startup (before parsing begins) by LoadCustomParsers
+var customParsers []*CustomParser
+
+// LoadCustomParsers loads parser definitions from YAML/TOML files or directories of them
+// The loaded set replaces any previous one; no paths clears it.
+func LoadCustomParsers(paths []string) error {
+	var loaded []*CustomParser
+	names := make(map[string]string)
+
+	for _, p := range paths {
+		files, err := customParserFiles(p)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			configs, err := readCustomParserFile(file)
+			if err != nil {
+				return err
+			}
+			for _, config := range configs {
+				if previous, exists := names[config.Name]; exists {
+					return fmt.Errorf("%s: parser %q is already defined in %s", file, config.Name, previous)
+				}
+				parser, err := newCustomParser(config, file)
+				if err != nil {
+					return fmt.Errorf("%s: %w", file, err)
+				}
+				names[config.Name] = file
+				loaded = append(loaded, parser)
+			}
+		}
+	}
+
+	customParsers = loaded
+	return nil
+}
+
+// customParserFiles expands a path into definition files, sorted for a stable detection order
+func customParserFiles(p string) ([]string, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parser config: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{p}, nil
+	}
+
+	entries, err := os.ReadDir(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parser config directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".toml":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(p, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// decodeConfigFile decodes a YAML or TOML config file by extension, rejecting unknown keys
+func decodeConfigFile(file string, v interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".toml":
+		meta, err := toml.Decode(string(data), v)
+		if err != nil {
+			return fmt.Errorf("%s: invalid TOML: %w", file, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("%s: unknown key %q", file, undecoded[0].String())
+		}
+	case ".yaml", ".yml":
+		if err := yaml.UnmarshalStrict(data, v); err != nil {
+			return fmt.Errorf("%s: invalid YAML: %w", file, err)
+		}
+	default:
+		return fmt.Errorf("%s: config must be .yaml, .yml or .toml", file)
+	}
+	return nil
+}
+
+// readCustomParserFile decodes a YAML or TOML definition file by extension
+func readCustomParserFile(file string) ([]CustomParserConfig, error) {
+	var definitions customParserFile
+	if err := decodeConfigFile(file, &definitions); err != nil {
+		return nil, err
+	}
+	if len(definitions.Parsers) == 0 {
+		return nil, fmt.Errorf("%s: no parsers defined", file)
+	}
+	return definitions.Parsers, nil
+}
+
+// newCustomParser validates a definition and compiles its patterns
+func newCustomParser(config CustomParserConfig, origin string) (*CustomParser, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("parser definition without a name")
+	}
+	if len(config.Globs) == 0 && len(config.HeaderRegex) == 0 {
+		return nil, fmt.Errorf("parser %q needs globs or header_regex for detection", config.Name)
+	}
+	if (config.LineRegex == "") == (config.Grok == "") {
+		return nil, fmt.Errorf("parser %q needs exactly one of line_regex or grok", config.Name)
+	}
+	for _, glob := range config.Globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("parser %q: invalid glob %q: %w", config.Name, glob, err)
+		}
+	}
+
+	parser := &CustomParser{config: config, origin: origin}
+	for _, expr := range config.HeaderRegex {
+		header, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("parser %q: invalid header_regex: %w", config.Name, err)
+		}
+		parser.headers = append(parser.headers, header)
+	}
+
+	var err error
+	if config.Grok != "" {
+		parser.pattern, err = compileGrok(config.Grok, config.Patterns)
+	} else {
+		parser.pattern, err = regexp.Compile(config.LineRegex)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parser %q: %w", config.Name, err)
+	}
+
+	parser.isStart = everyLineStartsRecord
+	if config.MultilineStart != "" {
+		start, err := regexp.Compile(config.MultilineStart)
+		if err != nil {
+			return nil, fmt.Errorf("parser %q: invalid multiline_start: %w", config.Name, err)
+		}
+		parser.isStart = start.MatchString
+	}
+
+	captures := make(map[string]bool)
+	for _, name := range parser.pattern.SubexpNames() {
+		captures[name] = true
+	}
+	for field, capture := range config.Fields {
+		if !customEventFields[field] {
+			return nil, fmt.Errorf("parser %q: unknown event field %q", config.Name, field)
+		}
+		if !captures[grokCaptureName(capture)] {
+			return nil, fmt.Errorf("parser %q: field %s maps to missing capture %q", config.Name, field, capture)
+		}
+	}
+
+	if config.Timezone != "" {
+		parser.location, err = loadTimezone(config.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("parser %q: invalid timezone: %w", config.Name, err)
+		}
+	}
+	if parser.config.EventType == "" {
+		parser.config.EventType = config.Name
+	}
+	if parser.config.MinHeaderMatches <= 0 {
+		parser.config.MinHeaderMatches = 1
+	}
+	return parser, nil
+}
+
+// Name returns the parser name from its definition
+func (p *CustomParser) Name() string {
+	return p.config.Name
+}
+
+// matchesGlob matches the globs against the base name, or against trailing path segments for globs with '/'
+func (p *CustomParser) matchesGlob(filePath string) bool {
+	for _, glob := range p.config.Globs {
+		if matchPathGlob(glob, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *CustomParser) CanParse(filePath string) bool {
+	if len(p.config.Globs) > 0 && !p.matchesGlob(filePath) {
+		return false
+	}
+	if len(p.headers) == 0 {
+		return true
+	}
+
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	matches := 0
+	for _, line := range lines {
+		for _, header := range p.headers {
+			if header.MatchString(line) {
+				matches++
+				break
+			}
+		}
+	}
+	return matches >= p.config.MinHeaderMatches
+}
+
+// Parse parses a file with the configured line pattern and returns a slice of events
+func (p *CustomParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
+	source := filepath.Base(filePath)
+	names := p.pattern.SubexpNames()
+	mapped := make(map[string]bool, len(p.config.Fields))
+	for _, capture := range p.config.Fields {
+		mapped[grokCaptureName(capture)] = true
+	}
+	timeFormat := ""
+
+	err := scanLogRecords(filePath, p.isStart, func(record *logRecord) {
+		matches := p.pattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			if !p.config.SkipUnmatched {
+				events = append(events, core.NewEvent(time.Time{}, source, p.config.EventType+"Raw", record.lineNum, "", "", record.withContinuation(record.line), filePath))
+			}
+			return
+		}
+
+		captures := make(map[string]string, len(names))
+		var extras []string
+		for i, name := range names {
+			if name == "" || matches[i] == "" {
+				continue
+			}
+			if _, seen := captures[name]; seen {
+				continue
+			}
+			captures[name] = matches[i]
+			if !mapped[name] {
+				extras = append(extras, fmt.Sprintf("%s=%s", name, matches[i]))
+			}
+		}
+		field := func(name string) string {
+			if capture, ok := p.config.Fields[name]; ok {
+				return captures[grokCaptureName(capture)]
+			}
+			return ""
+		}
+
+		var timestamp time.Time
+		if value := field("timestamp"); value != "" {
+			timestamp, timeFormat = p.parseTimestamp(value, timeFormat)
+		}
+
+		eventType := p.config.EventType
+		if v := field("event_type"); v != "" {
+			eventType = v
+		}
+		eventID := record.lineNum
+		if v := field("event_id"); v != "" {
+			if id, err := strconv.Atoi(v); err == nil {
+				eventID = id
+			}
+		}
+		eventSource := source
+		if v := field("source"); v != "" {
+			eventSource = v
+		}
+
+		// Unmapped captures are kept in the message so no extracted data is lost
+		msg := field("message")
+		if _, ok := p.config.Fields["message"]; !ok {
+			msg = record.line
+		} else if len(extras) > 0 {
+			msg = strings.TrimSpace(msg + " " + strings.Join(extras, " "))
+		}
+
+		event := core.NewEvent(timestamp, eventSource, eventType, eventID, field("user"), field("host"), record.withContinuation(msg), filePath)
+		event.Tags = append(event.Tags, p.config.Tags...)
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed %s file: %s (found %d events)\n", p.config.Name, filePath, len(events))
+	return events, nil
+}
+
+// parseTimestamp applies the configured layout; zone-less results are read in the configured timezone by NormalizeTimezones
+func (p *CustomParser) parseTimestamp(value, detected string) (time.Time, string) {
+	return parseLayoutTimestamp(p.config.TimestampLayout, value, detected)
+}
+
+// parseLayoutTimestamp parses a timestamp with a configured Go layout
+// Special layouts: unix, unix_ms, unix_us, unix_ns; an empty layout auto-detects common formats
+func parseLayoutTimestamp(layout, value, detected string) (time.Time, string) {
+	switch layout {
+	case "":
+		return parseTimestamp(value, detected)
+	case "unix", "unix_ms", "unix_us", "unix_ns":
+		// Integers are scaled exactly; a float64 cannot hold a 19-digit nanosecond epoch
+		unit := map[string]int64{"unix": 1e9, "unix_ms": 1e6, "unix_us": 1e3, "unix_ns": 1}[layout]
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Unix(0, n*unit).UTC(), detected
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, detected
+		}
+		whole, frac := math.Modf(f)
+		return time.Unix(0, int64(whole)*unit+int64(frac*float64(unit))).UTC(), detected
+	}
+
+	t, err := parseLogTime(layout, value)
+	if err != nil {
+		return time.Time{}, detected
+	}
+	return t, detected
+}
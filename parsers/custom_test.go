@@ -0,0 +1,188 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestCustomParsers(t *testing.T) {
+	dir := t.TempDir()
+	configDir := filepath.Join(dir, "parsers.d")
+	if err := os.Mkdir(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	yamlConfig := `parsers:
+  - name: acme-billing
+    globs: ["acme/billing-*.log"]
+    grok: '%{TIMESTAMP_ISO8601:ts} \[%{LOGLEVEL:level}\] %{USERNAME:user}@%{IP:client} %{GREEDYDATA:msg}'
+    timestamp_layout: "2006-01-02 15:04:05"
+    timezone: Europe/Berlin
+    event_type: AcmeBilling
+    tags: [acme]
+    fields:
+      timestamp: ts
+      user: user
+      host: client
+      message: msg
+`
+	tomlConfig := `[[parsers]]
+name = "door-badge"
+header_regex = ['^BADGE\|']
+line_regex = '^BADGE\|(?P<epoch>\d+)\|(?P<badge>\w+)\|(?P<door>[^|]+)\|(?P<result>\w+)$'
+timestamp_layout = "unix"
+skip_unmatched = true
+
+[parsers.fields]
+timestamp = "epoch"
+user = "badge"
+message = "door"
+`
+	files := map[string]string{
+		filepath.Join(configDir, "acme.yaml"):  yamlConfig,
+		filepath.Join(configDir, "badge.toml"): tomlConfig,
+		filepath.Join(dir, "acme", "billing-2023-04-21.log"): strings.Join([]string{
+			"2023-04-21 15:30:45 [WARN] jdoe@10.0.0.5 refund issued for invoice 4711",
+			"garbage line",
+		}, "\n"),
+		filepath.Join(dir, "doors.txt"): strings.Join([]string{
+			"BADGE|1682091045|B1234|Server Room|GRANTED",
+			"# rotated",
+		}, "\n"),
+	}
+	if err := os.Mkdir(filepath.Join(dir, "acme"), 0755); err != nil {
+		t.Fatalf("Failed to create log dir: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := LoadCustomParsers([]string{configDir}); err != nil {
+		t.Fatalf("Failed to load custom parsers: %v", err)
+	}
+	t.Cleanup(func() { LoadCustomParsers(nil) })
+
+	infos := ListParsers()
+	if len(infos) < 3 || infos[0].Name != "acme-billing" || !infos[0].Custom || infos[1].Name != "door-badge" || infos[2].Custom {
+		t.Fatalf("Expected custom parsers listed first, got %v", infos[:3])
+	}
+
+	billingPath := filepath.Join(dir, "acme", "billing-2023-04-21.log")
+	parser, err := GetParserForFile(billingPath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	events, err := parser.Parse(billingPath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	NormalizeTimezones(billingPath, parser, events)
+	event := events[0]
+	if event.EventType != "AcmeBilling" || event.User != "jdoe" || event.Host != "10.0.0.5" ||
+		event.Message != "refund issued for invoice 4711 level=WARN" || len(event.Tags) != 1 {
+		t.Errorf("Unexpected event: %s user=%q host=%q tags=%v %q", event.EventType, event.User, event.Host, event.Tags, event.Message)
+	}
+	if want := time.Date(2023, 4, 21, 13, 30, 45, 0, time.UTC); !event.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %s, got %s", want, event.Timestamp)
+	}
+	if event.Timezone != "Europe/Berlin" || event.TimezoneSource != core.TimezoneAssumed {
+		t.Errorf("Expected timezone assumed from the definition, got %q (%s)", event.Timezone, event.TimezoneSource)
+	}
+	if events[1].EventType != "AcmeBillingRaw" {
+		t.Errorf("Expected unmatched line as raw event, got %s", events[1].EventType)
+	}
+
+	doorsPath := filepath.Join(dir, "doors.txt")
+	parser, err = GetParserForFile(doorsPath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	events, err = parser.Parse(doorsPath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if events[0].EventType != "door-badge" || events[0].User != "B1234" || events[0].Message != "Server Room result=GRANTED" ||
+		!events[0].Timestamp.Equal(time.Unix(1682091045, 0)) {
+		t.Errorf("Unexpected event: %s user=%q %q at %s", events[0].EventType, events[0].User, events[0].Message, events[0].Timestamp)
+	}
+}
+
+func TestCustomParserValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		errMsg string
+	}{
+		{"no detection", "parsers:\n  - name: x\n    line_regex: '.*'\n", "needs globs or header_regex"},
+		{"missing capture", "parsers:\n  - name: x\n    globs: ['*.log']\n    line_regex: '(?P<a>.*)'\n    fields: {user: b}\n", "missing capture"},
+		{"unknown grok", "parsers:\n  - name: x\n    globs: ['*.log']\n    grok: '%{NOPE:a}'\n", "unknown grok pattern"},
+		{"unknown key", "parsers:\n  - name: x\n    globs: ['*.log']\n    line_regex: '.*'\n    colour: red\n", "invalid YAML"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "bad.yaml")
+		if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		err := LoadCustomParsers([]string{path})
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.errMsg, err)
+		}
+	}
+	if len(customParsers) != 0 {
+		t.Errorf("Expected failed loads to leave no custom parsers, got %d", len(customParsers))
+	}
+}
+
+func TestBuiltinParserNamesListed(t *testing.T) {
+	listed := make(map[string]int)
+	for _, info := range ListParsers() {
+		listed[info.Name]++
+	}
+	for name, count := range listed {
+		if count != 1 {
+			t.Errorf("%s is listed %d times", name, count)
+		}
+	}
+	for name := range builtinTimestampDescs {
+		if listed[name] == 0 {
+			t.Errorf("Timestamp description for unlisted parser %s", name)
+		}
+	}
+	for name := range builtinParserTimezones {
+		if listed[name] == 0 {
+			t.Errorf("Timezone for unlisted parser %s", name)
+		}
+	}
+}
+
+func TestParseLayoutTimestampEpochs(t *testing.T) {
+	tests := []struct {
+		layout, value string
+		want          time.Time
+	}{
+		{"unix", "1682091045", time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC)},
+		{"unix", "1682091045.25", time.Date(2023, 4, 21, 15, 30, 45, 250000000, time.UTC)},
+		{"unix_ms", "1682091045123", time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC)},
+		{"unix_us", "1682091045123456", time.Date(2023, 4, 21, 15, 30, 45, 123456000, time.UTC)},
+		{"unix_ns", "1682091045123456789", time.Date(2023, 4, 21, 15, 30, 45, 123456789, time.UTC)},
+		{"unix_ns", "not a number", time.Time{}},
+	}
+	for _, tt := range tests {
+		if got, _ := parseLayoutTimestamp(tt.layout, tt.value, ""); !got.Equal(tt.want) {
+			t.Errorf("%s %s: expected %s, got %s", tt.layout, tt.value, tt.want, got)
+		}
+	}
+}
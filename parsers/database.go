@@ -0,0 +1,699 @@
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for database server logs
+var (
+	// MySQL general query log, 5.7+ and 5.6 (the 5.6 timestamp only appears when the second changes)
+	// Example: 2023-04-21T15:30:45.123456Z	   12 Connect	root@localhost on shop using TCP/IP
+	// Example: 230421 15:30:45	   12 Query	SELECT * FROM customers
+	mysqlGeneralPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})?|\d{6}\s+\d{1,2}:\d{2}:\d{2})?\s+(\d+)\s+(Connect|Query|Quit|Init DB|Execute|Prepare|Close stmt|Reset stmt|Field List|Change user|Statistics|Shutdown|Kill|Ping|Refresh|Processlist|Set option|Fetch|Binlog Dump|Connect Out|Long Data|Debug|Daemon)\t?(.*)$`)
+
+	// MySQL general log Connect argument: root@10.0.0.5 on shop using TCP/IP
+	mysqlConnectPattern = regexp.MustCompile(`^(\S+)@(\S+)(?: as \S+)?(?: on (\S*))?`)
+
+	// MySQL error log, 8.0 / 5.7 / 5.5
+	// Example: 2023-04-21T15:30:45.123456Z 12 [Warning] [MY-010055] [Server] IP address '10.0.0.5' could not be resolved
+	// Example: 230421 15:30:45 [Note] /usr/sbin/mysqld: ready for connections.
+	mysqlErrorPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})?|\d{6}\s+\d{1,2}:\d{2}:\d{2})\s+(?:(\d+)\s+)?\[(Note|Warning|ERROR|Error|System)\]\s+(?:\[(MY-\d+)\]\s+)?(?:\[(\w+)\]\s+)?(.*)$`)
+
+	// Access denied for user 'root'@'10.0.0.5' (using password: YES)
+	mysqlAccessDeniedPattern = regexp.MustCompile(`Access denied for user '([^']*)'@'([^']*)'`)
+
+	// MySQL slow query log header lines
+	mysqlSlowTimePattern    = regexp.MustCompile(`^# Time:\s+(.+)$`)
+	mysqlSlowUserPattern    = regexp.MustCompile(`^# User@Host:\s+(\S+?)(?:\[[^\]]*\])?\s+@\s+(\S*)\s*\[([^\]]*)\](?:\s+Id:\s+(\d+))?`)
+	mysqlSlowStatsPattern   = regexp.MustCompile(`^# Query_time:\s+([\d.]+)\s+Lock_time:\s+([\d.]+)\s+Rows_sent:\s+(\d+)\s+Rows_examined:\s+(\d+)`)
+	mysqlSlowSchemaPattern  = regexp.MustCompile(`^# Schema:\s+(\S+)`)
+	mysqlSlowUsePattern     = regexp.MustCompile(`(?i)^use\s+` + "`?" + `([^;` + "`" + `]+)` + "`?" + `;$`)
+	mysqlSlowSetTimePattern = regexp.MustCompile(`(?i)^SET timestamp=(\d+);$`)
+
+	// PostgreSQL message after the log_line_prefix: LOG:  connection authorized: user=app database=shop
+	postgresMessagePattern = regexp.MustCompile(`(LOG|ERROR|FATAL|PANIC|WARNING|NOTICE|INFO|DEBUG\d?|DETAIL|HINT|STATEMENT|CONTEXT|QUERY|LOCATION):\s+(.*)$`)
+
+	// log_line_prefix escapes: %m, %p, %% ...
+	postgresEscapePattern = regexp.MustCompile(`%(?:\d+)?[a-zA-Z%]`)
+
+	// user=app database=shop host=10.0.0.5 in PostgreSQL connection messages
+	postgresKeyValuePattern = regexp.MustCompile(`\b(user|database|host)=("[^"]*"|\S+)`)
+
+	// password authentication failed for user "app", no pg_hba.conf entry for host "10.0.0.5", user "app", database "shop"
+	postgresAuthFailurePattern = regexp.MustCompile(`(?:password|Ident|Peer|LDAP|certificate|SCRAM|MD5) authentication failed for user "([^"]*)"|no pg_hba\.conf entry for host "([^"]*)", user "([^"]*)", database "([^"]*)"`)
+
+	// SQL Server ERRORLOG line: 2023-04-21 15:30:45.12 Logon       Login failed for user 'sa'. ... [CLIENT: 203.0.113.5]
+	mssqlErrorLogPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d+)\s+(\S+)\s+(.*)$`)
+
+	// Login failed for user 'sa'. / Login succeeded for user 'CORP\admin'.
+	mssqlLoginPattern  = regexp.MustCompile(`^Login (failed|succeeded) for user '([^']*)'`)
+	mssqlClientPattern = regexp.MustCompile(`\[CLIENT: ([^\]]+)\]`)
+
+	// SQL Server ERRORLOG file names: ERRORLOG, ERRORLOG.1 ... ERRORLOG.6
+	mssqlErrorLogNamePattern = regexp.MustCompile(`(?i)^errorlog(?:\.\d+)?$`)
+)
+
+// mysqlTimestamp parses MySQL ISO-8601 or legacy YYMMDD H:MM:SS timestamps
+func mysqlTimestamp(value string) time.Time {
+	value = strings.Join(strings.Fields(value), " ")
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999", "2006-01-02 15:04:05.999999", "060102 15:04:05"} {
+		if t, err := parseLogTime(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ============================================================================
+// MySQL / MariaDB Log Parser
+// ============================================================================
+
+// MySQLLogParser implements the Parser interface for MySQL and MariaDB general query, slow query and error logs
+type MySQLLogParser struct{}
+
+// mysqlLogKind identifies which MySQL log a file holds
+type mysqlLogKind int
+
+const (
+	mysqlLogUnknown mysqlLogKind = iota
+	mysqlLogGeneral
+	mysqlLogSlow
+	mysqlLogError
+)
+
+// detectMySQLLog inspects the file header to classify a MySQL log
+func detectMySQLLog(filePath string) mysqlLogKind {
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return mysqlLogUnknown
+	}
+	baseName := strings.ToLower(filepath.Base(filePath))
+	hinted := strings.Contains(baseName, "mysql") || strings.Contains(baseName, "mariadb") || strings.HasSuffix(baseName, ".err")
+
+	general, errorLines := 0, 0
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "# User@Host:") || strings.HasPrefix(line, "# Query_time:"):
+			return mysqlLogSlow
+		case strings.HasPrefix(line, "Time") && strings.Contains(line, "Id Command") && strings.Contains(line, "Argument"):
+			return mysqlLogGeneral
+		case mysqlErrorPattern.MatchString(line):
+			errorLines++
+		case mysqlGeneralPattern.MatchString(line):
+			general++
+		}
+	}
+	if errorLines >= 3 || hinted && errorLines > 0 {
+		return mysqlLogError
+	}
+	if general >= 3 {
+		return mysqlLogGeneral
+	}
+	return mysqlLogUnknown
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *MySQLLogParser) CanParse(filePath string) bool {
+	return detectMySQLLog(filePath) != mysqlLogUnknown
+}
+
+// Parse parses a MySQL log file and returns a slice of events
+func (p *MySQLLogParser) Parse(filePath string) ([]*core.Event, error) {
+	var events []*core.Event
+	var err error
+	kind := detectMySQLLog(filePath)
+	switch kind {
+	case mysqlLogSlow:
+		events, err = p.parseSlowLog(filePath)
+	case mysqlLogError:
+		events, err = p.parseErrorLog(filePath)
+	default:
+		events, err = p.parseGeneralLog(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed MySQL log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// mysqlSession tracks the account and database of a general log connection ID
+type mysqlSession struct {
+	user     string
+	host     string
+	database string
+}
+
+// parseGeneralLog parses a general query log; multi-line statements are kept with their command
+func (p *MySQLLogParser) parseGeneralLog(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 100))
+	source := filepath.Base(filePath)
+	sessions := make(map[string]*mysqlSession)
+	var lastTimestamp time.Time
+
+	err := scanLogRecords(filePath, mysqlGeneralPattern.MatchString, func(record *logRecord) {
+		matches := mysqlGeneralPattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			// Server startup banner and column header lines
+			return
+		}
+		if matches[1] != "" {
+			lastTimestamp = mysqlTimestamp(matches[1])
+		}
+		connID, command, argument := matches[2], matches[3], strings.TrimSpace(record.withContinuation(matches[4]))
+
+		session := sessions[connID]
+		if session == nil {
+			session = &mysqlSession{}
+			sessions[connID] = session
+		}
+
+		eventType := "MySQLCommand"
+		score := 0.0
+		switch command {
+		case "Connect", "Change user":
+			eventType = "MySQLConnect"
+			if m := mysqlAccessDeniedPattern.FindStringSubmatch(argument); m != nil {
+				eventType = "MySQLAuthFailure"
+				session.user, session.host = m[1], m[2]
+				score = 0.5
+			} else if m := mysqlConnectPattern.FindStringSubmatch(argument); m != nil {
+				session.user, session.host, session.database = m[1], m[2], m[3]
+			}
+		case "Init DB":
+			session.database = argument
+		case "Query", "Execute", "Prepare":
+			eventType = "MySQLQuery"
+		}
+
+		msg := fmt.Sprintf("[%s] %s (conn %s)", command, argument, connID)
+		if session.database != "" {
+			msg += " db=" + session.database
+		}
+		event := core.NewEvent(lastTimestamp, source, eventType, record.lineNum, session.user, session.host, msg, filePath)
+		event.Score = score
+		events = append(events, event)
+
+		if command == "Quit" {
+			delete(sessions, connID)
+		}
+	})
+	return events, err
+}
+
+// mysqlSlowQuery accumulates one slow query log entry
+type mysqlSlowQuery struct {
+	timestamp time.Time
+	lineNum   int
+	user      string
+	host      string
+	connID    string
+	database  string
+	stats     string
+	statement []string
+}
+
+// parseSlowLog parses a slow query log into one event per statement
+func (p *MySQLLogParser) parseSlowLog(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 400))
+	source := filepath.Base(filePath)
+	lineNum := 0
+	var lastTimestamp time.Time
+	var pending *mysqlSlowQuery
+
+	flush := func() {
+		if pending == nil || len(pending.statement) == 0 {
+			pending = nil
+			return
+		}
+		msg := fmt.Sprintf("Slow query (%s)", pending.stats)
+		if pending.database != "" {
+			msg += " db=" + pending.database
+		}
+		msg += ": " + strings.Join(pending.statement, "\n")
+		events = append(events, core.NewEvent(pending.timestamp, source, "MySQLSlowQuery", pending.lineNum, pending.user, pending.host, msg, filePath))
+		pending = nil
+	}
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if m := mysqlSlowTimePattern.FindStringSubmatch(line); m != nil {
+			flush()
+			lastTimestamp = mysqlTimestamp(m[1])
+			continue
+		}
+		if m := mysqlSlowUserPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			host := m[3]
+			if host == "" {
+				host = m[2]
+			}
+			pending = &mysqlSlowQuery{timestamp: lastTimestamp, lineNum: lineNum, user: m[1], host: host, connID: m[4]}
+			continue
+		}
+		if pending == nil {
+			continue
+		}
+		if m := mysqlSlowStatsPattern.FindStringSubmatch(line); m != nil {
+			pending.stats = fmt.Sprintf("query_time=%ss lock_time=%ss rows_sent=%s rows_examined=%s", m[1], m[2], m[3], m[4])
+			continue
+		}
+		if m := mysqlSlowSchemaPattern.FindStringSubmatch(line); m != nil {
+			pending.database = m[1]
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := mysqlSlowUsePattern.FindStringSubmatch(line); m != nil {
+			pending.database = m[1]
+			continue
+		}
+		if m := mysqlSlowSetTimePattern.FindStringSubmatch(line); m != nil {
+			if secs, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+				pending.timestamp = time.Unix(secs, 0).UTC()
+			}
+			continue
+		}
+		pending.statement = append(pending.statement, line)
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	return events, nil
+}
+
+// parseErrorLog parses a MySQL error log; failed logins become MySQLAuthFailure events
+func (p *MySQLLogParser) parseErrorLog(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
+	source := filepath.Base(filePath)
+
+	err := scanLogRecords(filePath, mysqlErrorPattern.MatchString, func(record *logRecord) {
+		matches := mysqlErrorPattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			return
+		}
+		level, code, subsystem, text := matches[3], matches[4], matches[5], matches[6]
+
+		eventType := "MySQLError"
+		user, host := "", ""
+		score := 0.0
+		if m := mysqlAccessDeniedPattern.FindStringSubmatch(text); m != nil {
+			eventType = "MySQLAuthFailure"
+			user, host = m[1], m[2]
+			score = 0.5
+		}
+
+		msgParts := []string{fmt.Sprintf("[%s]", level)}
+		if code != "" {
+			msgParts = append(msgParts, fmt.Sprintf("[%s]", code))
+		}
+		if subsystem != "" {
+			msgParts = append(msgParts, fmt.Sprintf("[%s]", subsystem))
+		}
+		msgParts = append(msgParts, text)
+
+		event := core.NewEvent(mysqlTimestamp(matches[1]), source, eventType, record.lineNum, user, host, record.withContinuation(strings.Join(msgParts, " ")), filePath)
+		event.Score = score
+		event.Tags = append(event.Tags, "level:"+strings.ToLower(level))
+		events = append(events, event)
+	})
+	return events, err
+}
+
+// ============================================================================
+// PostgreSQL Log Parser
+// ============================================================================
+
+// PostgreSQLLogParser implements the Parser interface for PostgreSQL server logs (stderr/logging_collector output)
+// The log_line_prefix is configured with SetPostgresLogLinePrefix; otherwise common defaults are tried
+type PostgreSQLLogParser struct{}
+
+// postgresLinePrefix is a log_line_prefix compiled to a line pattern
+type postgresLinePrefix struct {
+	prefix  string
+	pattern *regexp.Regexp
+	escapes []byte // Escape letter of each capture group before the message
+}
+
+// postgresDefaultPrefixes are common log_line_prefix settings tried when none is configured
+var postgresDefaultPrefixes = []string{
+	"%m [%p] %q%u@%d ", // Debian/Ubuntu packages
+	"%m [%p] ",         // PostgreSQL 10+ default
+	"%t [%p]: [%l-1] user=%u,db=%d,app=%a,client=%h ", // pgBadger recommendation
+	"%t [%p]: [%l-1] ",
+	"%m [%p] %u@%d ",
+	"%t ",
+	"< %m > ",
+}
+
+// customPostgresPrefix is set once at startup (before parsing begins) by SetPostgresLogLinePrefix
+var customPostgresPrefix *postgresLinePrefix
+
+// compiledPostgresDefaults holds the compiled default prefixes
+var compiledPostgresDefaults = func() []*postgresLinePrefix {
+	var prefixes []*postgresLinePrefix
+	for _, prefix := range postgresDefaultPrefixes {
+		if compiled, err := compilePostgresPrefix(prefix); err == nil {
+			prefixes = append(prefixes, compiled)
+		}
+	}
+	return prefixes
+}()
+
+// SetPostgresLogLinePrefix configures the server's log_line_prefix (e.g. "%m [%p] %q%u@%d ")
+// An empty prefix restores auto-detection among common defaults.
+func SetPostgresLogLinePrefix(prefix string) error {
+	if prefix == "" {
+		customPostgresPrefix = nil
+		return nil
+	}
+	compiled, err := compilePostgresPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	customPostgresPrefix = compiled
+	return nil
+}
+
+// compilePostgresPrefix turns a log_line_prefix into an anchored regex ending in the message severity
+// Text after %q only appears for session processes, so it becomes optional
+func compilePostgresPrefix(prefix string) (*postgresLinePrefix, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	var escapes []byte
+	optional := false
+
+	locs := postgresEscapePattern.FindAllStringIndex(prefix, -1)
+	prevEnd := 0
+	for i, loc := range locs {
+		pattern.WriteString(regexp.QuoteMeta(prefix[prevEnd:loc[0]]))
+		prevEnd = loc[1]
+
+		escape := prefix[loc[1]-1]
+		switch escape {
+		case '%':
+			pattern.WriteString("%")
+			continue
+		case 'q':
+			pattern.WriteString("(?:")
+			optional = true
+			continue
+		}
+
+		switch escape {
+		case 't', 's':
+			pattern.WriteString(`(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?: [A-Za-z0-9+:-]+)?)`)
+		case 'm':
+			pattern.WriteString(`(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d+(?: [A-Za-z0-9+:-]+)?)`)
+		case 'n':
+			pattern.WriteString(`(\d+(?:\.\d+)?)`)
+		case 'p', 'l', 'P', 'x', 'Q':
+			pattern.WriteString(`(\d*)`)
+		case 'e':
+			pattern.WriteString(`([0-9A-Z]{5})`)
+		default:
+			// Free-text escapes (%u, %d, %a, %h, %r, %b, %c, %i, %v) run to the next literal character
+			next := len(prefix)
+			if i+1 < len(locs) {
+				next = locs[i+1][0]
+			}
+			if loc[1] < next {
+				pattern.WriteString(fmt.Sprintf(`([^\x%02x]*)`, prefix[loc[1]]))
+			} else {
+				pattern.WriteString(`(\S*?)`)
+			}
+		}
+		escapes = append(escapes, escape)
+	}
+	pattern.WriteString(regexp.QuoteMeta(prefix[prevEnd:]))
+	if optional {
+		pattern.WriteString(")?")
+	}
+	pattern.WriteString(`\s*` + postgresMessagePattern.String())
+
+	compiled, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile log_line_prefix %q: %w", prefix, err)
+	}
+	return &postgresLinePrefix{prefix: prefix, pattern: compiled, escapes: escapes}, nil
+}
+
+// selectPostgresPrefix returns the configured prefix, or the default matching most header lines
+func selectPostgresPrefix(filePath string) (*postgresLinePrefix, int) {
+	candidates := compiledPostgresDefaults
+	if customPostgresPrefix != nil {
+		candidates = []*postgresLinePrefix{customPostgresPrefix}
+	}
+	var best *postgresLinePrefix
+	bestCount := 0
+	for _, candidate := range candidates {
+		if count := countHeaderMatches(filePath, candidate.pattern); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best, bestCount
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *PostgreSQLLogParser) CanParse(filePath string) bool {
+	_, count := selectPostgresPrefix(filePath)
+	baseName := strings.ToLower(filepath.Base(filePath))
+	if strings.Contains(baseName, "postgres") || strings.Contains(filePath, "pg_log") {
+		return count > 0
+	}
+	return count >= 3
+}
+
+// postgresRecord holds the fields of one PostgreSQL log line
+type postgresRecord struct {
+	timestamp time.Time
+	fields    map[byte]string
+	severity  string
+	message   string
+}
+
+// Parse parses a PostgreSQL log file and returns a slice of events
+func (p *PostgreSQLLogParser) Parse(filePath string) ([]*core.Event, error) {
+	prefix, _ := selectPostgresPrefix(filePath)
+	if prefix == nil {
+		prefix = compiledPostgresDefaults[0]
+	}
+
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
+	source := filepath.Base(filePath)
+	var last *core.Event
+
+	err := scanLogRecords(filePath, prefix.pattern.MatchString, func(record *logRecord) {
+		rec := prefix.parse(truncateLine(record.line))
+		if rec == nil {
+			return
+		}
+		text := record.withContinuation(rec.message)
+
+		// Detail lines belong to the preceding message
+		switch rec.severity {
+		case "DETAIL", "HINT", "STATEMENT", "CONTEXT", "QUERY", "LOCATION":
+			if last != nil {
+				last.Message += fmt.Sprintf("\n%s: %s", rec.severity, text)
+				return
+			}
+		}
+
+		user, database, host := rec.fields['u'], rec.fields['d'], rec.fields['h']
+		if host == "" {
+			host = stripPort(rec.fields['r'])
+		}
+		for _, m := range postgresKeyValuePattern.FindAllStringSubmatch(text, -1) {
+			value := strings.Trim(m[2], `"`)
+			switch m[1] {
+			case "user":
+				user = value
+			case "database":
+				database = value
+			case "host":
+				host = value
+			}
+		}
+
+		eventType := "PostgreSQLLog"
+		score := 0.0
+		switch {
+		case postgresAuthFailurePattern.MatchString(text):
+			m := postgresAuthFailurePattern.FindStringSubmatch(text)
+			eventType = "PostgreSQLAuthFailure"
+			score = 0.5
+			if m[1] != "" {
+				user = m[1]
+			} else {
+				host, user, database = m[2], m[3], m[4]
+			}
+		case strings.HasPrefix(text, "connection received") || strings.HasPrefix(text, "connection authorized") ||
+			strings.HasPrefix(text, "connection authenticated") || strings.HasPrefix(text, "disconnection"):
+			eventType = "PostgreSQLConnection"
+		case strings.Contains(text, "statement: ") || strings.HasPrefix(text, "execute ") || strings.Contains(text, "duration: "):
+			eventType = "PostgreSQLStatement"
+		}
+
+		msg := fmt.Sprintf("[%s] %s", rec.severity, text)
+		if database != "" {
+			msg += " db=" + database
+		}
+		if pid := rec.fields['p']; pid != "" {
+			msg += fmt.Sprintf(" (pid %s)", pid)
+		}
+
+		event := core.NewEvent(rec.timestamp, source, eventType, record.lineNum, user, host, msg, filePath)
+		event.Score = score
+		event.Tags = append(event.Tags, "level:"+strings.ToLower(rec.severity))
+		events = append(events, event)
+		last = event
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed PostgreSQL log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// parse matches a log line against the prefix and returns its fields
+func (pp *postgresLinePrefix) parse(line string) *postgresRecord {
+	matches := pp.pattern.FindStringSubmatch(line)
+	if matches == nil {
+		return nil
+	}
+	rec := &postgresRecord{fields: make(map[byte]string, len(pp.escapes))}
+	for i, escape := range pp.escapes {
+		if value := matches[i+1]; value != "" && value != "[unknown]" {
+			rec.fields[escape] = value
+		}
+	}
+	rec.severity = matches[len(matches)-2]
+	rec.message = matches[len(matches)-1]
+
+	v := rec.fields['m']
+	if v == "" {
+		v = rec.fields['t']
+	}
+	if v != "" {
+		for _, layout := range []string{"2006-01-02 15:04:05.999 MST", "2006-01-02 15:04:05.999 -07", "2006-01-02 15:04:05.999 -07:00", "2006-01-02 15:04:05.999"} {
+			if t, err := parseLogTime(layout, v); err == nil {
+				rec.timestamp = t
+				break
+			}
+		}
+	} else if v := rec.fields['n']; v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			rec.timestamp = time.UnixMilli(int64(secs * 1000)).UTC()
+		}
+	}
+	return rec
+}
+
+// ============================================================================
+// SQL Server ERRORLOG Parser
+// ============================================================================
+
+// MSSQLErrorLogParser implements the Parser interface for SQL Server ERRORLOG files (UTF-16LE text)
+type MSSQLErrorLogParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MSSQLErrorLogParser) CanParse(filePath string) bool {
+	return mssqlErrorLogNamePattern.MatchString(filepath.Base(filePath))
+}
+
+// Parse parses a SQL Server ERRORLOG file and returns a slice of events
+func (p *MSSQLErrorLogParser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(strings.ReplaceAll(decodeTextContent(data), "\r\n", "\n"), "\n")
+
+	events := make([]*core.Event, 0, len(lines))
+	source := filepath.Base(filePath)
+	var last *core.Event
+	pendingError := ""
+
+	for i, line := range lines {
+		lineNum := i + 1
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		matches := mssqlErrorLogPattern.FindStringSubmatch(truncateLine(line))
+		if matches == nil {
+			if last != nil {
+				last.Message += "\n" + strings.TrimSpace(line)
+			}
+			continue
+		}
+
+		timestamp, _ := parseLogTime("2006-01-02 15:04:05.99", matches[1])
+		processInfo, text := matches[2], strings.TrimSpace(matches[3])
+
+		// "Error: 18456, Severity: 14, State: 8." precedes the login failure message it describes
+		if strings.HasPrefix(text, "Error: ") && processInfo == "Logon" {
+			pendingError = text
+			continue
+		}
+
+		eventType := "MSSQLLog"
+		user, host := "", ""
+		score := 0.0
+		if m := mssqlLoginPattern.FindStringSubmatch(text); m != nil {
+			user = m[2]
+			if m[1] == "failed" {
+				eventType = "MSSQLLoginFailure"
+				score = 0.5
+			} else {
+				eventType = "MSSQLLoginSuccess"
+			}
+			if c := mssqlClientPattern.FindStringSubmatch(text); c != nil && !strings.HasPrefix(c[1], "<") {
+				host = c[1]
+			}
+		}
+
+		msg := fmt.Sprintf("[%s] %s", processInfo, text)
+		if pendingError != "" {
+			msg += " (" + strings.TrimSuffix(pendingError, ".") + ")"
+			pendingError = ""
+		}
+
+		event := core.NewEvent(timestamp, source, eventType, lineNum, user, host, msg, filePath)
+		event.Score = score
+		events = append(events, event)
+		last = event
+	}
+
+	fmt.Printf("Parsed SQL Server ERRORLOG: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
@@ -0,0 +1,169 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDatabaseLogs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"mysql-general.log": strings.Join([]string{
+			"/usr/sbin/mysqld, Version: 8.0.32 (MySQL Community Server - GPL). started with:",
+			"Tcp port: 3306  Unix socket: /var/run/mysqld/mysqld.sock",
+			"Time                 Id Command    Argument",
+			"2023-04-21T15:30:45.123456Z\t   12 Connect\tapp@10.0.0.5 on shop using TCP/IP",
+			"2023-04-21T15:30:46.000000Z\t   12 Query\tSELECT *",
+			"FROM customers",
+			"2023-04-21T15:30:47.000000Z\t   13 Connect\tAccess denied for user 'root'@'203.0.113.5' (using password: YES)",
+		}, "\n"),
+		"mysql-slow.log": strings.Join([]string{
+			"# Time: 2023-04-21T15:30:45.123456Z",
+			"# User@Host: app[app] @ web01 [10.0.0.5]  Id:    12",
+			"# Query_time: 12.000123  Lock_time: 0.000100 Rows_sent: 100000  Rows_examined: 100000",
+			"use shop;",
+			"SET timestamp=1682091048;",
+			"SELECT * FROM customers;",
+		}, "\n"),
+		"mysqld.err": strings.Join([]string{
+			"2023-04-21T15:30:45.123456Z 0 [System] [MY-010931] [Server] /usr/sbin/mysqld: ready for connections.",
+			"2023-04-21T15:30:49.000000Z 14 [Note] [MY-010926] [Server] Access denied for user 'admin'@'198.51.100.7' (using password: YES)",
+		}, "\n"),
+		"postgresql-2023-04-21_000000.log": strings.Join([]string{
+			"2023-04-21 15:30:45.123 UTC [1234] LOG:  connection received: host=10.0.0.5 port=54321",
+			"2023-04-21 15:30:45.130 UTC [1234] app@shop LOG:  connection authorized: user=app database=shop application_name=psql",
+			"2023-04-21 15:30:46.000 UTC [1234] app@shop ERROR:  relation \"secrets\" does not exist at character 15",
+			"2023-04-21 15:30:46.000 UTC [1234] app@shop STATEMENT:  SELECT * FROM secrets",
+			"\tWHERE id = 1;",
+			"2023-04-21 15:30:47.000 UTC [1240] postgres@postgres FATAL:  password authentication failed for user \"postgres\"",
+		}, "\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		index     int
+		count     int
+		eventType string
+		user      string
+		host      string
+		message   string
+		timestamp time.Time
+	}{
+		{"mysql-general.log", 1, 3, "MySQLQuery", "app", "10.0.0.5", "[Query] SELECT *\nFROM customers (conn 12) db=shop",
+			time.Date(2023, 4, 21, 15, 30, 46, 0, time.UTC)},
+		{"mysql-general.log", 2, 3, "MySQLAuthFailure", "root", "203.0.113.5", "[Connect] Access denied for user 'root'@'203.0.113.5' (using password: YES) (conn 13)",
+			time.Date(2023, 4, 21, 15, 30, 47, 0, time.UTC)},
+		{"mysql-slow.log", 0, 1, "MySQLSlowQuery", "app", "10.0.0.5", "Slow query (query_time=12.000123s lock_time=0.000100s rows_sent=100000 rows_examined=100000) db=shop: SELECT * FROM customers;",
+			time.Date(2023, 4, 21, 15, 30, 48, 0, time.UTC)},
+		{"mysqld.err", 1, 2, "MySQLAuthFailure", "admin", "198.51.100.7", "[Note] [MY-010926] [Server] Access denied for user 'admin'@'198.51.100.7' (using password: YES)",
+			time.Date(2023, 4, 21, 15, 30, 49, 0, time.UTC)},
+		{"postgresql-2023-04-21_000000.log", 0, 4, "PostgreSQLConnection", "", "10.0.0.5", "[LOG] connection received: host=10.0.0.5 port=54321 (pid 1234)",
+			time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC)},
+		{"postgresql-2023-04-21_000000.log", 2, 4, "PostgreSQLLog", "app", "", "[ERROR] relation \"secrets\" does not exist at character 15 db=shop (pid 1234)\nSTATEMENT: SELECT * FROM secrets\n\tWHERE id = 1;",
+			time.Date(2023, 4, 21, 15, 30, 46, 0, time.UTC)},
+		{"postgresql-2023-04-21_000000.log", 3, 4, "PostgreSQLAuthFailure", "postgres", "", "[FATAL] password authentication failed for user \"postgres\" db=postgres (pid 1240)",
+			time.Date(2023, 4, 21, 15, 30, 47, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		filePath := filepath.Join(dir, tt.name)
+		parser, err := GetParserForFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to get parser for %s: %v", tt.name, err)
+		}
+		events, err := parser.Parse(filePath)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.name, err)
+		}
+		if len(events) != tt.count {
+			t.Fatalf("%s: expected %d events, got %d", tt.name, tt.count, len(events))
+		}
+		event := events[tt.index]
+		if event.EventType != tt.eventType || event.User != tt.user || event.Host != tt.host || event.Message != tt.message {
+			t.Errorf("%s[%d]: expected %s user=%q host=%q %q, got %s user=%q host=%q %q", tt.name, tt.index,
+				tt.eventType, tt.user, tt.host, tt.message, event.EventType, event.User, event.Host, event.Message)
+		}
+		if !event.Timestamp.Equal(tt.timestamp) {
+			t.Errorf("%s[%d]: expected timestamp %s, got %s", tt.name, tt.index, tt.timestamp, event.Timestamp)
+		}
+	}
+}
+
+func TestPostgresLogLinePrefix(t *testing.T) {
+	if err := SetPostgresLogLinePrefix("%t [%p]: user=%u,db=%d,client=%h "); err != nil {
+		t.Fatalf("Failed to set log_line_prefix: %v", err)
+	}
+	t.Cleanup(func() { SetPostgresLogLinePrefix("") })
+
+	filePath := filepath.Join(t.TempDir(), "postgresql.log")
+	content := "2023-04-21 15:30:45 UTC [1234]: user=app,db=shop,client=10.0.0.5 LOG:  statement: DROP TABLE audit_log;\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	parser := &PostgreSQLLogParser{}
+	if !parser.CanParse(filePath) {
+		t.Fatal("Expected PostgreSQLLogParser to accept the file")
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.EventType != "PostgreSQLStatement" || event.User != "app" || event.Host != "10.0.0.5" ||
+		event.Message != "[LOG] statement: DROP TABLE audit_log; db=shop (pid 1234)" {
+		t.Errorf("Unexpected event: %s user=%q host=%q %q", event.EventType, event.User, event.Host, event.Message)
+	}
+}
+
+func TestMSSQLErrorLogParser(t *testing.T) {
+	content := strings.Join([]string{
+		"2023-04-21 15:30:00.12 Server      Microsoft SQL Server 2019 (RTM) - 15.0.2000.5 (X64)",
+		"\tSep 24 2019 13:48:23",
+		"2023-04-21 15:30:45.12 Logon       Error: 18456, Severity: 14, State: 8.",
+		"2023-04-21 15:30:45.12 Logon       Login failed for user 'sa'. Reason: Password did not match that for the login provided. [CLIENT: 203.0.113.5]",
+		`2023-04-21 15:31:00.55 Logon       Login succeeded for user 'CORP\admin'. Connection made using Windows authentication. [CLIENT: 10.0.0.9]`,
+	}, "\r\n")
+
+	filePath := filepath.Join(t.TempDir(), "ERRORLOG.1")
+	if err := os.WriteFile(filePath, append([]byte{0xFF, 0xFE}, utf16LEBytes(content)...), 0644); err != nil {
+		t.Fatalf("Failed to write ERRORLOG: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+
+	if events[0].Message != "[Server] Microsoft SQL Server 2019 (RTM) - 15.0.2000.5 (X64)\nSep 24 2019 13:48:23" {
+		t.Errorf("Unexpected startup message: %q", events[0].Message)
+	}
+	failure := events[1]
+	want := "[Logon] Login failed for user 'sa'. Reason: Password did not match that for the login provided. [CLIENT: 203.0.113.5] (Error: 18456, Severity: 14, State: 8)"
+	if failure.EventType != "MSSQLLoginFailure" || failure.User != "sa" || failure.Host != "203.0.113.5" || failure.Message != want {
+		t.Errorf("Unexpected failure event: %s user=%q host=%q %q", failure.EventType, failure.User, failure.Host, failure.Message)
+	}
+	if wantTime := time.Date(2023, 4, 21, 15, 30, 45, 120000000, time.UTC); !failure.Timestamp.Equal(wantTime) {
+		t.Errorf("Expected timestamp %s, got %s", wantTime, failure.Timestamp)
+	}
+	if events[2].EventType != "MSSQLLoginSuccess" || events[2].User != `CORP\admin` || events[2].Host != "10.0.0.9" {
+		t.Errorf("Unexpected success event: %s user=%q host=%q", events[2].EventType, events[2].User, events[2].Host)
+	}
+}
@@ -0,0 +1,358 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for Windows Defender support logs
+var (
+	// MPLog file names: MPLog-20230421-153045.log, MpDetection-20230421-153045.log
+	mplogFileNamePattern = regexp.MustCompile(`(?i)^(mplog|mpdetection)-[\d-]+\.log$`)
+
+	// Timestamped MPLog line: 2023-04-21T15:30:45.123Z message
+	mplogLinePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z?)\s+(.*)$`)
+
+	// DETECTION Trojan:Win32/Ceprolad.A file:C:\Users\jdoe\Downloads\invoice.exe
+	mplogDetectionPattern = regexp.MustCompile(`^DETECTION(?:_(\w+))?\s+(\S+)\s+(\w+):_?(.+)$`)
+
+	// ProcessImageName: powershell.exe, TotalTime: 1000, Count: 5, MaxTime: 300, MaxTimeFile: C:\x.ps1, EstimatedImpact: 10%
+	mplogImagePattern = regexp.MustCompile(`^ProcessImageName:\s*([^,]+),\s*TotalTime:\s*(\d+),\s*Count:\s*(\d+),\s*MaxTime:\s*(\d+),\s*MaxTimeFile:\s*(.*?),\s*EstimatedImpact:\s*(\d+)%`)
+
+	// SDN:Issuing SDN query for \Device\HarddiskVolume3\x.exe (\Device\HarddiskVolume3\x.exe) (sha1=..., sha2=...)
+	mplogSDNPattern = regexp.MustCompile(`^SDN:Issuing SDN query for (.+?) \((.+?)\) \(sha1=([0-9A-Fa-f]+), sha2=([0-9A-Fa-f]+)\)`)
+
+	// Defender threat names: Trojan:Win32/Ceprolad.A, Virus:DOS/EICAR_Test_File, HackTool:Win32/Mimikatz!pz
+	defenderThreatNamePattern = regexp.MustCompile(`^[A-Za-z]+:[A-Za-z0-9]+/[\w.!\-]+$`)
+
+	// DetectionHistory resources: file:_C:\path, process:_pid:1234, containerfile:_C:\a.zip
+	defenderResourcePattern = regexp.MustCompile(`^(file|containerfile|process|regkey|regkeyvalue|service|startup|amsi|behavior|webfile):_?(.+)$`)
+
+	// DOMAIN\user or NT AUTHORITY\SYSTEM
+	defenderUserPattern = regexp.MustCompile(`^[\w .-]+\\[\w .$-]+$`)
+)
+
+// defenderActions are the remediation action names recorded by Defender
+var defenderActions = map[string]bool{
+	"Quarantine": true,
+	"Remove":     true,
+	"Clean":      true,
+	"Allow":      true,
+	"Block":      true,
+	"NoAction":   true,
+}
+
+// windowsFiletimeEpochDiff is the number of 100-ns intervals between 1601 and 1970
+const windowsFiletimeEpochDiff = 116444736000000000
+
+// filetimeToTime converts a Windows FILETIME (100-ns intervals since 1601) to UTC
+func filetimeToTime(filetime uint64) time.Time {
+	if filetime <= windowsFiletimeEpochDiff {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(filetime-windowsFiletimeEpochDiff)*100).UTC()
+}
+
+// ============================================================================
+// Windows Defender MPLog Parser
+// ============================================================================
+
+// DefenderMPLogParser implements the Parser interface for Windows Defender support logs
+// (C:\ProgramData\Microsoft\Windows Defender\Support\MPLog-*.log)
+type DefenderMPLogParser struct{}
+
+// mplogRecord is a timestamped MPLog line plus its untimestamped continuation lines
+type mplogRecord struct {
+	timestamp time.Time
+	lineNum   int
+	text      string
+	fields    map[string]string // Lower-cased "Key: Value" continuation fields
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *DefenderMPLogParser) CanParse(filePath string) bool {
+	return mplogFileNamePattern.MatchString(filepath.Base(filePath))
+}
+
+// Parse parses an MPLog file and returns a slice of events
+func (p *DefenderMPLogParser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// MPLog files are UTF-16LE
+	lines := strings.Split(decodeTextContent(data), "\n")
+
+	// Pre-allocate slice with estimated capacity (avg 120 bytes per UTF-16 line)
+	events := make([]*core.Event, 0, len(data)/240+1)
+	source := filepath.Base(filePath)
+	detectionCount := 0
+
+	var current *mplogRecord
+	flush := func() {
+		if current == nil {
+			return
+		}
+		event := p.recordToEvent(current, source, filePath)
+		if event.EventType == "DefenderDetection" || event.EventType == "DefenderThreat" {
+			detectionCount++
+		}
+		events = append(events, event)
+		current = nil
+	}
+
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineForRegex := truncateLine(line)
+
+		if matches := mplogLinePattern.FindStringSubmatch(lineForRegex); matches != nil {
+			flush()
+			current = &mplogRecord{
+				timestamp: parseMPLogTimestamp(matches[1]),
+				lineNum:   i + 1,
+				text:      strings.TrimSpace(matches[2]),
+				fields:    make(map[string]string),
+			}
+			continue
+		}
+
+		// Continuation lines carry "Key:Value" details of scans and threat actions
+		if current != nil {
+			if key, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+				key = strings.ToLower(strings.TrimSpace(key))
+				if _, exists := current.fields[key]; !exists {
+					current.fields[key] = strings.TrimSpace(value)
+				}
+			}
+		}
+	}
+	flush()
+
+	fmt.Printf("Parsed Windows Defender MPLog: %s (found %d events, %d detections)\n", filePath, len(events), detectionCount)
+	return events, nil
+}
+
+// recordToEvent classifies an MPLog record and converts it into a core.Event
+func (p *DefenderMPLogParser) recordToEvent(r *mplogRecord, source, filePath string) *core.Event {
+	eventType := "DefenderLog"
+	message := r.text
+	user := firstNonEmpty(r.fields, "user", "domain\\user")
+	var score float64
+
+	if matches := mplogDetectionPattern.FindStringSubmatch(r.text); matches != nil {
+		eventType = "DefenderDetection"
+		kind := "Detection"
+		if matches[1] != "" {
+			kind = "Detection " + strings.ToLower(matches[1])
+		}
+		message = fmt.Sprintf("%s %s %s:%s", kind, matches[2], matches[3], matches[4])
+		score = 0.9
+	} else if threat := r.fields["threat name"]; threat != "" {
+		// Scan results and threat action blocks
+		eventType = "DefenderThreat"
+		parts := []string{fmt.Sprintf("%s: %s", r.text, threat)}
+		for _, key := range []string{"resource path", "action", "severity", "result", "scan source"} {
+			if v := r.fields[key]; v != "" {
+				parts = append(parts, fmt.Sprintf("%s=%s", strings.ReplaceAll(key, " ", "_"), v))
+			}
+		}
+		message = strings.Join(parts, " ")
+		score = 0.9
+	} else if matches := mplogImagePattern.FindStringSubmatch(r.text); matches != nil {
+		eventType = "DefenderProcessImage"
+		message = fmt.Sprintf("Process image %s scanned %s times (total=%sms max=%sms max_file=%s impact=%s%%)",
+			matches[1], matches[3], matches[2], matches[4], matches[5], matches[6])
+	} else if matches := mplogSDNPattern.FindStringSubmatch(r.text); matches != nil {
+		eventType = "DefenderSDNQuery"
+		message = fmt.Sprintf("SDN query for %s sha1=%s sha256=%s", matches[1], matches[3], matches[4])
+	} else if lower := strings.ToLower(r.text); strings.Contains(lower, "scan") &&
+		(strings.Contains(lower, "start") || strings.Contains(lower, "begin") || strings.Contains(lower, "end") || strings.Contains(lower, "complet")) {
+		eventType = "DefenderScan"
+		for _, key := range []string{"scan id", "scan source", "resource path"} {
+			if v := r.fields[key]; v != "" {
+				message += fmt.Sprintf(" %s=%s", strings.ReplaceAll(key, " ", "_"), v)
+			}
+		}
+	}
+
+	event := core.NewEvent(
+		r.timestamp,
+		source,
+		eventType,
+		r.lineNum,
+		user,
+		"",
+		message,
+		filePath,
+	)
+	event.Score = score
+	return event
+}
+
+// parseMPLogTimestamp parses MPLog timestamps (UTC, with or without the Z suffix)
+func parseMPLogTimestamp(timeStr string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := parseLogTime(layout, timeStr); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ============================================================================
+// Windows Defender DetectionHistory Parser
+// ============================================================================
+
+// DefenderDetectionHistoryParser implements the Parser interface for Defender DetectionHistory files
+// (C:\ProgramData\Microsoft\Windows Defender\Scans\History\Service\DetectionHistory\<n>\<GUID>)
+// The format is undocumented, so values are carved from its UTF-16 property strings
+type DefenderDetectionHistoryParser struct{}
+
+// defenderHistoryMagic is the first property name of every DetectionHistory file
+var defenderHistoryMagic = utf16LEBytes("Magic.Version")
+
+// utf16LEBytes encodes an ASCII string as UTF-16LE
+func utf16LEBytes(s string) []byte {
+	b := make([]byte, 0, len(s)*2)
+	for _, c := range []byte(s) {
+		b = append(b, c, 0)
+	}
+	return b
+}
+
+// carvedString is a UTF-16LE string found in binary data
+type carvedString struct {
+	text string
+	end  int // Offset just past the string's last character
+}
+
+// carveUTF16Strings finds runs of at least minLen printable UTF-16LE characters
+func carveUTF16Strings(data []byte, minLen int) []carvedString {
+	var result []carvedString
+	for i := 0; i+1 < len(data); {
+		j := i
+		for j+1 < len(data) && data[j+1] == 0 && data[j] >= 0x20 && data[j] < 0x7f {
+			j += 2
+		}
+		if (j-i)/2 >= minLen {
+			runes := make([]byte, 0, (j-i)/2)
+			for k := i; k < j; k += 2 {
+				runes = append(runes, data[k])
+			}
+			result = append(result, carvedString{text: string(runes), end: j})
+			i = j
+		} else {
+			i++
+		}
+	}
+	return result
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *DefenderDetectionHistoryParser) CanParse(filePath string) bool {
+	if !strings.Contains(strings.ToLower(filepath.ToSlash(filePath)), "detectionhistory/") {
+		return false
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 4096)
+	n, _ := file.Read(header)
+	return bytes.Contains(header[:n], defenderHistoryMagic)
+}
+
+// Parse parses a DetectionHistory file and returns its detection as an event
+func (p *DefenderDetectionHistoryParser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var threat, process, user, action string
+	var resources []string
+	var detected time.Time
+	for _, s := range carveUTF16Strings(data, 4) {
+		switch {
+		case s.text == "ThreatTrackingStartTime" && detected.IsZero():
+			detected = findFiletimeAfter(data, s.end)
+		case threat == "" && defenderThreatNamePattern.MatchString(s.text):
+			threat = s.text
+		case defenderResourcePattern.MatchString(s.text):
+			m := defenderResourcePattern.FindStringSubmatch(s.text)
+			resources = append(resources, m[1]+":"+m[2])
+		case user == "" && defenderUserPattern.MatchString(s.text):
+			user = s.text
+		case process == "" && strings.HasSuffix(strings.ToLower(s.text), ".exe") && strings.Contains(s.text, `\`):
+			process = s.text
+		case action == "" && defenderActions[s.text]:
+			action = s.text
+		}
+	}
+	if threat == "" {
+		return nil, fmt.Errorf("no threat name found in DetectionHistory file: %w", ErrParsingFailed)
+	}
+
+	approximate := false
+	if detected.IsZero() {
+		if info, err := os.Stat(filePath); err == nil {
+			detected = info.ModTime().UTC()
+			approximate = true
+		}
+	}
+
+	parts := []string{"Detection " + threat}
+	for _, r := range resources {
+		parts = append(parts, "resource="+r)
+	}
+	if process != "" {
+		parts = append(parts, "process="+process)
+	}
+	if action != "" {
+		parts = append(parts, "action="+action)
+	}
+
+	event := core.NewEvent(
+		detected,
+		filepath.Base(filePath),
+		"DefenderDetectionHistory",
+		1,
+		user,
+		"",
+		strings.Join(parts, " "),
+		filePath,
+	)
+	event.Score = 0.9
+	if approximate {
+		markFileMtime(event)
+	}
+
+	fmt.Printf("Parsed Windows Defender DetectionHistory file: %s (found 1 events)\n", filePath)
+	return []*core.Event{event}, nil
+}
+
+// findFiletimeAfter returns the first plausible FILETIME (2000-2100) in the 64 bytes after offset
+func findFiletimeAfter(data []byte, offset int) time.Time {
+	const minFiletime, maxFiletime = 125911584000000000, 157469184000000000
+	for pos := offset; pos+8 <= len(data) && pos < offset+64; pos++ {
+		if v := binary.LittleEndian.Uint64(data[pos:]); v >= minFiletime && v < maxFiletime {
+			return filetimeToTime(v)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,115 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefenderMPLogParser(t *testing.T) {
+	content := strings.Join([]string{
+		`2023-04-21T15:30:45.123Z ProcessImageName: powershell.exe, TotalTime: 1000, Count: 5, MaxTime: 300, MaxTimeFile: C:\Temp\run.ps1, EstimatedImpact: 10%`,
+		`2023-04-21T15:30:46.000Z SDN:Issuing SDN query for \Device\HarddiskVolume3\Users\jdoe\Downloads\invoice.exe (\Device\HarddiskVolume3\Users\jdoe\Downloads\invoice.exe) (sha1=da39a3ee5e6b4b0d3255bfef95601890afd80709, sha2=e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855)`,
+		`2023-04-21T15:30:47.000Z DETECTION Trojan:Win32/Ceprolad.A file:C:\Users\jdoe\Downloads\invoice.exe`,
+		`2023-04-21T15:30:48.000Z Beginning threat actions`,
+		`Threat Name: Trojan:Win32/Ceprolad.A`,
+		`   Resource Path: C:\Users\jdoe\Downloads\invoice.exe`,
+		`Action: Quarantine`,
+		`2023-04-21T15:30:49.000Z Engine: mpengine loaded`,
+	}, "\r\n")
+
+	// MPLog files are written as UTF-16LE with a BOM
+	encoded := []byte{0xFF, 0xFE}
+	for _, c := range content {
+		encoded = binary.LittleEndian.AppendUint16(encoded, uint16(c))
+	}
+	filePath := filepath.Join(t.TempDir(), "MPLog-20230421-153045.log")
+	if err := os.WriteFile(filePath, encoded, 0644); err != nil {
+		t.Fatalf("Failed to write MPLog: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	want := []struct {
+		eventType string
+		message   string
+	}{
+		{"DefenderProcessImage", `Process image powershell.exe scanned 5 times (total=1000ms max=300ms max_file=C:\Temp\run.ps1 impact=10%)`},
+		{"DefenderSDNQuery", `SDN query for \Device\HarddiskVolume3\Users\jdoe\Downloads\invoice.exe sha1=da39a3ee5e6b4b0d3255bfef95601890afd80709`},
+		{"DefenderDetection", `Detection Trojan:Win32/Ceprolad.A file:C:\Users\jdoe\Downloads\invoice.exe`},
+		{"DefenderThreat", `Beginning threat actions: Trojan:Win32/Ceprolad.A resource_path=C:\Users\jdoe\Downloads\invoice.exe action=Quarantine`},
+		{"DefenderLog", "Engine: mpengine loaded"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		if events[i].EventType != w.eventType || !strings.Contains(events[i].Message, w.message) {
+			t.Errorf("Event %d: expected %s %q, got %s %q", i, w.eventType, w.message, events[i].EventType, events[i].Message)
+		}
+	}
+	if wantTime := time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC); !events[0].Timestamp.Equal(wantTime) {
+		t.Errorf("Expected timestamp %s, got %s", wantTime, events[0].Timestamp)
+	}
+}
+
+func TestDefenderDetectionHistoryParser(t *testing.T) {
+	detected := time.Date(2023, 4, 21, 15, 30, 47, 0, time.UTC)
+	filetime := uint64(detected.UnixNano()/100) + windowsFiletimeEpochDiff
+
+	var data []byte
+	data = append(data, 0x08, 0x00, 0x00, 0x00)
+	for _, s := range []string{"Magic.Version", "ThreatTrackingStartTime"} {
+		data = append(data, utf16LEBytes(s)...)
+		data = append(data, 0, 0, 0x0a, 0, 0, 0)
+	}
+	data = binary.LittleEndian.AppendUint64(data, filetime)
+	for _, s := range []string{"Trojan:Win32/Ceprolad.A", `file:_C:\Users\jdoe\Downloads\invoice.exe`, `CORP\jdoe`, `C:\Program Files\Mozilla Firefox\firefox.exe`} {
+		data = append(data, 0x15, 0, 0, 0)
+		data = append(data, utf16LEBytes(s)...)
+		data = append(data, 0, 0)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "DetectionHistory", "02", "3A1F6B2C-1D4E-4F5A-9B8C-7D6E5F4A3B2C")
+	os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write DetectionHistory file: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*DefenderDetectionHistoryParser); !ok {
+		t.Fatalf("Expected DefenderDetectionHistoryParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	want := `Detection Trojan:Win32/Ceprolad.A resource=file:C:\Users\jdoe\Downloads\invoice.exe process=C:\Program Files\Mozilla Firefox\firefox.exe`
+	if event.Message != want {
+		t.Errorf("Expected message %q, got %q", want, event.Message)
+	}
+	if event.User != `CORP\jdoe` {
+		t.Errorf("Expected user CORP\\jdoe, got %q", event.User)
+	}
+	if !event.Timestamp.Equal(detected) {
+		t.Errorf("Expected timestamp %s, got %s", detected, event.Timestamp)
+	}
+}
@@ -0,0 +1,240 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"os"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ============================================================================
+// Text Encoding Detection
+// ============================================================================
+
+// textEncoding is the character encoding of a text log
+type textEncoding int
+
+const (
+	encodingUTF8 textEncoding = iota
+	encodingUTF16LE
+	encodingUTF16BE
+	encodingWindows1252
+)
+
+// encodingSampleSize is how much of a file is inspected to pick its encoding
+const encodingSampleSize = 64 * 1024
+
+// utf16NULRatio is the share of code units whose high byte must be NUL before a file
+// without a BOM is treated as UTF-16 (mostly-ASCII text written by Windows tools)
+const utf16NULRatio = 0.4
+
+// windows1252High maps bytes 0x80-0x9F to their Windows-1252 characters
+// Undefined positions (0x81, 0x8D, 0x8F, 0x90, 0x9D) map to the C1 control of the same value
+var windows1252High = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// detectTextEncoding picks the encoding of a file from its leading bytes
+// A BOM wins; otherwise NUL-interleaved text is UTF-16 and bytes that are not valid UTF-8
+// are Windows-1252. The returned length is the BOM to skip before decoding.
+func detectTextEncoding(sample []byte) (textEncoding, int) {
+	switch {
+	case bytes.HasPrefix(sample, []byte{0xEF, 0xBB, 0xBF}):
+		return encodingUTF8, 3
+	case bytes.HasPrefix(sample, []byte{0xFF, 0xFE}):
+		return encodingUTF16LE, 2
+	case bytes.HasPrefix(sample, []byte{0xFE, 0xFF}):
+		return encodingUTF16BE, 2
+	}
+
+	if units := len(sample) / 2; units >= 2 {
+		evenNULs, oddNULs := 0, 0
+		for i := 0; i+1 < len(sample); i += 2 {
+			if sample[i] == 0 {
+				evenNULs++
+			}
+			if sample[i+1] == 0 {
+				oddNULs++
+			}
+		}
+		threshold := max(1, int(float64(units)*utf16NULRatio))
+		switch {
+		case oddNULs >= threshold && evenNULs <= oddNULs/10:
+			return encodingUTF16LE, 0
+		case evenNULs >= threshold && oddNULs <= evenNULs/10:
+			return encodingUTF16BE, 0
+		}
+	}
+
+	if !isValidUTF8Prefix(sample) {
+		return encodingWindows1252, 0
+	}
+	return encodingUTF8, 0
+}
+
+// isValidUTF8Prefix reports whether b is valid UTF-8, allowing a character cut off at the end of the sample
+func isValidUTF8Prefix(b []byte) bool {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size <= 1 {
+			return !utf8.FullRune(b)
+		}
+		b = b[size:]
+	}
+	return true
+}
+
+// textFile is an open file whose content is transcoded to UTF-8 as it is read
+type textFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (f *textFile) Close() error {
+	return f.file.Close()
+}
+
+// openTextFile opens a text log and returns a reader that yields UTF-8 with any BOM removed
+// UTF-16 and Windows-1252 files are transcoded so line scanners and header detection see plain text
+func openTextFile(filePath string) (io.ReadCloser, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	src := bufio.NewReaderSize(file, encodingSampleSize)
+	sample, _ := src.Peek(encodingSampleSize)
+	encoding, bomLen := detectTextEncoding(sample)
+	if _, err := src.Discard(bomLen); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &textFile{Reader: newDecodingReader(src, encoding), file: file}, nil
+}
+
+// newDecodingReader wraps src so that reads return UTF-8
+func newDecodingReader(src *bufio.Reader, encoding textEncoding) io.Reader {
+	switch encoding {
+	case encodingUTF16LE:
+		return &utf16Reader{src: src, order: binary.LittleEndian}
+	case encodingUTF16BE:
+		return &utf16Reader{src: src, order: binary.BigEndian}
+	case encodingWindows1252:
+		return &windows1252Reader{src: src}
+	default:
+		return src
+	}
+}
+
+// decodeTextContent decodes a whole file's content to UTF-8 using the same detection as openTextFile
+func decodeTextContent(data []byte) string {
+	encoding, bomLen := detectTextEncoding(data)
+	data = data[bomLen:]
+	if encoding == encodingUTF8 {
+		return string(data)
+	}
+	decoded, _ := io.ReadAll(newDecodingReader(bufio.NewReader(bytes.NewReader(data)), encoding))
+	return string(decoded)
+}
+
+// decodeChunkSize is the number of characters decoded per refill
+const decodeChunkSize = 4096
+
+// utf16Reader transcodes a UTF-16 stream to UTF-8
+// Unpaired surrogates become U+FFFD and a trailing odd byte is dropped
+type utf16Reader struct {
+	src   *bufio.Reader
+	order binary.ByteOrder
+	buf   []byte // Decoded UTF-8 not yet returned
+	err   error
+}
+
+func (r *utf16Reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 && r.err == nil {
+		r.fill()
+	}
+	if len(r.buf) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *utf16Reader) fill() {
+	out := make([]byte, 0, decodeChunkSize*3)
+	var unit [2]byte
+	for i := 0; i < decodeChunkSize; i++ {
+		if _, err := io.ReadFull(r.src, unit[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			r.err = err
+			break
+		}
+		c := rune(r.order.Uint16(unit[:]))
+		if utf16.IsSurrogate(c) {
+			c = utf8.RuneError
+			if next, err := r.src.Peek(2); err == nil {
+				if decoded := utf16.DecodeRune(rune(r.order.Uint16(unit[:])), rune(r.order.Uint16(next))); decoded != utf8.RuneError {
+					c = decoded
+					r.src.Discard(2)
+				}
+			}
+		}
+		out = utf8.AppendRune(out, c)
+	}
+	r.buf = out
+}
+
+// windows1252Reader transcodes a Windows-1252 stream to UTF-8
+type windows1252Reader struct {
+	src *bufio.Reader
+	buf []byte // Decoded UTF-8 not yet returned
+	err error
+}
+
+func (r *windows1252Reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 && r.err == nil {
+		r.fill()
+	}
+	if len(r.buf) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *windows1252Reader) fill() {
+	chunk := make([]byte, decodeChunkSize)
+	n, err := r.src.Read(chunk)
+	out := make([]byte, 0, n*2)
+	for _, b := range chunk[:n] {
+		switch {
+		case b < 0x80:
+			out = append(out, b)
+		case b < 0xA0:
+			out = utf8.AppendRune(out, windows1252High[b-0x80])
+		default:
+			out = utf8.AppendRune(out, rune(b))
+		}
+	}
+	r.buf = out
+	r.err = err
+}
+
+// newXMLDecoder returns an XML decoder for content already transcoded by openTextFile or decodeTextContent
+// The encoding named in the XML declaration (often UTF-16 for exported tasks and events) is accepted as-is
+func newXMLDecoder(r io.Reader) *xml.Decoder {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	return decoder
+}
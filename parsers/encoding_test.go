@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, optionally with a BOM
+func encodeUTF16(s string, order binary.AppendByteOrder, bom bool) []byte {
+	var b []byte
+	if bom {
+		b = order.AppendUint16(b, 0xFEFF)
+	}
+	for _, unit := range utf16.Encode([]rune(s)) {
+		b = order.AppendUint16(b, unit)
+	}
+	return b
+}
+
+func TestDetectTextEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		sample   []byte
+		encoding textEncoding
+		bomLen   int
+	}{
+		{"utf-8", []byte("plain ascii line\n"), encodingUTF8, 0},
+		{"utf-8 bom", []byte("\xEF\xBB\xBFline"), encodingUTF8, 3},
+		{"utf-8 cut mid character", []byte("Gr\xC3\xBC\xC3"), encodingUTF8, 0},
+		{"utf-16le bom", encodeUTF16("line", binary.LittleEndian, true), encodingUTF16LE, 2},
+		{"utf-16be bom", encodeUTF16("line", binary.BigEndian, true), encodingUTF16BE, 2},
+		{"utf-16le no bom", encodeUTF16("[Device Install]", binary.LittleEndian, false), encodingUTF16LE, 0},
+		{"utf-16be no bom", encodeUTF16("[Device Install]", binary.BigEndian, false), encodingUTF16BE, 0},
+		{"windows-1252", []byte("Benutzer M\xFCller \x80 5"), encodingWindows1252, 0},
+	}
+	for _, tt := range tests {
+		encoding, bomLen := detectTextEncoding(tt.sample)
+		if encoding != tt.encoding || bomLen != tt.bomLen {
+			t.Errorf("%s: expected encoding %d with BOM %d, got %d with BOM %d", tt.name, tt.encoding, tt.bomLen, encoding, bomLen)
+		}
+	}
+}
+
+func TestOpenTextFileTranscodes(t *testing.T) {
+	const text = "Grüße \U0001F600 from C:\\Windows\r\nsecond line\r\n"
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"le.log":     encodeUTF16(text, binary.LittleEndian, true),
+		"be.log":     encodeUTF16(text, binary.BigEndian, true),
+		"utf8.log":   append([]byte("\xEF\xBB\xBF"), text...),
+		"cp1252.log": []byte("caf\xE9 \x93quoted\x94 \x80 5\r\n"),
+	}
+	expected := map[string]string{
+		"le.log":     text,
+		"be.log":     text,
+		"utf8.log":   text,
+		"cp1252.log": "café “quoted” € 5\r\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		file, err := openTextFile(path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", name, err)
+		}
+		decoded, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(decoded) != expected[name] {
+			t.Errorf("%s: expected %q, got %q", name, expected[name], decoded)
+		}
+	}
+}
+
+func TestUTF16LogsParse(t *testing.T) {
+	dir := t.TempDir()
+
+	transcriptPath := filepath.Join(dir, "PowerShell_output.txt")
+	transcript := strings.Join([]string{
+		"**********************",
+		"Windows PowerShell transcript start",
+		"Start time: 20230421153045",
+		"Username: CORP\\jdoe",
+		"Machine: WS01 (Microsoft Windows NT 10.0.19045.0)",
+		"**********************",
+		"PS C:\\Users\\jdoe> whoami",
+		"corp\\jdoe",
+		"",
+	}, "\r\n")
+	if err := os.WriteFile(transcriptPath, encodeUTF16(transcript, binary.LittleEndian, true), 0644); err != nil {
+		t.Fatalf("Failed to write transcript: %v", err)
+	}
+	parser, err := GetParserForFile(transcriptPath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*PowerShellTranscriptParser); !ok {
+		t.Fatalf("Expected UTF-16 transcript to be detected from its header, got %T", parser)
+	}
+
+	setupapiPath := filepath.Join(dir, "setupapi.dev.log")
+	setupapi := strings.Join([]string{
+		"2023-04-21 15:30:45, Info                  SETUPAPI  Device install started",
+		"2023-04-21 15:30:46, Info                  SETUPAPI  Device install finished",
+		"",
+	}, "\r\n")
+	if err := os.WriteFile(setupapiPath, encodeUTF16(setupapi, binary.LittleEndian, true), 0644); err != nil {
+		t.Fatalf("Failed to write setupapi log: %v", err)
+	}
+	events, err := (&WindowsTextParser{}).Parse(setupapiPath)
+	if err != nil {
+		t.Fatalf("Failed to parse setupapi log: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Timestamp.IsZero() || strings.ContainsRune(events[0].Message, 0) ||
+		!strings.Contains(events[0].Message, "Device install started") {
+		t.Errorf("Unexpected event at %s: %q", events[0].Timestamp, events[0].Message)
+	}
+}
@@ -0,0 +1,611 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+	"unicode/utf16"
+)
+
+// ============================================================================
+// Extensible Storage Engine (ESE/JET Blue) database reader
+// ============================================================================
+
+// Read-only reader for the ESE databases used by Windows artifacts (SRUDB.dat, Windows.edb, WebCacheV01.dat).
+// It walks the catalog and table B-trees directly; separated long values and XPRESS-compressed
+// columns are not supported and read as nil.
+
+// eseSignature is the database file header magic at offset 4
+var eseSignature = []byte{0xEF, 0xCD, 0xAB, 0x89}
+
+const (
+	eseCatalogPage = 4 // FDP of the MSysObjects catalog
+
+	// Page flags
+	esePageLeaf      = 0x2
+	esePageEmpty     = 0x8
+	esePageSpaceTree = 0x20
+	esePageLongValue = 0x80
+
+	// Page tag flags
+	eseTagDefunct   = 0x2
+	eseTagCommonKey = 0x4
+
+	// Tagged column data flags
+	eseTaggedCompressed = 0x2
+	eseTaggedLongValue  = 0x4
+	eseTaggedMultiValue = 0x8
+
+	// Catalog object types
+	eseCatalogTable  = 1
+	eseCatalogColumn = 2
+
+	// Column types
+	eseColBit          = 1
+	eseColUnsignedByte = 2
+	eseColShort        = 3
+	eseColLong         = 4
+	eseColCurrency     = 5
+	eseColFloat        = 6
+	eseColDouble       = 7
+	eseColDateTime     = 8
+	eseColBinary       = 9
+	eseColText         = 10
+	eseColLongBinary   = 11
+	eseColLongText     = 12
+	eseColUnsignedLong = 14
+	eseColLongLong     = 15
+	eseColGUID         = 16
+	eseColUnsignedShrt = 17
+
+	eseCodepageUnicode = 1200
+)
+
+// eseColumn describes a table column from the catalog
+type eseColumn struct {
+	id       uint32
+	name     string
+	colType  uint32
+	size     uint32
+	codepage uint32
+}
+
+// eseTable describes a table from the catalog; columns are sorted by ID
+type eseTable struct {
+	name    string
+	objID   uint32
+	fdp     uint32
+	columns []*eseColumn
+}
+
+// eseRecord maps column names to decoded values (int64, uint64, float64, time.Time, string, []byte)
+type eseRecord map[string]interface{}
+
+// eseDatabase is an open ESE database file
+type eseDatabase struct {
+	file     *os.File
+	pageSize uint32
+	revision uint32
+	tables   map[string]*eseTable
+}
+
+// eseCatalogColumns is the fixed layout of MSysObjects needed to bootstrap the catalog
+var eseCatalogColumns = []*eseColumn{
+	{id: 1, name: "ObjidTable", colType: eseColLong, size: 4},
+	{id: 2, name: "Type", colType: eseColShort, size: 2},
+	{id: 3, name: "Id", colType: eseColLong, size: 4},
+	{id: 4, name: "ColtypOrPgnoFDP", colType: eseColLong, size: 4},
+	{id: 5, name: "SpaceUsage", colType: eseColLong, size: 4},
+	{id: 6, name: "Flags", colType: eseColLong, size: 4},
+	{id: 7, name: "PagesOrLocale", colType: eseColLong, size: 4},
+	{id: 128, name: "Name", colType: eseColText, size: 255},
+}
+
+// eseFixedSizes are the storage sizes of fixed-size column types
+var eseFixedSizes = map[uint32]uint32{
+	eseColBit:          1,
+	eseColUnsignedByte: 1,
+	eseColShort:        2,
+	eseColLong:         4,
+	eseColCurrency:     8,
+	eseColFloat:        4,
+	eseColDouble:       8,
+	eseColDateTime:     8,
+	eseColUnsignedLong: 4,
+	eseColLongLong:     8,
+	eseColGUID:         16,
+	eseColUnsignedShrt: 2,
+}
+
+// isESEDatabase checks the database header signature
+func isESEDatabase(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 8)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return false
+	}
+	return bytes.Equal(header[4:8], eseSignature)
+}
+
+// openESEDatabase opens an ESE database and loads its catalog
+func openESEDatabase(filePath string) (*eseDatabase, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ESE database: %w", err)
+	}
+
+	header := make([]byte, 240)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read ESE header: %w", err)
+	}
+	if !bytes.Equal(header[4:8], eseSignature) {
+		file.Close()
+		return nil, fmt.Errorf("not an ESE database: %s", filePath)
+	}
+
+	db := &eseDatabase{
+		file:     file,
+		revision: binary.LittleEndian.Uint32(header[232:236]),
+		pageSize: binary.LittleEndian.Uint32(header[236:240]),
+		tables:   make(map[string]*eseTable),
+	}
+	switch db.pageSize {
+	case 2048, 4096, 8192, 16384, 32768:
+	default:
+		file.Close()
+		return nil, fmt.Errorf("unsupported ESE page size %d", db.pageSize)
+	}
+
+	if err := db.loadCatalog(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Close closes the underlying file
+func (db *eseDatabase) Close() error {
+	return db.file.Close()
+}
+
+// loadCatalog reads table and column definitions from MSysObjects
+func (db *eseDatabase) loadCatalog() error {
+	catalog := &eseTable{name: "MSysObjects", fdp: eseCatalogPage, columns: eseCatalogColumns}
+	tablesByID := make(map[uint32]*eseTable)
+	var columnRows []eseRecord
+
+	err := db.walk(eseCatalogPage, func(data []byte) error {
+		row, err := db.decodeRecord(catalog, data)
+		if err != nil {
+			return err
+		}
+		switch asInt64(row["Type"]) {
+		case eseCatalogTable:
+			name, _ := row["Name"].(string)
+			table := &eseTable{
+				name:  name,
+				objID: uint32(asInt64(row["ObjidTable"])),
+				fdp:   uint32(asInt64(row["ColtypOrPgnoFDP"])),
+			}
+			tablesByID[table.objID] = table
+			db.tables[name] = table
+		case eseCatalogColumn:
+			columnRows = append(columnRows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read ESE catalog: %w", err)
+	}
+	if len(db.tables) == 0 {
+		return fmt.Errorf("ESE catalog contains no tables")
+	}
+
+	for _, row := range columnRows {
+		table, ok := tablesByID[uint32(asInt64(row["ObjidTable"]))]
+		if !ok {
+			continue
+		}
+		name, _ := row["Name"].(string)
+		column := &eseColumn{
+			id:       uint32(asInt64(row["Id"])),
+			name:     name,
+			colType:  uint32(asInt64(row["ColtypOrPgnoFDP"])),
+			size:     uint32(asInt64(row["SpaceUsage"])),
+			codepage: uint32(asInt64(row["PagesOrLocale"])),
+		}
+		if size, ok := eseFixedSizes[column.colType]; ok && column.id < 128 {
+			column.size = size
+		}
+		table.columns = append(table.columns, column)
+	}
+	for _, table := range db.tables {
+		sort.Slice(table.columns, func(i, j int) bool { return table.columns[i].id < table.columns[j].id })
+	}
+	return nil
+}
+
+// Records returns all records of the named table
+func (db *eseDatabase) Records(tableName string) ([]eseRecord, error) {
+	table, ok := db.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("ESE table not found: %s", tableName)
+	}
+	var records []eseRecord
+	err := db.walk(table.fdp, func(data []byte) error {
+		record, err := db.decodeRecord(table, data)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// readPage reads a page by number; page N is stored after the two header pages at (N+1)*pageSize
+func (db *eseDatabase) readPage(pageNum uint32) ([]byte, error) {
+	page := make([]byte, db.pageSize)
+	if _, err := db.file.ReadAt(page, int64(pageNum+1)*int64(db.pageSize)); err != nil {
+		return nil, fmt.Errorf("failed to read ESE page %d: %w", pageNum, err)
+	}
+	return page, nil
+}
+
+// pageHeaderSize returns the page header size; large pages carry a 40-byte header extension
+func (db *eseDatabase) pageHeaderSize() int {
+	if db.pageSize > 8192 {
+		return 80
+	}
+	return 40
+}
+
+// pageValues returns the page flags and the data of each non-defunct tag (skipping tag 0, the page key)
+func (db *eseDatabase) pageValues(pageNum uint32, page []byte) (uint32, [][]byte, []uint8, error) {
+	le := binary.LittleEndian
+	flags := le.Uint32(page[36:40])
+	tagCount := int(le.Uint16(page[34:36]))
+	headerSize := db.pageHeaderSize()
+	small := db.pageSize <= 8192
+	if len(page)-4*tagCount < headerSize {
+		return 0, nil, nil, fmt.Errorf("malformed ESE page %d: %d tags overlap the page header", pageNum, tagCount)
+	}
+
+	var values [][]byte
+	var tagFlags []uint8
+	for i := 1; i < tagCount; i++ {
+		tagOffset := len(page) - 4*(i+1)
+		size := int(le.Uint16(page[tagOffset:]))
+		offset := int(le.Uint16(page[tagOffset+2:]))
+		var tf uint8
+		if small {
+			tf = uint8(offset >> 13)
+			size &= 0x1fff
+			offset &= 0x1fff
+		} else {
+			size &= 0x7fff
+			offset &= 0x7fff
+		}
+
+		start := headerSize + offset
+		if size == 0 {
+			continue
+		}
+		if start+size > len(page)-4*tagCount {
+			return 0, nil, nil, fmt.Errorf("malformed ESE page %d: tag %d extends past the page data", pageNum, i)
+		}
+		value := make([]byte, size)
+		copy(value, page[start:start+size])
+		if !small && size >= 2 {
+			tf = value[1] >> 5
+			value[1] &= 0x1f
+		}
+		if tf&eseTagDefunct != 0 {
+			continue
+		}
+		values = append(values, value)
+		tagFlags = append(tagFlags, tf)
+	}
+	return flags, values, tagFlags, nil
+}
+
+// walk visits the data of every leaf entry in the B-tree rooted at pageNum
+// Malformed pages and errors returned by visit stop the walk.
+func (db *eseDatabase) walk(pageNum uint32, visit func(data []byte) error) error {
+	visited := make(map[uint32]bool)
+	var walkPage func(pageNum uint32) error
+	walkPage = func(pageNum uint32) error {
+		if visited[pageNum] {
+			return nil
+		}
+		visited[pageNum] = true
+
+		page, err := db.readPage(pageNum)
+		if err != nil {
+			return err
+		}
+		flags, values, tagFlags, err := db.pageValues(pageNum, page)
+		if err != nil {
+			return err
+		}
+		if flags&(esePageEmpty|esePageSpaceTree|esePageLongValue) != 0 {
+			return nil
+		}
+
+		for i, value := range values {
+			pos := 0
+			if tagFlags[i]&eseTagCommonKey != 0 {
+				pos += 2
+			}
+			if pos+2 > len(value) {
+				return fmt.Errorf("malformed ESE page %d: entry %d is too short", pageNum, i)
+			}
+			pos += 2 + int(binary.LittleEndian.Uint16(value[pos:]))
+			if pos > len(value) {
+				return fmt.Errorf("malformed ESE page %d: entry %d key exceeds its data", pageNum, i)
+			}
+			data := value[pos:]
+
+			if flags&esePageLeaf != 0 {
+				if err := visit(data); err != nil {
+					return fmt.Errorf("malformed ESE page %d: entry %d: %w", pageNum, i, err)
+				}
+				continue
+			}
+			if len(data) < 4 {
+				continue
+			}
+			if err := walkPage(binary.LittleEndian.Uint32(data[len(data)-4:])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walkPage(pageNum)
+}
+
+// decodeRecord decodes the fixed, variable and tagged columns of a data record
+func (db *eseDatabase) decodeRecord(table *eseTable, data []byte) (eseRecord, error) {
+	record := make(eseRecord)
+	if len(data) < 4 {
+		return nil, fmt.Errorf("record of %d bytes is too short", len(data))
+	}
+	le := binary.LittleEndian
+	lastFixed := uint32(data[0])
+	lastVar := uint32(data[1])
+	varOffset := int(le.Uint16(data[2:4]))
+	if varOffset < 4 || varOffset > len(data) {
+		return nil, fmt.Errorf("variable column offset %d is outside the record", varOffset)
+	}
+
+	// Fixed columns are packed in ID order from offset 4, followed by a null bitmap
+	nullBitmap := data[max(0, varOffset-int((lastFixed+7)/8)):varOffset]
+	offset := 4
+	for _, column := range table.columns {
+		if column.id >= 128 || column.id > lastFixed {
+			continue
+		}
+		end := offset + int(column.size)
+		if end > len(data) {
+			break
+		}
+		bit := column.id - 1
+		if int(bit/8) >= len(nullBitmap) || nullBitmap[bit/8]&(1<<(bit%8)) == 0 {
+			record[column.name] = decodeESEValue(column, data[offset:end])
+		}
+		offset = end
+	}
+
+	// Variable columns have a table of end offsets; the high bit marks a null value
+	varCount := 0
+	if lastVar > 127 {
+		varCount = int(lastVar - 127)
+	}
+	varDataStart := varOffset + varCount*2
+	if varDataStart > len(data) {
+		return nil, fmt.Errorf("variable column table exceeds the record")
+	}
+	prevEnd := 0
+	for i := 0; i < varCount; i++ {
+		entry := le.Uint16(data[varOffset+2*i:])
+		end := int(entry & 0x7fff)
+		if entry&0x8000 == 0 && varDataStart+end <= len(data) && end >= prevEnd {
+			if column := table.column(uint32(128 + i)); column != nil {
+				record[column.name] = decodeESEValue(column, data[varDataStart+prevEnd:varDataStart+end])
+			}
+		}
+		if end >= prevEnd {
+			prevEnd = end
+		}
+	}
+
+	// Tagged columns follow as an (ID, offset) directory and their values
+	tagged := data[min(len(data), varDataStart+prevEnd):]
+	if len(tagged) == 0 {
+		return record, nil
+	}
+	if len(tagged) < 4 {
+		return nil, fmt.Errorf("tagged column directory is truncated")
+	}
+	largeFormat := db.revision >= 0x11 && db.pageSize > 8192
+	directorySize := int(le.Uint16(tagged[2:4]) & 0x3fff)
+	if largeFormat {
+		directorySize = int(le.Uint16(tagged[2:4]) & 0x7fff)
+	}
+	if directorySize > len(tagged) {
+		return nil, fmt.Errorf("tagged column directory of %d bytes exceeds the record", directorySize)
+	}
+	for i := 0; i*4+4 <= directorySize && i*4+4 <= len(tagged); i++ {
+		id := uint32(le.Uint16(tagged[i*4:]))
+		rawOffset := le.Uint16(tagged[i*4+2:])
+		start, hasFlags := int(rawOffset&0x3fff), rawOffset&0x4000 != 0
+		if largeFormat {
+			start, hasFlags = int(rawOffset&0x7fff), true
+		}
+		end := len(tagged)
+		if i*4+8 <= directorySize && i*4+8 <= len(tagged) {
+			end = int(le.Uint16(tagged[i*4+6:]) & 0x3fff)
+			if largeFormat {
+				end = int(le.Uint16(tagged[i*4+6:]) & 0x7fff)
+			}
+		}
+		if start > end || end > len(tagged) {
+			continue
+		}
+		column := table.column(id)
+		if column == nil {
+			continue
+		}
+
+		value := tagged[start:end]
+		if hasFlags && len(value) > 0 {
+			flags := value[0]
+			value = value[1:]
+			switch {
+			case flags&eseTaggedLongValue != 0:
+				continue
+			case flags&eseTaggedMultiValue != 0:
+				record[column.name] = value
+				continue
+			case flags&eseTaggedCompressed != 0:
+				if value = decompressESE7Bit(value); value == nil {
+					continue
+				}
+			}
+		}
+		record[column.name] = decodeESEValue(column, value)
+	}
+	return record, nil
+}
+
+// column returns the column with the given ID, or nil
+func (t *eseTable) column(id uint32) *eseColumn {
+	i := sort.Search(len(t.columns), func(i int) bool { return t.columns[i].id >= id })
+	if i < len(t.columns) && t.columns[i].id == id {
+		return t.columns[i]
+	}
+	return nil
+}
+
+// decompressESE7Bit expands 7-bit ASCII (type 1) and 7-bit Unicode (type 2) compressed values
+func decompressESE7Bit(data []byte) []byte {
+	if len(data) < 2 {
+		return nil
+	}
+	scheme := data[0] >> 3
+	if scheme != 1 && scheme != 2 {
+		return nil
+	}
+	packed := data[1:]
+	totalBits := (len(packed)-1)*8 + int(data[0]&0x7) + 1
+	out := make([]byte, 0, totalBits/7*2)
+	for bit := 0; bit+7 <= totalBits; bit += 7 {
+		b := uint16(packed[bit/8]) >> (bit % 8)
+		if bit%8 > 1 && bit/8+1 < len(packed) {
+			b |= uint16(packed[bit/8+1]) << (8 - bit%8)
+		}
+		out = append(out, byte(b&0x7f))
+		if scheme == 2 {
+			out = append(out, 0)
+		}
+	}
+	return out
+}
+
+// decodeESEValue converts raw column data to a Go value based on the column type
+func decodeESEValue(column *eseColumn, data []byte) interface{} {
+	le := binary.LittleEndian
+	switch column.colType {
+	case eseColBit, eseColUnsignedByte:
+		if len(data) >= 1 {
+			return int64(data[0])
+		}
+	case eseColShort:
+		if len(data) >= 2 {
+			return int64(int16(le.Uint16(data)))
+		}
+	case eseColUnsignedShrt:
+		if len(data) >= 2 {
+			return int64(le.Uint16(data))
+		}
+	case eseColLong:
+		if len(data) >= 4 {
+			return int64(int32(le.Uint32(data)))
+		}
+	case eseColUnsignedLong:
+		if len(data) >= 4 {
+			return int64(le.Uint32(data))
+		}
+	case eseColCurrency, eseColLongLong:
+		if len(data) >= 8 {
+			return int64(le.Uint64(data))
+		}
+	case eseColFloat:
+		if len(data) >= 4 {
+			return float64(math.Float32frombits(le.Uint32(data)))
+		}
+	case eseColDouble:
+		if len(data) >= 8 {
+			return math.Float64frombits(le.Uint64(data))
+		}
+	case eseColDateTime:
+		if len(data) >= 8 {
+			return oleDateToTime(math.Float64frombits(le.Uint64(data)))
+		}
+	case eseColText, eseColLongText:
+		if column.codepage == eseCodepageUnicode {
+			return utf16LEString(data)
+		}
+		return string(bytes.TrimRight(data, "\x00"))
+	case eseColGUID:
+		if len(data) >= 16 {
+			return fmt.Sprintf("{%08X-%04X-%04X-%X-%X}", le.Uint32(data), le.Uint16(data[4:]), le.Uint16(data[6:]), data[8:10], data[10:16])
+		}
+	default:
+		return data
+	}
+	return nil
+}
+
+// oleDateToTime converts an OLE Automation date (days since 1899-12-30) to UTC
+func oleDateToTime(days float64) time.Time {
+	if days == 0 || math.IsNaN(days) || math.IsInf(days, 0) {
+		return time.Time{}
+	}
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return base.Add(time.Duration(days * float64(24*time.Hour)))
+}
+
+// utf16LEString decodes a NUL-terminated UTF-16LE byte slice
+func utf16LEString(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		unit := binary.LittleEndian.Uint16(data[i:])
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+	return string(utf16.Decode(units))
+}
+
+// asInt64 returns an integer record value, or 0 when the value is missing or not an integer
+func asInt64(value interface{}) int64 {
+	if v, ok := value.(int64); ok {
+		return v
+	}
+	return 0
+}
@@ -67,7 +67,7 @@ func (p *EvtxParser) convertEvtxEvent(e *evtx.GoEvtxMap, source, filePath string
 	}
 
 	// Extract timestamp
-	timestamp := time.Now().UTC()
+	var timestamp time.Time
 	if systemTime, err := e.GetTime(&evtx.SystemTimePath); err == nil {
 		timestamp = systemTime
 	}
@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -37,6 +36,10 @@ var (
 	// Example: Apr 21 2023 15:30:45: %ASA-6-302013: Built inbound TCP connection 12345 for outside:192.168.1.100/54321 (192.168.1.100/54321) to inside:10.0.0.50/443 (10.0.0.50/443)
 	ciscoASAPattern = regexp.MustCompile(`^([A-Z][a-z]{2}\s+\d{1,2}\s+\d{4}\s+\d{2}:\d{2}:\d{2}):\s+%ASA-(\d)-(\d+):\s+(.*)$`)
 
+	// Cisco ASA log relayed through syslog with a device hostname (year optional)
+	// Example: Apr 21 15:30:45 fw01 : %ASA-6-113039: Group <GroupPolicy_VPN> User <jdoe> IP <203.0.113.5> AnyConnect parent session started.
+	ciscoASASyslogPattern = regexp.MustCompile(`^([A-Z][a-z]{2}\s+\d{1,2}(?:\s+\d{4})?\s+\d{2}:\d{2}:\d{2})\s+\S+\s*:?\s+%ASA-(\d)-(\d+):\s+(.*)$`)
+
 	// Cisco ASA connection patterns for extracting IPs and ports
 	// Built/Teardown patterns: for interface:IP/port to interface:IP/port
 	ciscoASAConnPattern = regexp.MustCompile(`(?:for|from)\s+(\S+):(\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3})/(\d+).*?(?:to)\s+(\S+):(\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3})/(\d+)`)
@@ -57,7 +60,7 @@ func (p *WindowsFirewallParser) CanParse(filePath string) bool {
 
 // Parse parses a Windows Firewall log file and returns a slice of events
 func (p *WindowsFirewallParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -101,7 +104,7 @@ func (p *WindowsFirewallParser) Parse(filePath string) ([]*core.Event, error) {
 			remainder := matches[9]
 
 			// Parse timestamp
-			timestamp, err := time.Parse("2006-01-02 15:04:05", dateStr+" "+timeStr)
+			timestamp, err := parseLogTime("2006-01-02 15:04:05", dateStr+" "+timeStr)
 			if err != nil {
 				timestamp = time.Time{}
 			}
@@ -171,7 +174,7 @@ func (p *IptablesParser) CanParse(filePath string) bool {
 
 // Parse parses an iptables/UFW log file and returns a slice of events
 func (p *IptablesParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -186,7 +189,7 @@ func (p *IptablesParser) Parse(filePath string) ([]*core.Event, error) {
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 200))
 	lineNum := 0
 	source := filepath.Base(filePath)
-	currentYear := time.Now().Year()
+	years := newYearInferrer(filePath)
 
 	for scanner.Scan() {
 		lineNum++
@@ -208,15 +211,8 @@ func (p *IptablesParser) Parse(filePath string) ([]*core.Event, error) {
 			action := matches[3] // e.g., "UFW BLOCK", "UFW ALLOW"
 			details := matches[4]
 
-			// Parse timestamp (RFC 3164 format without year)
-			timeStr := fmt.Sprintf("%d %s", currentYear, timestampStr)
-			timestamp, err := time.Parse("2006 Jan  2 15:04:05", timeStr)
-			if err != nil {
-				timestamp, err = time.Parse("2006 Jan 2 15:04:05", timeStr)
-				if err != nil {
-					timestamp = time.Time{}
-				}
-			}
+			// Parse timestamp (RFC 3164 format without year; the year is inferred once the whole file is read)
+			timestamp := parseBSDTimestamp(timestampStr, yearlessPlaceholder)
 
 			// Extract connection details
 			srcIP := extractField(iptablesSrcPattern, details)
@@ -288,12 +284,14 @@ func (p *IptablesParser) Parse(filePath string) ([]*core.Event, error) {
 			)
 		}
 
+		years.add(event)
 		events = append(events, event)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	years.apply()
 
 	fmt.Printf("Parsed Iptables file: %s (found %d events)\n", filePath, len(events))
 	return events, nil
@@ -315,12 +313,14 @@ func (p *CiscoASAParser) CanParse(filePath string) bool {
 	baseName := strings.ToLower(filepath.Base(filePath))
 	return strings.Contains(baseName, "asa") ||
 		strings.Contains(baseName, "cisco") ||
-		strings.Contains(baseName, "pix")
+		strings.Contains(baseName, "pix") ||
+		countHeaderMatches(filePath, ciscoASAPattern) > 0 ||
+		countHeaderMatches(filePath, ciscoASASyslogPattern) > 0
 }
 
 // Parse parses a Cisco ASA log file and returns a slice of events
 func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -335,6 +335,8 @@ func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 200))
 	lineNum := 0
 	source := filepath.Base(filePath)
+	years := newYearInferrer(filePath)
+	vpnSessions := newASAVPNSessions()
 
 	for scanner.Scan() {
 		lineNum++
@@ -350,6 +352,9 @@ func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
 		var event *core.Event
 
 		matches := ciscoASAPattern.FindStringSubmatch(lineForRegex)
+		if matches == nil {
+			matches = ciscoASASyslogPattern.FindStringSubmatch(lineForRegex)
+		}
 		if matches != nil {
 			timestampStr := matches[1]
 			severity := matches[2]
@@ -357,12 +362,13 @@ func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
 			message := matches[4]
 
 			// Parse timestamp: Apr 21 2023 15:30:45
-			timestamp, err := time.Parse("Jan 2 2006 15:04:05", timestampStr)
+			timestamp, err := parseLogTime("Jan 2 2006 15:04:05", timestampStr)
 			if err != nil {
 				// Try alternate format with padded day
-				timestamp, err = time.Parse("Jan  2 2006 15:04:05", timestampStr)
+				timestamp, err = parseLogTime("Jan  2 2006 15:04:05", timestampStr)
 				if err != nil {
-					timestamp = time.Time{}
+					// Syslog-relayed messages may omit the year
+					timestamp = parseBSDTimestamp(timestampStr, yearlessPlaceholder)
 				}
 			}
 
@@ -403,16 +409,30 @@ func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
 			// Convert message ID to integer for event ID
 			eventID, _ := strconv.Atoi(msgID)
 
+			// AnyConnect/WebVPN messages identify the user and are correlated into sessions
+			eventType := "CiscoASA"
+			user, host := "", ""
+			score := 0.0
+			if vpn := vpnSessions.track(msgID, message); vpn != nil {
+				eventType = "CiscoASAVPN"
+				user, host = vpn.user, vpn.publicIP
+				msg += vpn.describe()
+				if vpn.failed {
+					score = 0.5
+				}
+			}
+
 			event = core.NewEvent(
 				timestamp,
 				source,
-				"CiscoASA",
+				eventType,
 				eventID,
-				"",    // User not typically in ASA logs
-				"",    // Host implicit
+				user,
+				host,
 				msg,
 				filePath,
 			)
+			event.Score = score
 		} else {
 			// Fallback for unparseable lines - create raw event
 			event = core.NewEvent(
@@ -427,12 +447,14 @@ func (p *CiscoASAParser) Parse(filePath string) ([]*core.Event, error) {
 			)
 		}
 
+		years.add(event)
 		events = append(events, event)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	years.apply()
 
 	fmt.Printf("Parsed Cisco ASA file: %s (found %d events)\n", filePath, len(events))
 	return events, nil
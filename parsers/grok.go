@@ -0,0 +1,117 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// grokReferencePattern matches %{PATTERN} and %{PATTERN:capture} references
+var grokReferencePattern = regexp.MustCompile(`%\{(\w+)(?::([\w.@-]+))?\}`)
+
+// grokBuiltinPatterns is a subset of the Logstash grok pattern library covering common log fields
+var grokBuiltinPatterns = map[string]string{
+	"USERNAME":          `[a-zA-Z0-9._\\-]+`,
+	"USER":              `%{USERNAME}`,
+	"EMAILADDRESS":      `[a-zA-Z0-9!#$%&'*+/=?^_{|}~.-]+@[a-zA-Z0-9.-]+`,
+	"INT":               `[+-]?[0-9]+`,
+	"POSINT":            `[1-9][0-9]*`,
+	"NONNEGINT":         `[0-9]+`,
+	"BASE10NUM":         `[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)`,
+	"NUMBER":            `%{BASE10NUM}`,
+	"BASE16NUM":         `(?:0[xX])?[0-9A-Fa-f]+`,
+	"WORD":              `\b\w+\b`,
+	"NOTSPACE":          `\S+`,
+	"SPACE":             `\s*`,
+	"DATA":              `.*?`,
+	"GREEDYDATA":        `.*`,
+	"QUOTEDSTRING":      `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`,
+	"QS":                `%{QUOTEDSTRING}`,
+	"UUID":              `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC":               `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}|(?:[A-Fa-f0-9]{4}\.){2}[A-Fa-f0-9]{4}`,
+	"IPV4":              `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":              `(?:[0-9A-Fa-f]{0,4}:){2,7}[0-9A-Fa-f]{0,4}(?:%\w+)?`,
+	"IP":                `%{IPV6}|%{IPV4}`,
+	"HOSTNAME":          `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?\b`,
+	"IPORHOST":          `%{IP}|%{HOSTNAME}`,
+	"HOSTPORT":          `%{IPORHOST}:%{POSINT}`,
+	"PATH":              `(?:/[^\s]*|[A-Za-z]:\\[^\s]*|\\\\[^\s]*)`,
+	"URIPATH":           `/[^\s?#]*`,
+	"URIPARAM":          `\?[^\s#]*`,
+	"URIPATHPARAM":      `%{URIPATH}(?:%{URIPARAM})?`,
+	"URI":               `[A-Za-z][A-Za-z0-9+.-]*://\S+`,
+	"LOGLEVEL":          `(?i:trace|debug|info|notice|warn(?:ing)?|err(?:or)?|crit(?:ical)?|fatal|severe|alert|emerg(?:ency)?)`,
+	"MONTH":             `\b(?:[Jj]an(?:uary)?|[Ff]eb(?:ruary)?|[Mm]ar(?:ch)?|[Aa]pr(?:il)?|[Mm]ay|[Jj]un(?:e)?|[Jj]ul(?:y)?|[Aa]ug(?:ust)?|[Ss]ep(?:tember)?|[Oo]ct(?:ober)?|[Nn]ov(?:ember)?|[Dd]ec(?:ember)?)\b`,
+	"MONTHNUM":          `0?[1-9]|1[0-2]`,
+	"MONTHDAY":          `(?:0[1-9]|[12][0-9]|3[01]|[1-9])`,
+	"DAY":               `(?:Mon(?:day)?|Tue(?:sday)?|Wed(?:nesday)?|Thu(?:rsday)?|Fri(?:day)?|Sat(?:urday)?|Sun(?:day)?)`,
+	"YEAR":              `\d{2,4}`,
+	"HOUR":              `2[0123]|[01]?[0-9]`,
+	"MINUTE":            `[0-5][0-9]`,
+	"SECOND":            `(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"ISO8601_TIMEZONE":  `Z|[+-]%{HOUR}(?::?%{MINUTE})?`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?(?:%{ISO8601_TIMEZONE})?`,
+	"DATE_US":           `%{MONTHNUM}[/-]%{MONTHDAY}[/-]%{YEAR}`,
+	"DATE_EU":           `%{MONTHDAY}[./-]%{MONTHNUM}[./-]%{YEAR}`,
+	"SYSLOGTIMESTAMP":   `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+}
+
+// maxGrokDepth bounds nested pattern expansion so self-referencing definitions fail instead of looping
+const maxGrokDepth = 20
+
+// compileGrok expands a grok expression into a regular expression with named groups
+// Named references %{PATTERN:name} become (?P<name>...); custom patterns override the built-in library
+func compileGrok(expression string, custom map[string]string) (*regexp.Regexp, error) {
+	expanded, err := expandGrok(expression, custom, 0)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grok expression %q: %w", expression, err)
+	}
+	return compiled, nil
+}
+
+// expandGrok replaces pattern references recursively
+func expandGrok(expression string, custom map[string]string, depth int) (string, error) {
+	if depth > maxGrokDepth {
+		return "", fmt.Errorf("grok pattern nesting exceeds %d levels in %q", maxGrokDepth, expression)
+	}
+
+	var expandErr error
+	result := grokReferencePattern.ReplaceAllStringFunc(expression, func(ref string) string {
+		if expandErr != nil {
+			return ""
+		}
+		m := grokReferencePattern.FindStringSubmatch(ref)
+		definition, ok := custom[m[1]]
+		if !ok {
+			definition, ok = grokBuiltinPatterns[m[1]]
+		}
+		if !ok {
+			expandErr = fmt.Errorf("unknown grok pattern %q", m[1])
+			return ""
+		}
+		inner, err := expandGrok(definition, custom, depth+1)
+		if err != nil {
+			expandErr = err
+			return ""
+		}
+		if m[2] == "" {
+			return "(?:" + inner + ")"
+		}
+		return "(?P<" + grokCaptureName(m[2]) + ">" + inner + ")"
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return result, nil
+}
+
+// grokCaptureName converts a capture name to a valid Go group name ("http.status" -> "http_status")
+func grokCaptureName(name string) string {
+	return strings.NewReplacer(".", "_", "-", "_", "@", "").Replace(name)
+}
@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -44,7 +43,7 @@ func (p *IISParser) CanParse(filePath string) bool {
 
 // Parse parses an IIS W3C Extended Log Format file and returns a slice of events
 func (p *IISParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -235,9 +234,9 @@ func (p *IISParser) extractTimestamp(fields []string, fieldIndex map[string]int)
 	}
 
 	for _, layout := range layouts {
-		if t, err := time.Parse(layout, combined); err == nil {
+		if t, err := parseLogTime(layout, combined); err == nil {
 			// IIS logs are in UTC
-			return t.UTC()
+			return t
 		}
 	}
 
@@ -1,30 +1,52 @@
 package parsers
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"io"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"LogZero/core"
 )
 
-// JsonParser implements the Parser interface for JSON files
+// JsonParser implements the Parser interface for JSON, JSONL and NDJSON files
+// Records may be a JSON array, one object per line, or concatenated objects. Fields are taken from the
+// first JSON mapping matching the file, falling back to well-known keys.
 type JsonParser struct{}
 
+// jsonFieldKeys are the keys, or dot-paths, tried in order for event fields no mapping names
+var jsonFieldKeys = map[string][]string{
+	"timestamp":  {"timestamp", "@timestamp", "time", "ts", "eventTime", "event_time", "datetime", "date", "event.created"},
+	"event_type": {"event_type", "eventType", "type", "event.action"},
+	"event_id":   {"event_id", "eventId", "EventID", "event.code"},
+	"user":       {"user", "username", "userName", "user.name"},
+	"host":       {"host", "hostname", "host.name", "computer", "Computer"},
+	"message":    {"message", "msg", "@message", "log"},
+}
+
+// minEpochSeconds is the earliest epoch time accepted from an auto-detected key (1973-03-03);
+// smaller numbers under keys such as "time" are usually durations
+const minEpochSeconds = 1e8
+
 // CanParse checks if this parser can handle the given file
 func (p *JsonParser) CanParse(filePath string) bool {
-	ext := strings.ToLower(filepath.Ext(filePath))
-	return ext == ".json"
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json", ".jsonl", ".ndjson":
+		return true
+	}
+	return claimsJSONFile(filePath)
 }
 
 // Parse parses a JSON file and returns a slice of events
 func (p *JsonParser) Parse(filePath string) ([]*core.Event, error) {
-	// Open the file
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -32,117 +54,393 @@ func (p *JsonParser) Parse(filePath string) ([]*core.Event, error) {
 
 	// Pre-allocate slice with estimated capacity (avg 500 bytes per JSON event)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 500))
-	decoder := json.NewDecoder(file)
+	source := filepath.Base(filePath)
+	mapping := jsonMappingFor(filePath)
+	reader := bufio.NewReader(file)
+	skippedCount := 0
+	timeFormat := ""
 
-	// Check the first token to see if it's an array or object
-	token, err := decoder.Token()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read first token: %w", err)
+	addRecord := func(rawEvent map[string]interface{}, recordNum int) {
+		var event *core.Event
+		event, timeFormat = p.processRecord(rawEvent, mapping, filePath, source, recordNum, timeFormat)
+		events = append(events, event)
 	}
 
-	// Helper function to process a single raw event
-	processEvent := func(rawEvent map[string]interface{}) {
-		// Extract fields from the raw event with safe type assertions
-		timestamp := time.Now().UTC() // Default to current time
+	first, err := firstJSONByte(reader)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
 
-		// Safely extract timestamp
-		if tsVal, ok := rawEvent["timestamp"]; ok {
-			if tsStr, ok := tsVal.(string); ok {
-				if parsedTime, err := time.Parse(time.RFC3339, tsStr); err == nil {
-					timestamp = parsedTime
+	switch {
+	case first == '[':
+		// A single array of records
+		decoder := newJSONDecoder(reader)
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("failed to read first token: %w", err)
+		}
+		for decoder.More() {
+			var rawEvent map[string]interface{}
+			if err := decoder.Decode(&rawEvent); err != nil {
+				if _, ok := err.(*json.SyntaxError); ok {
+					// The decoder cannot resynchronize after a syntax error
+					fmt.Printf("Warning: stopped reading %s at malformed JSON: %v\n", filePath, err)
+					break
 				}
+				skippedCount++
+				continue
 			}
+			addRecord(rawEvent, len(events)+1)
 		}
 
-		source := filepath.Base(filePath)
+	case first == '{':
+		// JSONL/NDJSON when the first line is a whole object; otherwise one or more pretty-printed objects
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if json.Valid(line) {
+			lineNum := 0
+			for len(line) > 0 {
+				lineNum++
+				if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+					var rawEvent map[string]interface{}
+					if err := newJSONDecoder(bytes.NewReader(trimmed)).Decode(&rawEvent); err != nil || rawEvent == nil {
+						skippedCount++
+					} else {
+						addRecord(rawEvent, lineNum)
+					}
+				}
+				line, err = reader.ReadBytes('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					return nil, fmt.Errorf("error reading file: %w", err)
+				}
+			}
+			break
+		}
 
-		eventType := "Unknown"
-		if val, ok := rawEvent["event_type"].(string); ok {
-			eventType = val
+		decoder := newJSONDecoder(io.MultiReader(bytes.NewReader(line), reader))
+		for {
+			var rawEvent map[string]interface{}
+			if err := decoder.Decode(&rawEvent); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if len(events) == 0 {
+					return nil, fmt.Errorf("failed to decode JSON object: %w", err)
+				}
+				fmt.Printf("Warning: stopped reading %s at malformed JSON: %v\n", filePath, err)
+				break
+			}
+			addRecord(rawEvent, len(events)+1)
 		}
 
-		eventID := 0
-		if val, ok := rawEvent["event_id"].(float64); ok {
-			eventID = int(val)
-		} else if val, ok := rawEvent["event_id"].(int); ok {
-			eventID = val
+	default:
+		return nil, fmt.Errorf("unexpected JSON structure: starts with %q", first)
+	}
+
+	fmt.Printf("Parsed JSON file: %s (found %d events, skipped %d records)\n", filePath, len(events), skippedCount)
+	return events, nil
+}
+
+// processRecord converts a single JSON record into a core.Event
+// Mapped fields are read from their configured path only; other fields use the first well-known key present.
+func (p *JsonParser) processRecord(rawEvent map[string]interface{}, mapping *JSONMappingConfig, filePath, source string, recordNum int, timeFormat string) (*core.Event, string) {
+	field := func(name string) (interface{}, bool) {
+		if mapping != nil {
+			if jsonPath, ok := mapping.Fields[name]; ok {
+				return lookupJSONPath(rawEvent, jsonPath)
+			}
 		}
+		for _, key := range jsonFieldKeys[name] {
+			if value, ok := lookupJSONPath(rawEvent, key); ok {
+				if _, scalar := jsonScalar(value); scalar {
+					return value, true
+				}
+			}
+		}
+		return nil, false
+	}
+	text := func(name string) string {
+		value, _ := field(name)
+		s, _ := jsonScalar(value)
+		return s
+	}
 
-		user := ""
-		if val, ok := rawEvent["user"].(string); ok {
-			user = val
+	layout := ""
+	if mapping != nil {
+		layout = mapping.TimestampLayout
+	}
+	var timestamp time.Time
+	if mapping != nil && mapping.Fields["timestamp"] != "" {
+		value, _ := field("timestamp")
+		timestamp, timeFormat = parseJSONTimestamp(value, layout, timeFormat, false)
+	} else {
+		for _, key := range jsonFieldKeys["timestamp"] {
+			if value, ok := lookupJSONPath(rawEvent, key); ok {
+				if timestamp, timeFormat = parseJSONTimestamp(value, layout, timeFormat, true); !timestamp.IsZero() {
+					break
+				}
+			}
 		}
+	}
 
-		host := ""
-		if val, ok := rawEvent["host"].(string); ok {
-			host = val
+	eventType := "Unknown"
+	if mapping != nil && mapping.EventType != "" {
+		eventType = mapping.EventType
+	}
+	if v := text("event_type"); v != "" {
+		eventType = v
+	}
+
+	eventID := recordNum
+	if v := text("event_id"); v != "" {
+		if id, err := strconv.Atoi(v); err == nil {
+			eventID = id
 		}
+	}
 
-		message := ""
-		if val, ok := rawEvent["message"].(string); ok {
-			message = val
+	eventSource := source
+	if v := text("source"); v != "" {
+		eventSource = v
+	}
+
+	// Records without a message keep their full JSON so no data is lost
+	message := text("message")
+	if message == "" {
+		if raw, err := json.Marshal(rawEvent); err == nil {
+			message = string(raw)
 		}
+	}
 
-		path := filePath
+	event := core.NewEvent(
+		timestamp,
+		eventSource,
+		eventType,
+		eventID,
+		text("user"),
+		text("host"),
+		message,
+		filePath,
+	)
+	return event, timeFormat
+}
 
-		// Create a new event
-		event := core.NewEvent(
-			timestamp,
-			source,
-			eventType,
-			eventID,
-			user,
-			host,
-			message,
-			path,
-		)
+// newJSONDecoder returns a decoder that keeps numbers exact, so nanosecond epochs survive
+func newJSONDecoder(r io.Reader) *json.Decoder {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decoder
+}
 
-		events = append(events, event)
+// firstJSONByte returns the first non-whitespace byte without consuming it
+func firstJSONByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, reader.UnreadByte()
 	}
+}
 
-	if delim, ok := token.(json.Delim); ok && delim == '[' {
-		// It's an array, iterate through elements
-		for decoder.More() {
-			var rawEvent map[string]interface{}
-			if err := decoder.Decode(&rawEvent); err != nil {
-				// Log error but try to continue (don't include file path in log)
-				log.Printf("Warning: skipping malformed JSON event: %v", err)
-				continue
+// lookupJSONPath resolves a key or dot-path such as "actor.alternateId" or "answers.0.rdata"
+// A key containing dots is matched literally before the path is split.
+func lookupJSONPath(rawEvent map[string]interface{}, jsonPath string) (interface{}, bool) {
+	if value, ok := rawEvent[jsonPath]; ok {
+		return value, true
+	}
+	var current interface{} = rawEvent
+	for _, segment := range strings.Split(jsonPath, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
 			}
-			processEvent(rawEvent)
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
 		}
+	}
+	return current, true
+}
 
-		// Consume closing bracket
-		if _, err := decoder.Token(); err != nil {
-			// Just log, not fatal if we got the data
-			log.Printf("Warning: error reading JSON closing bracket: %v", err)
+// jsonScalar formats a string, number or boolean value; objects, arrays and null are not scalars
+func jsonScalar(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// parseJSONTimestamp parses a timestamp string or epoch number
+// Without a layout, epochs in seconds, milliseconds, microseconds or nanoseconds are told apart by magnitude;
+// guessed values must also be plausible dates.
+func parseJSONTimestamp(value interface{}, layout, detected string, guessed bool) (time.Time, string) {
+	s, ok := jsonScalar(value)
+	if !ok || s == "" {
+		return time.Time{}, detected
+	}
+	if layout == "" {
+		if _, isNumber := value.(json.Number); isNumber || isEpochString(s) {
+			t := parseEpoch(s)
+			if guessed && !t.IsZero() && t.Unix() < minEpochSeconds {
+				return time.Time{}, detected
+			}
+			return t, detected
 		}
-	} else if delim, ok := token.(json.Delim); ok && delim == '{' {
-		// It's a single object (or maybe NDJSON/JSONL if we supported that here, but for now assume single object)
-		// We already consumed the opening brace, so we need to decode the rest into a map
-		// But decoder.Decode expects the full value.
-		// Since we consumed '{', we can't easily use Decode(&map) directly on the *rest* without hacks.
-		// A better approach for single object:
-		// Re-create a new decoder with a MultiReader that puts the '{' back, OR just handle it.
+	}
+	return parseLayoutTimestamp(layout, s, detected)
+}
 
-		// Actually, standard library decoder doesn't support "unreading" a token easily for Decode.
-		// Strategy: Create a new decoder from the start of file for the single object case.
-		// This is rare (usually logs are arrays or lines), so seeking back is acceptable.
+// isEpochString reports whether a string holds a decimal epoch such as "1682091045" or "1682091045.123"
+func isEpochString(s string) bool {
+	integer, fraction, _ := strings.Cut(s, ".")
+	return isNumeric(integer) && (fraction == "" || isNumeric(fraction))
+}
 
-		if _, err := file.Seek(0, 0); err != nil {
-			return nil, fmt.Errorf("cannot seek file to parse single object: %w", err)
+// parseEpoch converts an epoch number, choosing its unit by magnitude
+func parseEpoch(s string) time.Time {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		switch {
+		case n <= 0:
+			return time.Time{}
+		case n >= 1e17:
+			return time.Unix(0, n).UTC()
+		case n >= 1e14:
+			return time.UnixMicro(n).UTC()
+		case n >= 1e11:
+			return time.UnixMilli(n).UTC()
+		default:
+			return time.Unix(n, 0).UTC()
 		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return time.Time{}
+	}
+	scale := 1e9 // Fractional seconds
+	switch {
+	case f >= 1e17:
+		scale = 1
+	case f >= 1e14:
+		scale = 1e3
+	case f >= 1e11:
+		scale = 1e6
+	}
+	return time.Unix(0, int64(f*scale)).UTC()
+}
+
+// ============================================================================
+// JSON Field Mappings
+// ============================================================================
+
+// JSONMappingConfig maps JSON paths onto event fields for JSON files matching its globs
+//
+//	mappings:
+//	  - name: okta
+//	    globs: ["okta-*.jsonl"]
+//	    timestamp_layout: "2006-01-02T15:04:05.000Z"
+//	    fields: {timestamp: published, user: actor.alternateId, host: client.ipAddress, message: displayMessage, event_type: eventType}
+type JSONMappingConfig struct {
+	Name            string            `yaml:"name" toml:"name"`
+	Globs           []string          `yaml:"globs" toml:"globs"`
+	Fields          map[string]string `yaml:"fields" toml:"fields"`
+	TimestampLayout string            `yaml:"timestamp_layout" toml:"timestamp_layout"`
+	EventType       string            `yaml:"event_type" toml:"event_type"`
+}
 
-		decoder = json.NewDecoder(file)
-		var rawEvent map[string]interface{}
-		if err := decoder.Decode(&rawEvent); err != nil {
-			return nil, fmt.Errorf("failed to decode single JSON object: %w", err)
+// jsonMappingFile is the top-level structure of a JSON mapping file
+type jsonMappingFile struct {
+	Mappings []JSONMappingConfig `yaml:"mappings" toml:"mappings"`
+}
+
+// jsonMappings is set once at startup (before parsing begins) by LoadJSONMappings
+var jsonMappings []JSONMappingConfig
+
+// LoadJSONMappings loads JSON field mappings from YAML/TOML files, replacing any previous ones
+// The first mapping whose globs match a file applies to it; a mapping without globs applies to every JSON file.
+func LoadJSONMappings(paths []string) error {
+	var loaded []JSONMappingConfig
+	for _, file := range paths {
+		if strings.TrimSpace(file) == "" {
+			continue
+		}
+		var definitions jsonMappingFile
+		if err := decodeConfigFile(file, &definitions); err != nil {
+			return err
+		}
+		if len(definitions.Mappings) == 0 {
+			return fmt.Errorf("%s: no mappings defined", file)
+		}
+		for _, mapping := range definitions.Mappings {
+			if len(mapping.Fields) == 0 {
+				return fmt.Errorf("%s: mapping %q has no fields", file, mapping.Name)
+			}
+			for field, jsonPath := range mapping.Fields {
+				if !customEventFields[field] {
+					return fmt.Errorf("%s: mapping %q: unknown event field %q", file, mapping.Name, field)
+				}
+				if jsonPath == "" {
+					return fmt.Errorf("%s: mapping %q: field %s has no JSON path", file, mapping.Name, field)
+				}
+			}
+			for _, glob := range mapping.Globs {
+				if _, err := path.Match(glob, ""); err != nil {
+					return fmt.Errorf("%s: mapping %q: invalid glob %q: %w", file, mapping.Name, glob, err)
+				}
+			}
+			loaded = append(loaded, mapping)
 		}
-		processEvent(rawEvent)
-	} else {
-		return nil, fmt.Errorf("unexpected JSON structure: starts with %v", token)
 	}
+	jsonMappings = loaded
+	return nil
+}
 
-	log.Printf("Parsed JSON file: %s (found %d events)", filepath.Base(filePath), len(events))
-	return events, nil
+// jsonMappingFor returns the mapping that applies to a file, or nil
+func jsonMappingFor(filePath string) *JSONMappingConfig {
+	for i := range jsonMappings {
+		if len(jsonMappings[i].Globs) == 0 || jsonMappingMatches(&jsonMappings[i], filePath) {
+			return &jsonMappings[i]
+		}
+	}
+	return nil
+}
+
+// claimsJSONFile reports whether a mapping names the file by glob, so it is parsed as JSON whatever its name
+func claimsJSONFile(filePath string) bool {
+	for i := range jsonMappings {
+		if jsonMappingMatches(&jsonMappings[i], filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonMappingMatches reports whether any of a mapping's globs matches the file
+func jsonMappingMatches(mapping *JSONMappingConfig, filePath string) bool {
+	for _, glob := range mapping.Globs {
+		if matchPathGlob(glob, filePath) {
+			return true
+		}
+	}
+	return false
 }
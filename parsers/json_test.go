@@ -0,0 +1,106 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJsonParserFormats(t *testing.T) {
+	dir := t.TempDir()
+	want := time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC)
+	files := map[string]string{
+		"array.json":   `[{"timestamp": "2023-04-21T15:30:45Z", "message": "a"}, {"timestamp": "2023-04-21T15:30:45Z", "message": "b"}]`,
+		"single.json":  "{\n  \"@timestamp\": \"2023-04-21T15:30:45Z\",\n  \"message\": \"a\"\n}\n",
+		"app.jsonl":    "{\"ts\": 1682091045, \"msg\": \"a\"}\nnot json\n\n{\"ts\": 1682091045000, \"msg\": \"b\"}\n",
+		"app.ndjson":   "{\"time\": \"1682091045.000\", \"log\": \"a\"}\n{\"eventTime\": 1682091045000000000, \"log\": \"b\"}\n",
+		"nested.jsonl": `{"event": {"created": "2023-04-21T15:30:45Z", "action": "login"}, "user": {"name": "jdoe"}, "host": {"name": "web01"}, "duration": 3}` + "\n",
+	}
+	counts := map[string]int{"array.json": 2, "single.json": 1, "app.jsonl": 2, "app.ndjson": 2, "nested.jsonl": 1}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		parser, err := GetParserForFile(path)
+		if err != nil {
+			t.Fatalf("%s: failed to get parser: %v", name, err)
+		}
+		if _, ok := parser.(*JsonParser); !ok {
+			t.Fatalf("%s: expected JsonParser, got %T", name, parser)
+		}
+		events, err := parser.Parse(path)
+		if err != nil {
+			t.Fatalf("%s: failed to parse: %v", name, err)
+		}
+		if len(events) != counts[name] {
+			t.Fatalf("%s: expected %d events, got %d", name, counts[name], len(events))
+		}
+		for _, event := range events {
+			if !event.Timestamp.Equal(want) {
+				t.Errorf("%s: expected %s, got %s", name, want, event.Timestamp)
+			}
+		}
+	}
+
+	events, _ := (&JsonParser{}).Parse(filepath.Join(dir, "nested.jsonl"))
+	if e := events[0]; e.EventType != "login" || e.User != "jdoe" || e.Host != "web01" || !strings.Contains(e.Message, `"duration":3`) {
+		t.Errorf("Unexpected nested event: type=%q user=%q host=%q %q", e.EventType, e.User, e.Host, e.Message)
+	}
+}
+
+func TestJsonMappings(t *testing.T) {
+	dir := t.TempDir()
+	mappingPath := filepath.Join(dir, "mappings.yaml")
+	mapping := `mappings:
+  - name: okta
+    globs: ["okta-*.log"]
+    event_type: OktaEvent
+    fields:
+      timestamp: published
+      user: actor.alternateId
+      host: client.ipAddress
+      message: displayMessage
+      event_id: outcome.codes.1
+`
+	if err := os.WriteFile(mappingPath, []byte(mapping), 0644); err != nil {
+		t.Fatalf("Failed to write mapping: %v", err)
+	}
+	if err := LoadJSONMappings([]string{mappingPath}); err != nil {
+		t.Fatalf("Failed to load mappings: %v", err)
+	}
+	t.Cleanup(func() { LoadJSONMappings(nil) })
+
+	logPath := filepath.Join(dir, "okta-2023.log")
+	record := `{"published": "2023-04-21T15:30:45.123Z", "timestamp": "1999-01-01T00:00:00Z", "displayMessage": "User login", ` +
+		`"actor": {"alternateId": "jdoe@corp.example"}, "client": {"ipAddress": "203.0.113.7"}, "outcome": {"codes": [1, 42]}}` + "\n"
+	if err := os.WriteFile(logPath, []byte(record), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	parser, err := GetParserForFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*JsonParser); !ok {
+		t.Fatalf("Expected a mapped file to be parsed as JSON, got %T", parser)
+	}
+	events, err := parser.Parse(logPath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	e := events[0]
+	if !e.Timestamp.Equal(time.Date(2023, 4, 21, 15, 30, 45, 123e6, time.UTC)) || e.EventType != "OktaEvent" ||
+		e.User != "jdoe@corp.example" || e.Host != "203.0.113.7" || e.Message != "User login" || e.EventID != 42 {
+		t.Errorf("Unexpected mapped event at %s: type=%q id=%d user=%q host=%q %q", e.Timestamp, e.EventType, e.EventID, e.User, e.Host, e.Message)
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("mappings:\n  - name: x\n    fields: {colour: red}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write mapping: %v", err)
+	}
+	if err := LoadJSONMappings([]string{bad}); err == nil || !strings.Contains(err.Error(), "unknown event field") {
+		t.Errorf("Expected an unknown field error, got %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -41,7 +40,7 @@ func (p *LinuxSyslogParser) CanParse(filePath string) bool {
 
 // Parse parses a syslog file and returns a slice of events
 func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -56,12 +55,7 @@ func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 120))
 	lineNum := 0
 	source := filepath.Base(filePath)
-	now := time.Now()
-	currentYear := now.Year()
-	currentMonth := now.Month()
-
-	// Track the last timestamp to detect year boundary crossings
-	var lastTimestamp time.Time
+	years := newYearInferrer(filePath)
 
 	for scanner.Scan() {
 		lineNum++
@@ -77,15 +71,11 @@ func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
 
 		// Try RFC 5424 first (ISO timestamp)
 		if matches := rfc5424Pattern.FindStringSubmatch(lineForRegex); matches != nil {
-			timestamp, err := time.Parse(time.RFC3339, matches[1])
-			if err != nil {
-				timestamp = time.Now().UTC()
-			}
+			timestamp, _ := parseLogTime(time.RFC3339, matches[1])
 			host := matches[2]
 			proc := matches[3]
 			msg := matches[4]
 
-			lastTimestamp = timestamp
 			event = core.NewEvent(
 				timestamp,
 				source,
@@ -96,46 +86,11 @@ func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
 				fmt.Sprintf("[%s] %s", proc, msg),
 				filePath,
 			)
+			// Dated lines anchor the year of their year-less neighbours
+			years.add(event)
 		} else if matches := rfc3164Pattern.FindStringSubmatch(lineForRegex); matches != nil {
-			// RFC 3164 (No year)
-			// Parse: Jan 01 12:00:00
-			// Handle year boundary: if we're in Jan and see Dec dates, use previous year
-			// Similarly, if log month is ahead of current month, it's likely from previous year
-			timeStr := fmt.Sprintf("%d %s", currentYear, matches[1])
-			timestamp, err := time.Parse("2006 Jan  2 15:04:05", timeStr)
-			if err != nil {
-				// Try alternate format with single-digit day
-				timestamp, err = time.Parse("2006 Jan 2 15:04:05", timeStr)
-			}
-			if err != nil {
-				timestamp = time.Now().UTC()
-			} else {
-				// Year boundary detection:
-				// 1. If current month is Jan/Feb and log month is Nov/Dec, use previous year
-				// 2. If this timestamp is more than 6 months in the future, use previous year
-				logMonth := timestamp.Month()
-
-				if currentMonth <= time.February && logMonth >= time.November {
-					// We're in early year but log is from late year - must be previous year
-					timestamp = timestamp.AddDate(-1, 0, 0)
-				} else if timestamp.After(now.AddDate(0, 6, 0)) {
-					// Timestamp is more than 6 months in the future - must be previous year
-					timestamp = timestamp.AddDate(-1, 0, 0)
-				}
-
-				// Additional check: if timestamps go backwards significantly (>30 days),
-				// we might have crossed a year boundary incorrectly
-				if !lastTimestamp.IsZero() && timestamp.Before(lastTimestamp.AddDate(0, 0, -30)) {
-					// Large backwards jump - likely year boundary issue
-					// Re-evaluate: if adding a year makes it closer to last timestamp, do that
-					timestampPlusYear := timestamp.AddDate(1, 0, 0)
-					if timestampPlusYear.Sub(lastTimestamp).Abs() < timestamp.Sub(lastTimestamp).Abs() {
-						timestamp = timestampPlusYear
-					}
-				}
-			}
-
-			lastTimestamp = timestamp
+			// RFC 3164 (No year): Jan 01 12:00:00; the year is inferred once the whole file is read
+			timestamp := parseBSDTimestamp(matches[1], yearlessPlaceholder)
 			host := matches[2]
 			proc := matches[3]
 			msg := matches[4]
@@ -150,10 +105,11 @@ func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
 				fmt.Sprintf("[%s] %s", proc, msg),
 				filePath,
 			)
+			years.add(event)
 		} else {
 			// Fallback to simple line
 			event = core.NewEvent(
-				time.Now().UTC(),
+				time.Time{},
 				source,
 				"SyslogRaw",
 				lineNum,
@@ -170,6 +126,7 @@ func (p *LinuxSyslogParser) Parse(filePath string) ([]*core.Event, error) {
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	years.apply()
 
 	fmt.Printf("Parsed Syslog file: %s (found %d events)\n", filePath, len(events))
 	return events, nil
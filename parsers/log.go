@@ -1,9 +1,7 @@
 package parsers
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -43,38 +41,19 @@ func (p *LogParser) CanParse(filePath string) bool {
 }
 
 // Parse parses a log file and returns a slice of events
+// Continuation lines such as stack traces are folded into the preceding record's message
 func (p *LogParser) Parse(filePath string) ([]*core.Event, error) {
-	// Open the file
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	// Create a scanner to read the file line by line
-	scanner := bufio.NewScanner(file)
-	// Increase buffer to 1MB to handle long log lines
-	const maxScannerBuffer = 1024 * 1024
-	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
-
 	// Pre-allocate slice with estimated capacity (avg 100 bytes per log line)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 100))
-	lineNum := 0
 
 	// Extract the source name from the file path
 	source := filepath.Base(filePath)
 
-	// Process each line
+	// Process each record
 	var detectedPatternIndex = -1
 
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-
-		// Skip empty lines
-		if len(strings.TrimSpace(line)) == 0 {
-			continue
-		}
+	err := scanLogRecords(filePath, recordStartFunc(filePath), func(record *logRecord) {
+		line := record.line
 
 		var timestamp time.Time
 		var timeStr string
@@ -107,18 +86,17 @@ func (p *LogParser) Parse(filePath string) ([]*core.Event, error) {
 			timestamp,
 			source,
 			"LogEntry",
-			lineNum, // Use line number as event ID
-			"",      // User is unknown
-			"",      // Host is unknown
-			message,
+			record.lineNum, // Use line number as event ID
+			"",             // User is unknown
+			"",             // Host is unknown
+			record.withContinuation(message),
 			filePath,
 		)
 
 		events = append(events, event)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	fmt.Printf("Parsed log file: %s (found %d events)\n", filePath, len(events))
@@ -131,9 +109,9 @@ func extractTimestampWithDetection(line string) (time.Time, string, int) {
 		matches := pattern.FindStringSubmatch(line)
 		if len(matches) > 1 {
 			timeStr := matches[1]
-			timestamp, err := time.Parse(timestampFormats[i], timeStr)
+			timestamp, err := parseLogTime(timestampFormats[i], timeStr)
 			if err == nil {
-				return timestamp.UTC(), timeStr, i
+				return timestamp, timeStr, i
 			}
 		}
 	}
@@ -151,9 +129,9 @@ func extractTimestampWithPattern(line string, patternIndex int) (time.Time, stri
 	matches := pattern.FindStringSubmatch(line)
 	if len(matches) > 1 {
 		timeStr := matches[1]
-		timestamp, err := time.Parse(timestampFormats[patternIndex], timeStr)
+		timestamp, err := parseLogTime(timestampFormats[patternIndex], timeStr)
 		if err == nil {
-			return timestamp.UTC(), timeStr
+			return timestamp, timeStr
 		}
 	}
 
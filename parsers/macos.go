@@ -2,7 +2,10 @@ package parsers
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -72,7 +75,7 @@ func (p *MacOSUnifiedLogParser) CanParse(filePath string) bool {
 
 // Parse parses a macOS Unified Log file and returns a slice of events
 func (p *MacOSUnifiedLogParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -152,7 +155,7 @@ func (p *MacOSUnifiedLogParser) Parse(filePath string) ([]*core.Event, error) {
 		} else {
 			// Fallback to raw event
 			event = core.NewEvent(
-				time.Now().UTC(),
+				time.Time{},
 				source,
 				"UnifiedLogRaw",
 				lineNum,
@@ -176,6 +179,173 @@ func (p *MacOSUnifiedLogParser) Parse(filePath string) ([]*core.Event, error) {
 	return events, nil
 }
 
+// unifiedLogMessage renders an entry the way `log show` prints it:
+// [process(pid)] (sender) [subsystem:category] message
+// The sender is omitted when it is the process image itself
+func unifiedLogMessage(processPath string, pid uint64, senderPath, subsystem, category, message string) string {
+	process := filepath.Base(processPath)
+	if processPath == "" {
+		process = "unknown"
+	}
+
+	parts := []string{fmt.Sprintf("[%s(%d)]", process, pid)}
+	if senderPath != "" && senderPath != processPath {
+		parts = append(parts, fmt.Sprintf("(%s)", filepath.Base(senderPath)))
+	}
+	if subsystem != "" {
+		parts = append(parts, fmt.Sprintf("[%s:%s]", subsystem, category))
+	}
+	return strings.Join(append(parts, message), " ")
+}
+
+// MacOSUnifiedLogJSONParser implements the Parser interface for macOS Unified Logs
+// exported with `log show --style json` (array) or `--style ndjson` (one object per line)
+type MacOSUnifiedLogJSONParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSUnifiedLogJSONParser) CanParse(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext != ".json" && ext != ".jsonl" && ext != ".ndjson" && ext != ".log" && ext != "" {
+		return false
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	// Decode the first record of either style without reading the whole export
+	decoder := json.NewDecoder(bufio.NewReaderSize(file, 64*1024))
+	var rawEvent map[string]interface{}
+	if token, err := decoder.Token(); err != nil {
+		return false
+	} else if delim, ok := token.(json.Delim); ok && delim == '[' {
+		if err := decoder.Decode(&rawEvent); err != nil {
+			return false
+		}
+	} else if ok && delim == '{' {
+		if _, err := file.Seek(0, 0); err != nil {
+			return false
+		}
+		if err := json.NewDecoder(bufio.NewReaderSize(file, 64*1024)).Decode(&rawEvent); err != nil {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	_, hasMessage := rawEvent["eventMessage"]
+	_, hasMachTime := rawEvent["machTimestamp"]
+	return hasMessage && getStringField(rawEvent, "timestamp") != "" &&
+		(hasMachTime || getStringField(rawEvent, "processImagePath") != "")
+}
+
+// Parse parses a `log show` JSON or NDJSON export and returns a slice of events
+func (p *MacOSUnifiedLogJSONParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Pre-allocate slice with estimated capacity (avg 900 bytes per NDJSON record)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 900))
+	source := filepath.Base(filePath)
+	skippedCount := 0
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read first token: %w", err)
+	}
+	isArray := false
+	if delim, ok := token.(json.Delim); ok && delim == '[' {
+		isArray = true
+	} else {
+		// NDJSON: restart so the first object is decoded whole
+		if _, err := file.Seek(0, 0); err != nil {
+			return nil, fmt.Errorf("cannot seek file: %w", err)
+		}
+		decoder = json.NewDecoder(bufio.NewReader(file))
+	}
+
+	for !isArray || decoder.More() {
+		var rawEvent map[string]interface{}
+		if err := decoder.Decode(&rawEvent); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if _, ok := err.(*json.SyntaxError); ok {
+				// The decoder cannot resynchronize after a syntax error
+				fmt.Printf("Warning: stopped reading %s at malformed JSON: %v\n", filePath, err)
+				break
+			}
+			skippedCount++
+			continue
+		}
+
+		// ndjson exports end with a {"count":N,"finished":1} trailer
+		if _, ok := rawEvent["finished"]; ok {
+			continue
+		}
+		if _, ok := rawEvent["eventMessage"]; !ok {
+			skippedCount++
+			continue
+		}
+
+		events = append(events, p.processRecord(rawEvent, filePath, source, len(events)+1))
+	}
+
+	fmt.Printf("Parsed macOS Unified Log JSON: %s (found %d events, skipped %d records)\n", filePath, len(events), skippedCount)
+	return events, nil
+}
+
+// processRecord converts a single `log show` JSON record into a core.Event
+func (p *MacOSUnifiedLogJSONParser) processRecord(rawEvent map[string]interface{}, filePath, source string, eventID int) *core.Event {
+	message := getStringField(rawEvent, "eventMessage")
+	switch getStringField(rawEvent, "eventType") {
+	case "activityCreateEvent":
+		message = "Activity: " + message
+	case "signpostEvent":
+		message = strings.TrimSpace(fmt.Sprintf("Signpost %s %s: %s",
+			getStringField(rawEvent, "signpostType"), getStringField(rawEvent, "signpostName"), message))
+	case "stateEvent":
+		message = "statedump " + message
+	}
+
+	var pid uint64
+	if val, ok := rawEvent["processID"].(float64); ok {
+		pid = uint64(val)
+	}
+	user := ""
+	if val, ok := rawEvent["userID"].(float64); ok {
+		user = strconv.FormatUint(uint64(val), 10)
+	}
+
+	event := core.NewEvent(
+		parseUnifiedTimestamp(getStringField(rawEvent, "timestamp")),
+		source,
+		"UnifiedLog",
+		eventID,
+		user,
+		"",
+		unifiedLogMessage(
+			getStringField(rawEvent, "processImagePath"),
+			pid,
+			getStringField(rawEvent, "senderImagePath"),
+			getStringField(rawEvent, "subsystem"),
+			getStringField(rawEvent, "category"),
+			message,
+		),
+		filePath,
+	)
+	if level := getStringField(rawEvent, "messageType"); level != "" {
+		event.Tags = append(event.Tags, "level:"+strings.ToLower(level))
+	}
+	return event
+}
+
 // MacOSInstallLogParser implements the Parser interface for macOS install.log files
 type MacOSInstallLogParser struct{}
 
@@ -189,7 +359,7 @@ func (p *MacOSInstallLogParser) CanParse(filePath string) bool {
 
 // Parse parses a macOS install.log file and returns a slice of events
 func (p *MacOSInstallLogParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -264,7 +434,7 @@ func (p *MacOSInstallLogParser) Parse(filePath string) ([]*core.Event, error) {
 		} else {
 			// Fallback to raw event
 			event = core.NewEvent(
-				time.Now().UTC(),
+				time.Time{},
 				source,
 				"InstallLogRaw",
 				lineNum,
@@ -326,7 +496,7 @@ func (p *MacOSASLParser) CanParse(filePath string) bool {
 
 // Parse parses a macOS ASL file and returns a slice of events
 func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -341,14 +511,9 @@ func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
 	lineNum := 0
 	source := filepath.Base(filePath)
-	now := time.Now()
-	currentYear := now.Year()
-	currentMonth := now.Month()
 	parsedCount := 0
 	rawCount := 0
-
-	// Track the last timestamp to detect year boundary crossings
-	var lastTimestamp time.Time
+	years := newYearInferrer(filePath)
 
 	for scanner.Scan() {
 		lineNum++
@@ -364,7 +529,7 @@ func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
 
 		// Try ASL pattern with PID: Apr 21 15:30:45 hostname process[1234] <Notice>: message
 		if matches := aslPattern.FindStringSubmatch(lineForRegex); matches != nil {
-			timestamp := parseASLTimestamp(matches[1], currentYear, currentMonth, now, &lastTimestamp)
+			timestamp := parseASLTimestamp(matches[1])
 			host := matches[2]
 			process := strings.TrimSpace(matches[3])
 			pid, _ := strconv.Atoi(matches[4])
@@ -381,10 +546,11 @@ func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
 				fmt.Sprintf("[%s(%d)] <%s> %s", process, pid, level, message),
 				filePath,
 			)
+			years.add(event)
 			parsedCount++
 		} else if matches := aslNoPIDPattern.FindStringSubmatch(lineForRegex); matches != nil {
 			// Try ASL pattern without PID
-			timestamp := parseASLTimestamp(matches[1], currentYear, currentMonth, now, &lastTimestamp)
+			timestamp := parseASLTimestamp(matches[1])
 			host := matches[2]
 			process := strings.TrimSpace(matches[3])
 			level := matches[4]
@@ -400,11 +566,12 @@ func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
 				fmt.Sprintf("[%s] <%s> %s", process, level, message),
 				filePath,
 			)
+			years.add(event)
 			parsedCount++
 		} else {
 			// Fallback to raw event
 			event = core.NewEvent(
-				time.Now().UTC(),
+				time.Time{},
 				source,
 				"ASLRaw",
 				lineNum,
@@ -422,6 +589,7 @@ func (p *MacOSASLParser) Parse(filePath string) ([]*core.Event, error) {
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	years.apply()
 
 	fmt.Printf("Parsed macOS ASL: %s (parsed: %d, raw: %d, total: %d events)\n",
 		filePath, parsedCount, rawCount, len(events))
@@ -440,13 +608,12 @@ func parseUnifiedTimestamp(timeStr string) time.Time {
 	}
 
 	for _, format := range formats {
-		if timestamp, err := time.Parse(format, timeStr); err == nil {
+		if timestamp, err := parseLogTime(format, timeStr); err == nil {
 			return timestamp
 		}
 	}
 
-	// Fallback to current time
-	return time.Now().UTC()
+	return time.Time{}
 }
 
 // parseInstallLogTimestamp parses timestamps from macOS install.log
@@ -459,57 +626,21 @@ func parseInstallLogTimestamp(timeStr, tzOffset string) time.Time {
 	}
 
 	for _, format := range formats {
-		if timestamp, err := time.Parse(format, fullTimeStr); err == nil {
+		if timestamp, err := parseLogTime(format, fullTimeStr); err == nil {
 			return timestamp
 		}
 	}
 
 	// Try without timezone
-	if timestamp, err := time.Parse("2006-01-02 15:04:05", timeStr); err == nil {
+	if timestamp, err := parseLogTime("2006-01-02 15:04:05", timeStr); err == nil {
 		return timestamp
 	}
 
-	return time.Now().UTC()
+	return time.Time{}
 }
 
 // parseASLTimestamp parses timestamps from ASL format (without year)
-// Handles format: Apr 21 15:30:45
-func parseASLTimestamp(timeStr string, currentYear int, currentMonth time.Month, now time.Time, lastTimestamp *time.Time) time.Time {
-	// Parse: Apr 21 15:30:45
-	fullTimeStr := fmt.Sprintf("%d %s", currentYear, timeStr)
-	timestamp, err := time.Parse("2006 Jan  2 15:04:05", fullTimeStr)
-	if err != nil {
-		// Try alternate format with single-digit day
-		timestamp, err = time.Parse("2006 Jan 2 15:04:05", fullTimeStr)
-	}
-	if err != nil {
-		return time.Now().UTC()
-	}
-
-	// Year boundary detection (same logic as Linux syslog parser):
-	// 1. If current month is Jan/Feb and log month is Nov/Dec, use previous year
-	// 2. If this timestamp is more than 6 months in the future, use previous year
-	logMonth := timestamp.Month()
-
-	if currentMonth <= time.February && logMonth >= time.November {
-		// We're in early year but log is from late year - must be previous year
-		timestamp = timestamp.AddDate(-1, 0, 0)
-	} else if timestamp.After(now.AddDate(0, 6, 0)) {
-		// Timestamp is more than 6 months in the future - must be previous year
-		timestamp = timestamp.AddDate(-1, 0, 0)
-	}
-
-	// Additional check: if timestamps go backwards significantly (>30 days),
-	// we might have crossed a year boundary incorrectly
-	if lastTimestamp != nil && !lastTimestamp.IsZero() && timestamp.Before(lastTimestamp.AddDate(0, 0, -30)) {
-		// Large backwards jump - likely year boundary issue
-		// Re-evaluate: if adding a year makes it closer to last timestamp, do that
-		timestampPlusYear := timestamp.AddDate(1, 0, 0)
-		if timestampPlusYear.Sub(*lastTimestamp).Abs() < timestamp.Sub(*lastTimestamp).Abs() {
-			timestamp = timestampPlusYear
-		}
-	}
-
-	*lastTimestamp = timestamp
-	return timestamp
+// Handles format: Apr 21 15:30:45; the year is filled in by the file's yearInferrer
+func parseASLTimestamp(timeStr string) time.Time {
+	return parseBSDTimestamp(timeStr, yearlessPlaceholder)
 }
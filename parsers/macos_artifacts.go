@@ -0,0 +1,667 @@
+package parsers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled patterns for macOS artifact detection
+var (
+	// FSEvents page files are named by the 16-digit hex event ID of their last record
+	fseventsFileNamePattern = regexp.MustCompile(`^[0-9a-fA-F]{16}$`)
+
+	// Home directory in a macOS path: /Users/<name>/...
+	macUserPathPattern = regexp.MustCompile(`(?:^|[/\\])Users[/\\]([^/\\]+)[/\\]`)
+)
+
+// FSEvents page header magics (DLS1/DLS2/DLS3 stored little-endian)
+var (
+	fseventsMagicV1 = []byte("1SLD")
+	fseventsMagicV2 = []byte("2SLD")
+	fseventsMagicV3 = []byte("3SLD")
+)
+
+// fseventsPageHeaderLen is the size of the magic, unknown field and page length
+const fseventsPageHeaderLen = 12
+
+// fseventsFlags maps on-disk FSEvents record flags to names, in output order
+var fseventsFlags = []struct {
+	bit  uint32
+	name string
+}{
+	{0x01000000, "Created"},
+	{0x02000000, "Removed"},
+	{0x08000000, "Renamed"},
+	{0x10000000, "Modified"},
+	{0x04000000, "InodeMetaMod"},
+	{0x20000000, "Exchange"},
+	{0x40000000, "FinderInfoMod"},
+	{0x80000000, "FolderCreated"},
+	{0x00010000, "PermissionChange"},
+	{0x00020000, "ExtendedAttrModified"},
+	{0x00040000, "ExtendedAttrRemoved"},
+	{0x00100000, "DocumentRevisioning"},
+	{0x00400000, "ItemCloned"},
+	{0x00000001, "FolderEvent"},
+	{0x00008000, "FileEvent"},
+	{0x00004000, "SymbolicLink"},
+	{0x00001000, "HardLink"},
+	{0x00000800, "LastHardLinkRemoved"},
+	{0x00000002, "Mount"},
+	{0x00000004, "Unmount"},
+	{0x00000020, "EndOfTransaction"},
+}
+
+// tccAuthValues maps TCC access.auth_value (Big Sur+) to names
+var tccAuthValues = map[int64]string{
+	0: "denied",
+	1: "unknown",
+	2: "allowed",
+	3: "limited",
+}
+
+// tccAuthReasons maps TCC access.auth_reason to names
+var tccAuthReasons = map[int64]string{
+	1:  "error",
+	2:  "user consent",
+	3:  "user set",
+	4:  "system set",
+	5:  "service policy",
+	6:  "MDM policy",
+	7:  "override policy",
+	8:  "missing usage string",
+	9:  "prompt timeout",
+	10: "preflight unknown",
+	11: "entitled",
+	12: "app type policy",
+}
+
+// tccSensitiveServices are TCC services whose grants are commonly abused by malware
+var tccSensitiveServices = map[string]bool{
+	"SystemPolicyAllFiles": true,
+	"ScreenCapture":        true,
+	"Accessibility":        true,
+	"ListenEvent":          true,
+	"PostEvent":            true,
+	"Camera":               true,
+	"Microphone":           true,
+	"AppleEvents":          true,
+}
+
+// quarantineAgentTypes maps LSQuarantineTypeNumber to names
+var quarantineAgentTypes = map[int64]string{
+	0: "web download",
+	1: "other download",
+	2: "email attachment",
+	3: "instant message attachment",
+	4: "calendar event attachment",
+	5: "other attachment",
+}
+
+// sqliteTables returns the table names of a SQLite database, or nil if it is not a readable SQLite file
+func sqliteTables(filePath string) map[string]bool {
+	if !isSQLiteDatabase(filePath) {
+		return nil
+	}
+	db, err := sql.Open("sqlite3", buildSQLiteConnectionString(filePath, true))
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table'`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err == nil {
+			tables[name] = true
+		}
+	}
+	return tables
+}
+
+// openSQLiteEvidence opens an evidence database read-only, copying it first if locked
+// The returned cleanup function closes the database and removes any temp copy
+func openSQLiteEvidence(filePath string) (*sql.DB, func(), error) {
+	dbPath, tempFile, err := prepareSQLiteDatabase(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", buildSQLiteConnectionString(dbPath, true))
+	if err != nil {
+		if tempFile != "" {
+			os.Remove(tempFile)
+		}
+		return nil, nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	cleanup := func() {
+		db.Close()
+		if tempFile != "" {
+			os.Remove(tempFile)
+		}
+	}
+	return db, cleanup, nil
+}
+
+// macUserFromPath extracts the account name from a /Users/<name>/ path
+func macUserFromPath(filePath string) string {
+	if m := macUserPathPattern.FindStringSubmatch(filePath); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ============================================================================
+// macOS FSEvents Parser
+// ============================================================================
+
+// MacOSFSEventsParser implements the Parser interface for /.fseventsd gzip page files
+// Records carry no timestamp; the page file's modification time is used as an approximation
+type MacOSFSEventsParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSFSEventsParser) CanParse(filePath string) bool {
+	if !fseventsFileNamePattern.MatchString(filepath.Base(filePath)) {
+		return false
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return false
+	}
+	defer gz.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(gz, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, fseventsMagicV1) || bytes.Equal(magic, fseventsMagicV2) || bytes.Equal(magic, fseventsMagicV3)
+}
+
+// Parse parses an FSEvents page file and returns a slice of events
+func (p *MacOSFSEventsParser) Parse(filePath string) ([]*core.Event, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	pageTime := info.ModTime().UTC()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	data, err := io.ReadAll(gz)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to decompress FSEvents file: %w", err)
+	}
+	if err != nil {
+		// Pages recovered from unallocated space are often truncated
+		fmt.Printf("Warning: FSEvents file %s is truncated\n", filePath)
+	}
+
+	// Pre-allocate slice with estimated capacity (avg 80 bytes per decompressed record)
+	events := make([]*core.Event, 0, len(data)/80+1)
+	source := filepath.Base(filePath)
+
+	offset := 0
+	for offset+fseventsPageHeaderLen <= len(data) {
+		magic := data[offset : offset+4]
+		var trailer int // Bytes after the flags: node ID (v2) plus unknown field (v3)
+		switch {
+		case bytes.Equal(magic, fseventsMagicV1):
+			trailer = 0
+		case bytes.Equal(magic, fseventsMagicV2):
+			trailer = 8
+		case bytes.Equal(magic, fseventsMagicV3):
+			trailer = 12
+		default:
+			fmt.Printf("Warning: unknown FSEvents page magic at offset %d in %s\n", offset, filePath)
+			offset = len(data)
+			continue
+		}
+
+		pageLen := int(binary.LittleEndian.Uint32(data[offset+8 : offset+12]))
+		pageEnd := offset + pageLen
+		if pageLen < fseventsPageHeaderLen || pageEnd > len(data) {
+			pageEnd = len(data)
+		}
+
+		pos := offset + fseventsPageHeaderLen
+		for pos < pageEnd {
+			nul := bytes.IndexByte(data[pos:pageEnd], 0)
+			if nul < 0 || pos+nul+1+12+trailer > pageEnd {
+				break
+			}
+			path := string(data[pos : pos+nul])
+			pos += nul + 1
+
+			eventID := binary.LittleEndian.Uint64(data[pos : pos+8])
+			flags := binary.LittleEndian.Uint32(data[pos+8 : pos+12])
+			var nodeID uint64
+			if trailer >= 8 {
+				nodeID = binary.LittleEndian.Uint64(data[pos+12 : pos+20])
+			}
+			pos += 12 + trailer
+
+			msg := fmt.Sprintf("/%s [%s] event_id=%d", strings.TrimPrefix(path, "/"), fseventsFlagString(flags), eventID)
+			if nodeID != 0 {
+				msg += fmt.Sprintf(" node_id=%d", nodeID)
+			}
+
+			event := core.NewEvent(
+				pageTime,
+				source,
+				"MacFSEvent",
+				int(eventID),
+				"",
+				"",
+				msg,
+				filePath,
+			)
+			markFileMtime(event)
+			events = append(events, event)
+		}
+
+		offset = pageEnd
+	}
+
+	fmt.Printf("Parsed macOS FSEvents file: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// fseventsFlagString formats record flags as a semicolon-separated list
+func fseventsFlagString(flags uint32) string {
+	if flags == 0 {
+		return "None"
+	}
+	var names []string
+	for _, f := range fseventsFlags {
+		if flags&f.bit != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return strings.Join(names, ";")
+}
+
+// ============================================================================
+// macOS KnowledgeC Parser
+// ============================================================================
+
+// MacOSKnowledgeCParser implements the Parser interface for knowledgeC.db (CoreDuet usage database)
+type MacOSKnowledgeCParser struct{}
+
+// knowledgeCMetadataColumns are optional ZSTRUCTUREDMETADATA columns included in messages when present
+var knowledgeCMetadataColumns = []struct {
+	column string
+	label  string
+}{
+	{"Z_DKSAFARIHISTORYMETADATAKEY__TITLE", "title"},
+	{"Z_DKAPPLICATIONACTIVITYMETADATAKEY__TITLE", "title"},
+	{"Z_DKAPPLICATIONACTIVITYMETADATAKEY__ACTIVITYTYPE", "activity"},
+	{"Z_DKINTENTMETADATAKEY__INTENTCLASS", "intent"},
+	{"Z_DKINTENTMETADATAKEY__INTENTVERB", "verb"},
+	{"Z_DKDIGITALHEALTHMETADATAKEY__WEBDOMAIN", "domain"},
+	{"Z_DKDIGITALHEALTHMETADATAKEY__WEBPAGEURL", "url"},
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSKnowledgeCParser) CanParse(filePath string) bool {
+	tables := sqliteTables(filePath)
+	return tables["ZOBJECT"] && tables["ZSTRUCTUREDMETADATA"]
+}
+
+// Parse parses a knowledgeC.db database and returns a slice of events
+func (p *MacOSKnowledgeCParser) Parse(filePath string) ([]*core.Event, error) {
+	db, cleanup, err := openSQLiteEvidence(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	objectColumns := tableColumns(db, "ZOBJECT")
+	metadataColumns := tableColumns(db, "ZSTRUCTUREDMETADATA")
+
+	valueInteger := "0"
+	if objectColumns["ZVALUEINTEGER"] {
+		valueInteger = "COALESCE(o.ZVALUEINTEGER, 0)"
+	}
+	selects := []string{
+		"o.ZSTREAMNAME",
+		"COALESCE(o.ZVALUESTRING, '')",
+		valueInteger,
+		"COALESCE(o.ZSTARTDATE, 0)",
+		"COALESCE(o.ZENDDATE, 0)",
+	}
+	var metaLabels []string
+	for _, meta := range knowledgeCMetadataColumns {
+		if metadataColumns[meta.column] {
+			selects = append(selects, fmt.Sprintf("COALESCE(CAST(m.%s AS TEXT), '')", meta.column))
+			metaLabels = append(metaLabels, meta.label)
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM ZOBJECT o
+		LEFT JOIN ZSTRUCTUREDMETADATA m ON o.ZSTRUCTUREDMETADATA = m.Z_PK
+		ORDER BY o.ZSTARTDATE
+	`, strings.Join(selects, ", "))
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query knowledgeC: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	user := macUserFromPath(filePath)
+
+	for rows.Next() {
+		var stream sql.NullString
+		var valueString string
+		var valueInt int64
+		var startDate, endDate float64
+		metaValues := make([]string, len(metaLabels))
+
+		dest := []interface{}{&stream, &valueString, &valueInt, &startDate, &endDate}
+		for i := range metaValues {
+			dest = append(dest, &metaValues[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			fmt.Printf("Warning: failed to scan knowledgeC row: %v\n", err)
+			continue
+		}
+
+		start := macAbsoluteToTime(startDate)
+		end := macAbsoluteToTime(endDate)
+
+		var msg string
+		switch stream.String {
+		case "/app/usage", "/app/inFocus":
+			msg = fmt.Sprintf("App in focus: %s", valueString)
+		case "/app/webUsage":
+			msg = fmt.Sprintf("Web usage in %s", valueString)
+		case "/device/isLocked":
+			msg = "Screen unlocked"
+			if valueInt == 1 {
+				msg = "Screen locked"
+			}
+		case "/display/isBacklit":
+			msg = "Display off"
+			if valueInt == 1 {
+				msg = "Display on"
+			}
+		case "/device/isPluggedIn":
+			msg = "Power unplugged"
+			if valueInt == 1 {
+				msg = "Power plugged in"
+			}
+		case "/safari/history":
+			msg = fmt.Sprintf("Safari visit: %s", valueString)
+		case "/app/intents":
+			msg = fmt.Sprintf("App intent: %s", valueString)
+		default:
+			msg = strings.TrimSpace(fmt.Sprintf("%s %s", stream.String, valueString))
+		}
+		if msg == "" {
+			msg = stream.String
+		}
+
+		for i, label := range metaLabels {
+			if metaValues[i] != "" {
+				msg += fmt.Sprintf(" %s=%s", label, metaValues[i])
+			}
+		}
+		if endDate > startDate {
+			msg += fmt.Sprintf(" (duration %s)", end.Sub(start).Round(time.Second))
+		}
+		msg += fmt.Sprintf(" [%s]", stream.String)
+
+		events = append(events, core.NewEvent(
+			start,
+			source,
+			"MacKnowledgeC",
+			0,
+			user,
+			"",
+			msg,
+			filePath,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating knowledgeC rows: %w", err)
+	}
+
+	fmt.Printf("Parsed macOS knowledgeC database: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// macOS Quarantine Events Parser
+// ============================================================================
+
+// MacOSQuarantineParser implements the Parser interface for com.apple.LaunchServices.QuarantineEventsV2
+type MacOSQuarantineParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSQuarantineParser) CanParse(filePath string) bool {
+	return sqliteTables(filePath)["LSQuarantineEvent"]
+}
+
+// Parse parses a QuarantineEventsV2 database and returns a slice of events
+func (p *MacOSQuarantineParser) Parse(filePath string) ([]*core.Event, error) {
+	db, cleanup, err := openSQLiteEvidence(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	columns := tableColumns(db, "LSQuarantineEvent")
+	query := fmt.Sprintf(`
+		SELECT LSQuarantineTimeStamp, %s, %s, %s, %s, %s, %s, %s
+		FROM LSQuarantineEvent
+		ORDER BY LSQuarantineTimeStamp
+	`,
+		columnOr(columns, "LSQuarantineAgentName", "''"),
+		columnOr(columns, "LSQuarantineAgentBundleIdentifier", "''"),
+		columnOr(columns, "LSQuarantineDataURLString", "''"),
+		columnOr(columns, "LSQuarantineOriginURLString", "''"),
+		columnOr(columns, "LSQuarantineSenderName", "''"),
+		columnOr(columns, "LSQuarantineSenderAddress", "''"),
+		columnOr(columns, "LSQuarantineTypeNumber", "-1"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantine events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	user := macUserFromPath(filePath)
+
+	for rows.Next() {
+		var timestamp sql.NullFloat64
+		var agentName, agentBundle, dataURL, originURL, senderName, senderAddress sql.NullString
+		var typeNumber sql.NullInt64
+
+		if err := rows.Scan(&timestamp, &agentName, &agentBundle, &dataURL, &originURL, &senderName, &senderAddress, &typeNumber); err != nil {
+			fmt.Printf("Warning: failed to scan quarantine row: %v\n", err)
+			continue
+		}
+
+		agent := agentName.String
+		if agent == "" {
+			agent = agentBundle.String
+		}
+		if agent == "" {
+			agent = "unknown agent"
+		}
+
+		msgParts := []string{fmt.Sprintf("Quarantined download via %s: %s", agent, dataURL.String)}
+		if originURL.String != "" {
+			msgParts = append(msgParts, fmt.Sprintf("origin=%s", originURL.String))
+		}
+		if senderName.String != "" || senderAddress.String != "" {
+			msgParts = append(msgParts, fmt.Sprintf("sender=%s", strings.TrimSpace(senderName.String+" "+senderAddress.String)))
+		}
+		if name, ok := quarantineAgentTypes[typeNumber.Int64]; ok {
+			msgParts = append(msgParts, fmt.Sprintf("(%s)", name))
+		}
+
+		events = append(events, core.NewEvent(
+			macAbsoluteToTime(timestamp.Float64),
+			source,
+			"MacQuarantineEvent",
+			0,
+			user,
+			"",
+			strings.Join(msgParts, " "),
+			filePath,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quarantine rows: %w", err)
+	}
+
+	fmt.Printf("Parsed macOS quarantine events: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// macOS TCC Parser
+// ============================================================================
+
+// MacOSTCCParser implements the Parser interface for TCC.db privacy permission databases
+type MacOSTCCParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSTCCParser) CanParse(filePath string) bool {
+	tables := sqliteTables(filePath)
+	return tables["access"] && (tables["admin"] || tables["policies"] || tables["access_overrides"])
+}
+
+// Parse parses a TCC.db database and returns a slice of events
+func (p *MacOSTCCParser) Parse(filePath string) ([]*core.Event, error) {
+	db, cleanup, err := openSQLiteEvidence(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// Big Sur replaced the allowed column with auth_value/auth_reason
+	columns := tableColumns(db, "access")
+	authValue := "-1"
+	if columns["auth_value"] {
+		authValue = "auth_value"
+	} else if columns["allowed"] {
+		authValue = "CASE WHEN allowed = 1 THEN 2 ELSE 0 END"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT service, client, %s, %s, %s, %s
+		FROM access
+		ORDER BY last_modified
+	`,
+		columnOr(columns, "client_type", "-1"),
+		authValue,
+		columnOr(columns, "auth_reason", "0"),
+		columnOr(columns, "last_modified", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TCC access: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	user := macUserFromPath(filePath)
+
+	for rows.Next() {
+		var service, client sql.NullString
+		var clientType, auth, reason, lastModified sql.NullInt64
+
+		if err := rows.Scan(&service, &client, &clientType, &auth, &reason, &lastModified); err != nil {
+			fmt.Printf("Warning: failed to scan TCC row: %v\n", err)
+			continue
+		}
+
+		serviceName := strings.TrimPrefix(service.String, "kTCCService")
+		authName, ok := tccAuthValues[auth.Int64]
+		if !ok {
+			authName = fmt.Sprintf("auth_value=%d", auth.Int64)
+		}
+
+		clientKind := "bundle"
+		if clientType.Int64 == 1 {
+			clientKind = "path"
+		}
+
+		msg := fmt.Sprintf("%s %s for %s (%s)", serviceName, authName, client.String, clientKind)
+		if reasonName, ok := tccAuthReasons[reason.Int64]; ok {
+			msg += fmt.Sprintf(" reason=%s", reasonName)
+		}
+
+		// last_modified is Unix epoch seconds, unlike the Mac Absolute Time used elsewhere
+		event := core.NewEvent(
+			unixSecondsToTime(lastModified.Int64),
+			source,
+			"MacTCCPermission",
+			0,
+			user,
+			"",
+			msg,
+			filePath,
+		)
+		if auth.Int64 == 2 && tccSensitiveServices[serviceName] {
+			event.Score = 0.5
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating TCC rows: %w", err)
+	}
+
+	fmt.Printf("Parsed macOS TCC database: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
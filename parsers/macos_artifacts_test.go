@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestMacOSFSEventsParser(t *testing.T) {
+	// One v2 page with two records: path\0, event ID, flags, node ID
+	var records bytes.Buffer
+	for _, r := range []struct {
+		path  string
+		id    uint64
+		flags uint32
+	}{
+		{"Users/jdoe/Downloads/payload.dmg", 1001, 0x01000000 | 0x00008000},
+		{"private/tmp/x", 1002, 0x02000000 | 0x00008000},
+	} {
+		records.WriteString(r.path)
+		records.WriteByte(0)
+		binary.Write(&records, binary.LittleEndian, r.id)
+		binary.Write(&records, binary.LittleEndian, r.flags)
+		binary.Write(&records, binary.LittleEndian, uint64(42))
+	}
+	page := append([]byte("2SLD"), make([]byte, 8)...)
+	binary.LittleEndian.PutUint32(page[8:12], uint32(fseventsPageHeaderLen+records.Len()))
+	page = append(page, records.Bytes()...)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(page)
+	gz.Close()
+
+	filePath := filepath.Join(t.TempDir(), ".fseventsd", "00000000000003ea")
+	os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err := os.WriteFile(filePath, compressed.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write FSEvents page: %v", err)
+	}
+	mtime := time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC)
+	os.Chtimes(filePath, mtime, mtime)
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*MacOSFSEventsParser); !ok {
+		t.Fatalf("Expected MacOSFSEventsParser, got %T", parser)
+	}
+
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if want := "/Users/jdoe/Downloads/payload.dmg [Created;FileEvent] event_id=1001 node_id=42"; events[0].Message != want {
+		t.Errorf("Expected message %q, got %q", want, events[0].Message)
+	}
+	if !events[0].Timestamp.Equal(mtime) {
+		t.Errorf("Expected page mtime %s, got %s", mtime, events[0].Timestamp)
+	}
+	if events[0].TimestampQuality != core.TimestampFileMtime || len(events[0].Tags) != 0 {
+		t.Errorf("Expected file-mtime quality and no tags, got %s %v", events[0].TimestampQuality, events[0].Tags)
+	}
+}
+
+func TestMacOSSQLiteArtifacts(t *testing.T) {
+	home := filepath.Join(t.TempDir(), "Users", "jdoe", "Library")
+
+	quarantinePath := filepath.Join(home, "Preferences", "com.apple.LaunchServices.QuarantineEventsV2")
+	createTestDatabase(t, quarantinePath,
+		`CREATE TABLE LSQuarantineEvent (LSQuarantineEventIdentifier TEXT PRIMARY KEY, LSQuarantineTimeStamp REAL,
+			LSQuarantineAgentBundleIdentifier TEXT, LSQuarantineAgentName TEXT, LSQuarantineDataURLString TEXT,
+			LSQuarantineSenderName TEXT, LSQuarantineSenderAddress TEXT, LSQuarantineTypeNumber INTEGER,
+			LSQuarantineOriginTitle TEXT, LSQuarantineOriginURLString TEXT, LSQuarantineOriginAlias BLOB)`,
+		`INSERT INTO LSQuarantineEvent VALUES ('A1', 703783845.5, 'com.apple.Safari', 'Safari',
+			'https://cdn.example.com/payload.dmg', NULL, NULL, 0, NULL, 'https://example.com/', NULL)`,
+	)
+
+	tccPath := filepath.Join(home, "Application Support", "com.apple.TCC", "TCC.db")
+	createTestDatabase(t, tccPath,
+		`CREATE TABLE admin (key TEXT PRIMARY KEY, value INTEGER)`,
+		`CREATE TABLE access (service TEXT, client TEXT, client_type INTEGER, auth_value INTEGER, auth_reason INTEGER,
+			auth_version INTEGER, csreq BLOB, policy_id INTEGER, indirect_object_identifier_type INTEGER,
+			indirect_object_identifier TEXT, indirect_object_code_identity BLOB, flags INTEGER, last_modified INTEGER)`,
+		`INSERT INTO access VALUES ('kTCCServiceScreenCapture', 'com.example.agent', 0, 2, 3, 1, NULL, NULL, 0, 'UNUSED', NULL, 0, 1682091045)`,
+	)
+
+	tests := []struct {
+		path     string
+		wantType string
+		wantTime time.Time
+		wantMsg  string
+	}{
+		{quarantinePath, "MacQuarantineEvent", time.Unix(1682091045, 500000000).UTC(),
+			"Quarantined download via Safari: https://cdn.example.com/payload.dmg origin=https://example.com/ (web download)"},
+		{tccPath, "MacTCCPermission", time.Unix(1682091045, 0).UTC(),
+			"ScreenCapture allowed for com.example.agent (bundle) reason=user set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			parser, err := GetParserForFile(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to get parser: %v", err)
+			}
+			events, err := parser.Parse(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("Expected 1 event, got %d", len(events))
+			}
+			event := events[0]
+			if event.EventType != tt.wantType || event.User != "jdoe" {
+				t.Errorf("Expected %s for jdoe, got %s for %q", tt.wantType, event.EventType, event.User)
+			}
+			if !event.Timestamp.Equal(tt.wantTime) {
+				t.Errorf("Expected timestamp %s, got %s", tt.wantTime, event.Timestamp)
+			}
+			if !strings.Contains(event.Message, tt.wantMsg) {
+				t.Errorf("Expected message to contain %q, got %q", tt.wantMsg, event.Message)
+			}
+		})
+	}
+}
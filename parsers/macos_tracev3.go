@@ -0,0 +1,1386 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"LogZero/core"
+)
+
+// tracev3 chunk tags
+const (
+	tracev3HeaderTag     = 0x1000
+	tracev3HeaderSubtag  = 0x11
+	tracev3CatalogTag    = 0x600b
+	tracev3ChunksetTag   = 0x600d
+	tracev3FirehoseTag   = 0x6001
+	tracev3OversizeTag   = 0x6002
+	tracev3StatedumpTag  = 0x6003
+	tracev3SimpledumpTag = 0x6004
+)
+
+// tracev3 header sub-chunk tags
+const tracev3GenerationSubtag = 0x6102
+
+// Signatures of the Unified Log support files
+const (
+	uuidtextSignature       = 0x66778899
+	dscSignature            = 0x64736368 // "hcsd"
+	timesyncBootSignature   = 0xbbb0
+	timesyncRecordSignature = 0x00207354 // "Ts \0"
+)
+
+// timesync record sizes
+const (
+	timesyncBootLen   = 48
+	timesyncRecordLen = 32
+)
+
+// Firehose entry activity types
+const (
+	firehoseActivity    = 0x2
+	firehoseTrace       = 0x3
+	firehoseNonActivity = 0x4
+	firehoseSignpost    = 0x6
+	firehoseLoss        = 0x7
+)
+
+// Firehose entry flags
+const (
+	firehoseHasCurrentAID    = 0x0001
+	firehoseMainExe          = 0x0002
+	firehoseSharedCache      = 0x0004
+	firehoseAbsolute         = 0x0008
+	firehoseUUIDRelative     = 0x000a
+	firehoseLargeSharedCache = 0x000c
+	firehoseFormatterMask    = 0x000e
+	firehoseUniquePID        = 0x0010
+	firehoseLargeOffset      = 0x0020
+	firehosePrivateRange     = 0x0100
+	firehoseHasSubsystem     = 0x0200 // has_other_current_aid on activity entries
+	firehoseHasTTL           = 0x0400
+	firehoseHasDataRef       = 0x0800
+	firehoseHasBacktrace     = 0x1000
+)
+
+// firehoseDynamicFormat marks a format location whose format string is "%s"
+const firehoseDynamicFormat = 0x80000000
+
+// firehoseEntryHeaderLen is the fixed size of a firehose entry before its data
+const firehoseEntryHeaderLen = 24
+
+// firehosePrivateVirtualEnd is the virtual end offset of a firehose chunk's private data
+const firehosePrivateVirtualEnd = 0x1000
+
+// firehoseLogTypes maps firehose log types to the level names used by `log show`
+var firehoseLogTypes = map[uint8]string{
+	0x00: "Default",
+	0x01: "Info",
+	0x02: "Debug",
+	0x10: "Error",
+	0x11: "Fault",
+}
+
+// unifiedLogStoreDirs are the tracev3 directories inside a logarchive or diagnostics folder
+var unifiedLogStoreDirs = map[string]bool{
+	"Persist":    true,
+	"Special":    true,
+	"Signpost":   true,
+	"HighVolume": true,
+}
+
+// unifiedLogFormatPattern matches printf-style specifiers with Apple's {annotation} extension
+var unifiedLogFormatPattern = regexp.MustCompile(`%(\{[^}]*\})?([-+ #0']*)(\d+|\*)?(?:\.(\d+|\*))?(hh|h|ll|l|q|j|z|t|L)?([diouxXeEfFgGaAcCsSpP@%])`)
+
+// tracev3Reader is a bounds-checked little-endian reader over a byte slice
+// Reads past the end return zero values and set err
+type tracev3Reader struct {
+	data []byte
+	pos  int
+	err  bool
+}
+
+func (r *tracev3Reader) take(n int) []byte {
+	if r.err || n < 0 || n > len(r.data)-r.pos {
+		r.err = true
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *tracev3Reader) u8() uint8 {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *tracev3Reader) u16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *tracev3Reader) u32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *tracev3Reader) u64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// uuid reads a 16-byte UUID as the uppercase hex string used for uuidtext/dsc file names
+func (r *tracev3Reader) uuid() string {
+	if b := r.take(16); b != nil {
+		return strings.ToUpper(hex.EncodeToString(b))
+	}
+	return ""
+}
+
+func (r *tracev3Reader) remaining() []byte {
+	if r.err {
+		return nil
+	}
+	return r.data[r.pos:]
+}
+
+// padding8 returns the number of padding bytes that align size to 8
+func padding8(size int) int {
+	return (8 - size%8) % 8
+}
+
+// nulString reads a NUL-terminated string starting at offset
+func nulString(data []byte, offset int) string {
+	if offset < 0 || offset >= len(data) {
+		return ""
+	}
+	end := offset
+	for end < len(data) && data[end] != 0 {
+		end++
+	}
+	return string(data[offset:end])
+}
+
+// ============================================================================
+// Unified Log support files: timesync, uuidtext and dsc
+// ============================================================================
+
+// timesyncBoot maps mach continuous time to wall-clock time for one boot session
+type timesyncBoot struct {
+	numer, denom uint32
+	bootTime     int64 // Nanoseconds since the Unix epoch
+	records      []timesyncRecord
+}
+
+// timesyncRecord is a (continuous time, wall-clock time) sample within a boot
+type timesyncRecord struct {
+	kernelTime uint64
+	wallTime   int64
+}
+
+// wallClock converts a continuous time to UTC using the closest preceding sync record
+func (b *timesyncBoot) wallClock(continuousTime uint64) time.Time {
+	kernel, wall := uint64(0), b.bootTime
+	for _, rec := range b.records {
+		if rec.kernelTime > continuousTime {
+			break
+		}
+		kernel, wall = rec.kernelTime, rec.wallTime
+	}
+	numer, denom := uint64(b.numer), uint64(b.denom)
+	if numer == 0 || denom == 0 {
+		numer, denom = 1, 1
+	}
+	delta := int64((continuousTime - kernel) * numer / denom)
+	return time.Unix(0, wall+delta).UTC()
+}
+
+// parseTimesync reads the boot and sync records of a .timesync file into boots
+func parseTimesync(data []byte, boots map[string]*timesyncBoot) {
+	var current *timesyncBoot
+	for pos := 0; pos+4 <= len(data); {
+		switch {
+		case binary.LittleEndian.Uint16(data[pos:]) == timesyncBootSignature:
+			if pos+timesyncBootLen > len(data) {
+				return
+			}
+			r := &tracev3Reader{data: data[pos : pos+timesyncBootLen]}
+			r.u16() // Signature
+			r.u16() // Header size
+			r.u32() // Unknown
+			bootUUID := r.uuid()
+			numer, denom := r.u32(), r.u32()
+			bootTime := int64(r.u64())
+
+			current = boots[bootUUID]
+			if current == nil {
+				current = &timesyncBoot{numer: numer, denom: denom, bootTime: bootTime}
+				boots[bootUUID] = current
+			}
+			pos += timesyncBootLen
+		case binary.LittleEndian.Uint32(data[pos:]) == timesyncRecordSignature:
+			if pos+timesyncRecordLen > len(data) {
+				return
+			}
+			if current != nil {
+				current.records = append(current.records, timesyncRecord{
+					kernelTime: binary.LittleEndian.Uint64(data[pos+8:]),
+					wallTime:   int64(binary.LittleEndian.Uint64(data[pos+16:])),
+				})
+			}
+			pos += timesyncRecordLen
+		default:
+			return
+		}
+	}
+}
+
+// uuidtextFile holds the format strings of one binary image
+type uuidtextFile struct {
+	entries     []uuidtextEntry
+	data        []byte
+	libraryPath string
+}
+
+// uuidtextEntry maps a range of image offsets to the string data
+type uuidtextEntry struct {
+	rangeStart uint32
+	size       uint32
+	dataOffset uint32
+}
+
+// parseUUIDText parses a uuidtext file
+func parseUUIDText(data []byte) (*uuidtextFile, error) {
+	r := &tracev3Reader{data: data}
+	if r.u32() != uuidtextSignature {
+		return nil, errors.New("invalid uuidtext signature")
+	}
+	r.u32() // Major version
+	r.u32() // Minor version
+	count := r.u32()
+
+	u := &uuidtextFile{}
+	var total uint32
+	for i := uint32(0); i < count && !r.err; i++ {
+		start, size := r.u32(), r.u32()
+		u.entries = append(u.entries, uuidtextEntry{rangeStart: start, size: size, dataOffset: total})
+		total += size
+	}
+	if r.err {
+		return nil, errors.New("truncated uuidtext entries")
+	}
+	u.data = r.remaining()
+	u.libraryPath = nulString(u.data, int(total))
+	return u, nil
+}
+
+// formatString returns the format string at an image offset
+func (u *uuidtextFile) formatString(offset uint64) (string, bool) {
+	for _, e := range u.entries {
+		if offset >= uint64(e.rangeStart) && offset < uint64(e.rangeStart)+uint64(e.size) {
+			return nulString(u.data, int(uint64(e.dataOffset)+offset-uint64(e.rangeStart))), true
+		}
+	}
+	return "", false
+}
+
+// dscFile holds the format strings of the dyld shared cache
+type dscFile struct {
+	ranges []dscRange
+	paths  []string
+	data   []byte
+}
+
+// dscRange maps a range of shared cache offsets to string data and an image
+type dscRange struct {
+	rangeOffset uint64
+	dataOffset  uint32
+	size        uint32
+	uuidIndex   uint64
+}
+
+// parseDSC parses a shared cache strings (dsc) file, versions 1 and 2
+func parseDSC(data []byte) (*dscFile, error) {
+	r := &tracev3Reader{data: data}
+	if r.u32() != dscSignature {
+		return nil, errors.New("invalid dsc signature")
+	}
+	major := r.u16()
+	r.u16() // Minor version
+	rangeCount, uuidCount := r.u32(), r.u32()
+
+	d := &dscFile{data: data}
+	for i := uint32(0); i < rangeCount && !r.err; i++ {
+		var rng dscRange
+		if major >= 2 {
+			rng.rangeOffset = r.u64()
+			rng.dataOffset = r.u32()
+			rng.size = r.u32()
+			rng.uuidIndex = r.u64()
+		} else {
+			rng.uuidIndex = uint64(r.u32())
+			rng.rangeOffset = uint64(r.u32())
+			rng.dataOffset = r.u32()
+			rng.size = r.u32()
+		}
+		d.ranges = append(d.ranges, rng)
+	}
+	for i := uint32(0); i < uuidCount && !r.err; i++ {
+		if major >= 2 {
+			r.u64() // Text offset
+		} else {
+			r.u32()
+		}
+		r.u32() // Text size
+		r.uuid()
+		d.paths = append(d.paths, nulString(data, int(r.u32())))
+	}
+	if r.err {
+		return nil, errors.New("truncated dsc file")
+	}
+	return d, nil
+}
+
+// formatString returns the format string at a shared cache offset and the image that owns it
+func (d *dscFile) formatString(offset uint64) (string, string, bool) {
+	for _, rng := range d.ranges {
+		if offset >= rng.rangeOffset && offset < rng.rangeOffset+uint64(rng.size) {
+			var library string
+			if rng.uuidIndex < uint64(len(d.paths)) {
+				library = d.paths[rng.uuidIndex]
+			}
+			return nulString(d.data, int(uint64(rng.dataOffset)+offset-rng.rangeOffset)), library, true
+		}
+	}
+	return "", "", false
+}
+
+// unifiedLogArchive resolves timesync, uuidtext and dsc data for tracev3 files
+// of one logarchive bundle or diagnostics folder
+type unifiedLogArchive struct {
+	mu           sync.Mutex
+	uuidtextRoot string
+	boots        map[string]*timesyncBoot
+	uuidtexts    map[string]*uuidtextFile
+	dscs         map[string]*dscFile
+}
+
+// unifiedLogArchives caches archives by root so support files are loaded once per collection
+var unifiedLogArchives = struct {
+	mu     sync.Mutex
+	byRoot map[string]*unifiedLogArchive
+}{byRoot: make(map[string]*unifiedLogArchive)}
+
+// openUnifiedLogArchive returns the archive containing a tracev3 file
+// Supports .logarchive bundles (uuidtext and dsc inside the bundle) and
+// /private/var/db/diagnostics with its sibling /private/var/db/uuidtext
+func openUnifiedLogArchive(tracePath string) *unifiedLogArchive {
+	root := filepath.Dir(tracePath)
+	if unifiedLogStoreDirs[filepath.Base(root)] {
+		root = filepath.Dir(root)
+	}
+
+	unifiedLogArchives.mu.Lock()
+	defer unifiedLogArchives.mu.Unlock()
+	if archive, ok := unifiedLogArchives.byRoot[root]; ok {
+		return archive
+	}
+
+	archive := &unifiedLogArchive{
+		uuidtextRoot: root,
+		boots:        make(map[string]*timesyncBoot),
+		uuidtexts:    make(map[string]*uuidtextFile),
+		dscs:         make(map[string]*dscFile),
+	}
+	if info, err := os.Stat(filepath.Join(root, "dsc")); err != nil || !info.IsDir() {
+		sibling := filepath.Join(filepath.Dir(root), "uuidtext")
+		if info, err := os.Stat(sibling); err == nil && info.IsDir() {
+			archive.uuidtextRoot = sibling
+		}
+	}
+
+	timesyncFiles, _ := filepath.Glob(filepath.Join(root, "timesync", "*.timesync"))
+	sort.Strings(timesyncFiles)
+	for _, path := range timesyncFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("Warning: failed to read timesync file %s: %v\n", path, err)
+			continue
+		}
+		parseTimesync(data, archive.boots)
+	}
+	for _, boot := range archive.boots {
+		sort.Slice(boot.records, func(i, j int) bool { return boot.records[i].kernelTime < boot.records[j].kernelTime })
+	}
+
+	unifiedLogArchives.byRoot[root] = archive
+	return archive
+}
+
+// uuidtext returns the uuidtext file for an image UUID, or nil if it is missing
+func (a *unifiedLogArchive) uuidtext(uuid string) *uuidtextFile {
+	if len(uuid) != 32 {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if u, ok := a.uuidtexts[uuid]; ok {
+		return u
+	}
+	var u *uuidtextFile
+	if data, err := os.ReadFile(filepath.Join(a.uuidtextRoot, uuid[:2], uuid[2:])); err == nil {
+		u, _ = parseUUIDText(data)
+	}
+	a.uuidtexts[uuid] = u
+	return u
+}
+
+// dsc returns the shared cache strings file for a UUID, or nil if it is missing
+func (a *unifiedLogArchive) dsc(uuid string) *dscFile {
+	if uuid == "" {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if d, ok := a.dscs[uuid]; ok {
+		return d
+	}
+	var d *dscFile
+	if data, err := os.ReadFile(filepath.Join(a.uuidtextRoot, "dsc", uuid)); err == nil {
+		d, _ = parseDSC(data)
+	}
+	a.dscs[uuid] = d
+	return d
+}
+
+// imagePath returns the binary path recorded in an image's uuidtext file
+func (a *unifiedLogArchive) imagePath(uuid string) string {
+	if u := a.uuidtext(uuid); u != nil {
+		return u.libraryPath
+	}
+	return ""
+}
+
+// ============================================================================
+// tracev3 chunks and catalog
+// ============================================================================
+
+// tracev3Chunk is one tagged chunk of a tracev3 file or decompressed chunkset
+type tracev3Chunk struct {
+	tag    uint32
+	subtag uint32
+	data   []byte
+}
+
+// readTracev3Chunks splits data into chunks; truncated reports a partial trailing chunk
+func readTracev3Chunks(data []byte) (chunks []tracev3Chunk, truncated bool) {
+	for pos := 0; pos+16 <= len(data); {
+		size := binary.LittleEndian.Uint64(data[pos+8:])
+		start := pos + 16
+		if size > uint64(len(data)-start) {
+			return chunks, true
+		}
+		chunks = append(chunks, tracev3Chunk{
+			tag:    binary.LittleEndian.Uint32(data[pos:]),
+			subtag: binary.LittleEndian.Uint32(data[pos+4:]),
+			data:   data[start : start+int(size)],
+		})
+		pos = start + int(size) + padding8(int(size))
+	}
+	return chunks, false
+}
+
+// tracev3BootUUID returns the boot UUID recorded in a tracev3 header chunk
+func tracev3BootUUID(header []byte) string {
+	r := &tracev3Reader{data: header}
+	r.take(40) // Timebase, continuous time, wall time, timezone bias and flags
+	for !r.err && len(r.remaining()) >= 8 {
+		tag, size := r.u32(), r.u32()
+		body := r.take(int(size))
+		if tag == tracev3GenerationSubtag && len(body) >= 16 {
+			return strings.ToUpper(hex.EncodeToString(body[:16]))
+		}
+	}
+	return ""
+}
+
+// decompressTracev3Chunkset decompresses the LZ4 blocks of a chunkset
+func decompressTracev3Chunkset(data []byte) ([]byte, error) {
+	var out []byte
+	r := &tracev3Reader{data: data}
+	for !r.err && len(r.remaining()) >= 4 {
+		switch string(r.take(4)) {
+		case "bv41":
+			r.u32() // Uncompressed size
+			block := r.take(int(r.u32()))
+			if r.err {
+				break
+			}
+			var err error
+			// Later blocks may reference earlier output, so decode into the shared buffer
+			if out, err = lz4DecodeBlock(block, out); err != nil {
+				return nil, err
+			}
+		case "bv4-":
+			out = append(out, r.take(int(r.u32()))...)
+		case "bv4$":
+			return out, nil
+		default:
+			return nil, errors.New("unknown chunkset block signature")
+		}
+	}
+	if r.err {
+		return nil, errors.New("truncated chunkset")
+	}
+	return out, nil
+}
+
+// lz4DecodeBlock decodes one raw LZ4 block, appending to dst
+func lz4DecodeBlock(src, dst []byte) ([]byte, error) {
+	errCorrupt := errors.New("corrupt LZ4 block")
+	readLength := func(i, n int) (int, int, error) {
+		if n != 15 {
+			return i, n, nil
+		}
+		for {
+			if i >= len(src) {
+				return i, 0, errCorrupt
+			}
+			b := src[i]
+			i++
+			n += int(b)
+			if b != 255 {
+				return i, n, nil
+			}
+		}
+	}
+
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		var litLen int
+		var err error
+		if i, litLen, err = readLength(i, int(token>>4)); err != nil {
+			return nil, err
+		}
+		if litLen > len(src)-i {
+			return nil, errCorrupt
+		}
+		dst = append(dst, src[i:i+litLen]...)
+		i += litLen
+		if i == len(src) {
+			break // Last sequence has literals only
+		}
+
+		if i+2 > len(src) {
+			return nil, errCorrupt
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errCorrupt
+		}
+
+		var matchLen int
+		if i, matchLen, err = readLength(i, int(token&15)); err != nil {
+			return nil, err
+		}
+		matchLen += 4
+		start := len(dst) - offset
+		for k := 0; k < matchLen; k++ {
+			dst = append(dst, dst[start+k])
+		}
+	}
+	return dst, nil
+}
+
+// unifiedLogCatalog describes the processes whose entries follow it in a tracev3 file
+type unifiedLogCatalog struct {
+	uuids     []string
+	processes map[unifiedLogProcKey]*unifiedLogProcess
+}
+
+// unifiedLogProcKey identifies a process within a catalog
+type unifiedLogProcKey struct {
+	first  uint64
+	second uint32
+}
+
+// unifiedLogProcess holds catalog information for one process
+type unifiedLogProcess struct {
+	pid        uint32
+	euid       uint32
+	mainUUID   string
+	dscUUID    string
+	subsystems map[uint16][2]string // Subsystem identifier -> subsystem, category
+}
+
+// uuidAt returns the catalog UUID at index, or "" if out of range
+func (c *unifiedLogCatalog) uuidAt(index uint16) string {
+	if int(index) < len(c.uuids) {
+		return c.uuids[index]
+	}
+	return ""
+}
+
+// parseTracev3Catalog parses a catalog chunk
+func parseTracev3Catalog(data []byte) (*unifiedLogCatalog, error) {
+	r := &tracev3Reader{data: data}
+	subsystemStringsOffset := int(r.u16())
+	processInfoOffset := int(r.u16())
+	processCount := int(r.u16())
+	r.u16()   // Sub-chunks offset
+	r.u16()   // Sub-chunk count
+	r.take(6) // Padding
+	r.u64()   // Earliest firehose time
+	base := r.pos
+
+	catalog := &unifiedLogCatalog{processes: make(map[unifiedLogProcKey]*unifiedLogProcess)}
+	for i := 0; i < subsystemStringsOffset/16 && !r.err; i++ {
+		catalog.uuids = append(catalog.uuids, r.uuid())
+	}
+	if r.err || subsystemStringsOffset > processInfoOffset || base+processInfoOffset > len(data) {
+		return nil, errors.New("truncated catalog")
+	}
+	subsystemStrings := data[base+subsystemStringsOffset : base+processInfoOffset]
+
+	r.pos = base + processInfoOffset
+	for i := 0; i < processCount && !r.err; i++ {
+		r.u16() // Index
+		r.u16() // Unknown
+		mainIndex, dscIndex := r.u16(), r.u16()
+		key := unifiedLogProcKey{first: r.u64(), second: r.u32()}
+		proc := &unifiedLogProcess{
+			pid:        r.u32(),
+			euid:       r.u32(),
+			mainUUID:   catalog.uuidAt(mainIndex),
+			dscUUID:    catalog.uuidAt(dscIndex),
+			subsystems: make(map[uint16][2]string),
+		}
+		r.u32() // Unknown
+		imageCount := int(r.u32())
+		r.u32()                 // Unknown
+		r.take(imageCount * 16) // Image size, unknown, UUID index and load address
+
+		subsystemCount := int(r.u32())
+		r.u32() // Unknown
+		for j := 0; j < subsystemCount && !r.err; j++ {
+			id := r.u16()
+			subsystemOffset, categoryOffset := int(r.u16()), int(r.u16())
+			proc.subsystems[id] = [2]string{
+				nulString(subsystemStrings, subsystemOffset),
+				nulString(subsystemStrings, categoryOffset),
+			}
+		}
+		r.take(padding8(subsystemCount * 6))
+		catalog.processes[key] = proc
+	}
+	if r.err {
+		return catalog, errors.New("truncated catalog process entries")
+	}
+	return catalog, nil
+}
+
+// ============================================================================
+// Firehose entries
+// ============================================================================
+
+// firehoseArgKind classifies a firehose message argument
+type firehoseArgKind int
+
+const (
+	firehoseArgNumber firehoseArgKind = iota
+	firehoseArgString
+	firehoseArgPrivate
+	firehoseArgPrecision
+)
+
+// firehoseArg is one decoded message argument
+type firehoseArg struct {
+	kind firehoseArgKind
+	raw  []byte
+	text string
+}
+
+// firehoseFormatter records where an entry's format string is stored
+type firehoseFormatter struct {
+	kind             uint16
+	largeOffset      uint16
+	largeSharedCache uint16
+	altIndex         uint16
+	uuid             string
+}
+
+// readFirehoseFormatter reads the formatter fields selected by the entry flags
+func readFirehoseFormatter(r *tracev3Reader, flags uint16) firehoseFormatter {
+	f := firehoseFormatter{kind: flags & firehoseFormatterMask}
+	switch f.kind {
+	case firehoseMainExe, firehoseSharedCache:
+		if flags&firehoseLargeOffset != 0 {
+			f.largeOffset = r.u16()
+		}
+	case firehoseLargeSharedCache:
+		if flags&firehoseLargeOffset != 0 {
+			f.largeOffset = r.u16()
+		}
+		f.largeSharedCache = r.u16()
+	case firehoseAbsolute:
+		f.altIndex = r.u16()
+	case firehoseUUIDRelative:
+		f.uuid = r.uuid()
+	}
+	return f
+}
+
+// firehose item types by how their value is stored
+var (
+	firehoseStringItems  = map[uint8]bool{0x20: true, 0x22: true, 0x30: true, 0x32: true, 0x40: true, 0x42: true, 0xf2: true}
+	firehosePrivateItems = map[uint8]bool{0x21: true, 0x25: true, 0x31: true, 0x35: true, 0x41: true, 0x45: true, 0x85: true}
+	firehosePrivateNums  = map[uint8]bool{0x01: true, 0x05: true}
+	firehosePrecisions   = map[uint8]bool{0x10: true, 0x12: true}
+)
+
+// readFirehoseItems decodes the argument items of an entry
+// String values live after the item headers; private strings live in private
+func readFirehoseItems(data, private []byte, flags uint16) []firehoseArg {
+	type stringRef struct {
+		arg          int
+		offset, size int
+		isPrivate    bool
+	}
+
+	r := &tracev3Reader{data: data}
+	r.u8() // Unknown
+	count := int(r.u8())
+
+	var args []firehoseArg
+	var refs []stringRef
+	for i := 0; i < count && !r.err; i++ {
+		itemType, itemSize := r.u8(), int(r.u8())
+		switch {
+		case firehoseStringItems[itemType], firehosePrivateItems[itemType]:
+			offset, size := int(r.u16()), int(r.u16())
+			refs = append(refs, stringRef{arg: len(args), offset: offset, size: size, isPrivate: firehosePrivateItems[itemType]})
+			args = append(args, firehoseArg{kind: firehoseArgString})
+		case firehosePrivateNums[itemType]:
+			r.take(itemSize)
+			args = append(args, firehoseArg{kind: firehoseArgPrivate})
+		case firehosePrecisions[itemType]:
+			args = append(args, firehoseArg{kind: firehoseArgPrecision, raw: r.take(itemSize)})
+		default:
+			args = append(args, firehoseArg{kind: firehoseArgNumber, raw: r.take(itemSize)})
+		}
+	}
+
+	strData := r.remaining()
+	// Skip an embedded backtrace: signature, UUID and offset counts, UUIDs, offsets and indexes
+	if flags&firehoseHasBacktrace != 0 && len(strData) >= 6 && strData[0] == 0x01 && strData[1] == 0x00 && strData[2] == 0x18 {
+		br := &tracev3Reader{data: strData}
+		br.take(3)
+		uuidCount := int(br.u8())
+		offsetCount := int(br.u16())
+		br.take(uuidCount*16 + offsetCount*5 + (4-offsetCount%4)%4)
+		strData = br.remaining()
+	}
+
+	for _, ref := range refs {
+		source := strData
+		if ref.isPrivate {
+			source = private
+		}
+		switch {
+		case ref.size == 0 && ref.isPrivate:
+			args[ref.arg] = firehoseArg{kind: firehoseArgPrivate}
+		case ref.size == 0:
+			args[ref.arg].text = "(null)"
+		case ref.offset+ref.size <= len(source):
+			args[ref.arg].text = strings.TrimRight(string(source[ref.offset:ref.offset+ref.size]), "\x00")
+		case ref.isPrivate:
+			args[ref.arg] = firehoseArg{kind: firehoseArgPrivate}
+		default:
+			args[ref.arg].text = "<decode: missing data>"
+		}
+	}
+	return args
+}
+
+// unifiedLogEntry is one decoded log entry awaiting format resolution
+type unifiedLogEntry struct {
+	catalog        *unifiedLogCatalog
+	process        *unifiedLogProcess
+	continuousTime uint64
+	activityType   uint8
+	logType        uint8
+	formatLocation uint32
+	formatter      firehoseFormatter
+	subsystemID    uint16
+	hasSubsystem   bool
+	args           []firehoseArg
+	dataRef        *tracev3OversizeKey
+	text           string // Preformatted message for loss, statedump and simpledump entries
+	sender         string // Sender image path for simpledump entries
+}
+
+// tracev3OversizeKey identifies the oversize chunk holding an entry's arguments
+type tracev3OversizeKey struct {
+	proc    unifiedLogProcKey
+	dataRef uint32
+}
+
+// tracev3Session accumulates state while reading one tracev3 file
+type tracev3Session struct {
+	archive  *unifiedLogArchive
+	catalog  *unifiedLogCatalog
+	entries  []*unifiedLogEntry
+	oversize map[tracev3OversizeKey][]firehoseArg
+	skipped  int
+}
+
+// readChunk dispatches one decompressed chunkset chunk
+func (s *tracev3Session) readChunk(c tracev3Chunk) {
+	switch c.tag {
+	case tracev3FirehoseTag:
+		s.readFirehose(c.data)
+	case tracev3OversizeTag:
+		s.readOversize(c.data)
+	case tracev3StatedumpTag:
+		s.readStatedump(c.data)
+	case tracev3SimpledumpTag:
+		s.readSimpledump(c.data)
+	}
+}
+
+// process looks up a process in the current catalog
+func (s *tracev3Session) process(key unifiedLogProcKey) *unifiedLogProcess {
+	if s.catalog == nil {
+		return nil
+	}
+	return s.catalog.processes[key]
+}
+
+// readFirehose reads the entries of a firehose chunk
+func (s *tracev3Session) readFirehose(data []byte) {
+	r := &tracev3Reader{data: data}
+	key := unifiedLogProcKey{first: r.u64(), second: r.u32()}
+	r.u8()    // TTL
+	r.u8()    // Collapsed
+	r.take(2) // Unknown
+	publicSize := int(r.u16())
+	privateVirtualOffset := int(r.u16())
+	r.u16() // Unknown
+	r.u16() // Unknown
+	baseTime := r.u64()
+	if r.err || publicSize < 16 {
+		s.skipped++
+		return
+	}
+
+	publicEnd := r.pos + publicSize - 16
+	if publicEnd > len(data) {
+		publicEnd = len(data)
+	}
+	var private []byte
+	if privateVirtualOffset < firehosePrivateVirtualEnd {
+		if size := firehosePrivateVirtualEnd - privateVirtualOffset; size <= len(data)-publicEnd {
+			private = data[len(data)-size:]
+		}
+	}
+	proc := s.process(key)
+
+	for pos := r.pos; pos+firehoseEntryHeaderLen <= publicEnd; {
+		er := &tracev3Reader{data: data[pos:publicEnd]}
+		activityType, logType := er.u8(), er.u8()
+		flags := er.u16()
+		formatLocation := er.u32()
+		er.u64() // Thread ID
+		delta := uint64(er.u32()) | uint64(er.u16())<<32
+		dataSize := int(er.u16())
+		body := er.take(dataSize)
+		if activityType == 0 || er.err {
+			break
+		}
+		pos += firehoseEntryHeaderLen + dataSize + padding8(dataSize)
+
+		entry := &unifiedLogEntry{
+			catalog:        s.catalog,
+			process:        proc,
+			continuousTime: baseTime + delta,
+			activityType:   activityType,
+			logType:        logType,
+			formatLocation: formatLocation,
+		}
+		if s.readFirehoseEntry(entry, key, flags, body, private, privateVirtualOffset) {
+			s.entries = append(s.entries, entry)
+		}
+	}
+}
+
+// readFirehoseEntry decodes the type-specific data of a firehose entry
+// Returns false for entry types that are not reported
+func (s *tracev3Session) readFirehoseEntry(entry *unifiedLogEntry, key unifiedLogProcKey, flags uint16, body, private []byte, privateVirtualOffset int) bool {
+	r := &tracev3Reader{data: body}
+	var privateStart, privateSize int
+
+	switch entry.activityType {
+	case firehoseNonActivity:
+		if flags&firehoseHasCurrentAID != 0 {
+			r.u32() // Activity ID
+			r.u32() // Sentinel
+		}
+		if flags&firehosePrivateRange != 0 {
+			privateStart, privateSize = int(r.u16()), int(r.u16())
+		}
+		r.u32() // PC ID
+		entry.formatter = readFirehoseFormatter(r, flags)
+		if flags&firehoseHasSubsystem != 0 {
+			entry.subsystemID = r.u16()
+			entry.hasSubsystem = true
+		}
+		if flags&firehoseHasTTL != 0 {
+			r.u8()
+		}
+		if flags&firehoseHasDataRef != 0 {
+			entry.dataRef = &tracev3OversizeKey{proc: key, dataRef: uint32(r.u16())}
+		}
+	case firehoseActivity:
+		if flags&firehoseHasCurrentAID != 0 {
+			r.u32() // Activity ID
+			r.u32() // Sentinel
+		}
+		if flags&firehoseUniquePID != 0 {
+			r.u64()
+		}
+		if flags&firehoseHasCurrentAID != 0 {
+			r.u32() // New activity ID
+			r.u32() // Sentinel
+		}
+		if flags&firehoseHasSubsystem != 0 {
+			r.u32() // Other activity ID
+			r.u32() // Sentinel
+		}
+		r.u32() // PC ID
+		entry.formatter = readFirehoseFormatter(r, flags)
+	case firehoseLoss:
+		r.u64() // Start time
+		r.u64() // End time
+		entry.text = fmt.Sprintf("lost %d unified log messages", r.u64())
+		return !r.err
+	default:
+		// Trace and signpost entries are not part of default `log show` output
+		s.skipped++
+		return false
+	}
+
+	if r.err {
+		s.skipped++
+		return false
+	}
+	if entry.dataRef == nil {
+		var entryPrivate []byte
+		if privateSize > 0 {
+			start := privateStart - privateVirtualOffset
+			if start >= 0 && start+privateSize <= len(private) {
+				entryPrivate = private[start:]
+			}
+		}
+		entry.args = readFirehoseItems(r.remaining(), entryPrivate, flags)
+	}
+	return true
+}
+
+// readOversize stores the arguments of entries too large for a firehose chunk
+func (s *tracev3Session) readOversize(data []byte) {
+	r := &tracev3Reader{data: data}
+	proc := unifiedLogProcKey{first: r.u64(), second: r.u32()}
+	r.u8()    // TTL
+	r.take(3) // Unknown
+	r.u64()   // Continuous time
+	dataRef := r.u32()
+	publicSize, privateSize := int(r.u16()), int(r.u16())
+	public := r.take(publicSize)
+	private := r.take(privateSize)
+	if public == nil {
+		s.skipped++
+		return
+	}
+	s.oversize[tracev3OversizeKey{proc: proc, dataRef: dataRef}] = readFirehoseItems(public, private, 0)
+}
+
+// readStatedump records a statedump chunk by its title
+func (s *tracev3Session) readStatedump(data []byte) {
+	r := &tracev3Reader{data: data}
+	key := unifiedLogProcKey{first: r.u64(), second: r.u32()}
+	r.u8()    // TTL
+	r.take(3) // Unknown
+	continuousTime := r.u64()
+	r.u64()  // Activity ID
+	r.uuid() // Unknown
+	r.u32()  // Data type
+	dataSize := r.u32()
+	r.take(128) // Decoder library and type
+	title := nulString(r.take(64), 0)
+	if r.err {
+		s.skipped++
+		return
+	}
+	s.entries = append(s.entries, &unifiedLogEntry{
+		catalog:        s.catalog,
+		process:        s.process(key),
+		continuousTime: continuousTime,
+		text:           fmt.Sprintf("statedump %s (%d bytes)", title, dataSize),
+	})
+}
+
+// readSimpledump records a simpledump chunk, which carries its message inline
+func (s *tracev3Session) readSimpledump(data []byte) {
+	r := &tracev3Reader{data: data}
+	key := unifiedLogProcKey{first: r.u64(), second: r.u32()}
+	continuousTime := r.u64()
+	r.u64() // Thread ID
+	r.u32() // Unknown offset
+	r.u16() // TTL
+	r.u16() // Type
+	senderUUID := r.uuid()
+	r.uuid() // DSC UUID
+	r.u32()  // Message string count
+	subsystemSize, messageSize := int(r.u32()), int(r.u32())
+	subsystem := nulString(r.take(subsystemSize), 0)
+	message := nulString(r.take(messageSize), 0)
+	if r.err {
+		s.skipped++
+		return
+	}
+	if subsystem != "" {
+		message = fmt.Sprintf("[%s] %s", subsystem, message)
+	}
+	s.entries = append(s.entries, &unifiedLogEntry{
+		catalog:        s.catalog,
+		process:        s.process(key),
+		continuousTime: continuousTime,
+		text:           message,
+		sender:         s.archive.imagePath(senderUUID),
+	})
+}
+
+// largeFormatOffset combines a large offset prefix with a format location
+func largeFormatOffset(large uint16, location uint32) uint64 {
+	if large == 0 {
+		return uint64(location)
+	}
+	return uint64(large)<<28 | uint64(location&0x0fffffff)
+}
+
+// resolveFormat finds an entry's format string and the image it came from
+func (s *tracev3Session) resolveFormat(e *unifiedLogEntry) (format, sender string, ok bool) {
+	var mainUUID, dscUUID string
+	if e.process != nil {
+		mainUUID, dscUUID = e.process.mainUUID, e.process.dscUUID
+	}
+	if e.formatLocation&firehoseDynamicFormat != 0 {
+		return "%s", s.archive.imagePath(mainUUID), true
+	}
+
+	switch e.formatter.kind {
+	case firehoseMainExe:
+		if u := s.archive.uuidtext(mainUUID); u != nil {
+			format, ok = u.formatString(largeFormatOffset(e.formatter.largeOffset, e.formatLocation))
+			return format, u.libraryPath, ok
+		}
+	case firehoseSharedCache, firehoseLargeSharedCache:
+		if d := s.archive.dsc(dscUUID); d != nil {
+			large := e.formatter.largeOffset
+			if e.formatter.largeSharedCache != 0 {
+				large = e.formatter.largeSharedCache / 2
+			}
+			return d.formatString(largeFormatOffset(large, e.formatLocation))
+		}
+	case firehoseAbsolute, firehoseUUIDRelative:
+		uuid := e.formatter.uuid
+		if e.formatter.kind == firehoseAbsolute && e.catalog != nil {
+			uuid = e.catalog.uuidAt(e.formatter.altIndex)
+		}
+		if u := s.archive.uuidtext(uuid); u != nil {
+			format, ok = u.formatString(uint64(e.formatLocation))
+			return format, u.libraryPath, ok
+		}
+	}
+	return "", "", false
+}
+
+// formatUnifiedLogMessage expands a format string with firehose arguments
+func formatUnifiedLogMessage(format string, args []firehoseArg) string {
+	next := 0
+	pop := func(precision bool) (firehoseArg, bool) {
+		for next < len(args) {
+			arg := args[next]
+			next++
+			// Precision items only feed '*' specifiers
+			if arg.kind != firehoseArgPrecision || precision {
+				return arg, true
+			}
+		}
+		return firehoseArg{}, false
+	}
+
+	return unifiedLogFormatPattern.ReplaceAllStringFunc(format, func(spec string) string {
+		m := unifiedLogFormatPattern.FindStringSubmatch(spec)
+		if m[6] == "%" {
+			return "%"
+		}
+		if m[3] == "*" {
+			pop(true)
+		}
+		if m[4] == "*" {
+			pop(true)
+		}
+		arg, ok := pop(false)
+		if !ok {
+			return "<decode: missing data>"
+		}
+		switch arg.kind {
+		case firehoseArgPrivate:
+			return "<private>"
+		case firehoseArgString:
+			return arg.text
+		}
+		return formatFirehoseNumber(arg.raw, strings.Trim(m[1], "{}"), m[6])
+	})
+}
+
+// formatFirehoseNumber renders an inline numeric argument for a conversion
+func formatFirehoseNumber(raw []byte, annotation, conv string) string {
+	var u uint64
+	for i := len(raw) - 1; i >= 0 && i < 8; i-- {
+		u = u<<8 | uint64(raw[i])
+	}
+	signed := int64(u)
+	switch len(raw) {
+	case 1:
+		signed = int64(int8(u))
+	case 2:
+		signed = int64(int16(u))
+	case 4:
+		signed = int64(int32(u))
+	}
+
+	for _, a := range strings.Split(annotation, ",") {
+		switch strings.TrimSpace(a) {
+		case "BOOL":
+			if u != 0 {
+				return "YES"
+			}
+			return "NO"
+		case "bool":
+			return strconv.FormatBool(u != 0)
+		case "time_t":
+			return time.Unix(signed, 0).UTC().Format("2006-01-02 15:04:05+0000")
+		}
+	}
+
+	switch conv {
+	case "u":
+		return strconv.FormatUint(u, 10)
+	case "x", "X", "o":
+		return fmt.Sprintf("%"+conv, u)
+	case "p":
+		return fmt.Sprintf("0x%x", u)
+	case "c", "C":
+		return string(rune(u))
+	case "e", "E", "f", "F", "g", "G", "a", "A":
+		value := math.Float64frombits(u)
+		if len(raw) == 4 {
+			value = float64(math.Float32frombits(uint32(u)))
+		}
+		verb := strings.ToLower(conv)
+		if verb == "a" {
+			verb = "g"
+		}
+		return fmt.Sprintf("%"+verb, value)
+	}
+	return strconv.FormatInt(signed, 10)
+}
+
+// ============================================================================
+// macOS Unified Log tracev3 Parser
+// ============================================================================
+
+// MacOSTraceV3Parser implements the Parser interface for native Unified Log tracev3 files
+// Reads .logarchive bundles and /private/var/db/diagnostics without `log show`,
+// resolving format strings from uuidtext and dsc files
+type MacOSTraceV3Parser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *MacOSTraceV3Parser) CanParse(filePath string) bool {
+	if strings.EqualFold(filepath.Ext(filePath), ".tracev3") {
+		return true
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 8)
+	n, _ := file.Read(header)
+	if n < 8 {
+		return false
+	}
+	if binary.LittleEndian.Uint32(header) == tracev3HeaderTag && binary.LittleEndian.Uint32(header[4:]) == tracev3HeaderSubtag {
+		return true
+	}
+	return isUnifiedLogSupportFile(filePath, header)
+}
+
+// isUnifiedLogSupportFile reports whether a file is a timesync, uuidtext or dsc file
+func isUnifiedLogSupportFile(filePath string, header []byte) bool {
+	if len(header) < 4 {
+		return false
+	}
+	if strings.EqualFold(filepath.Ext(filePath), ".timesync") {
+		return binary.LittleEndian.Uint16(header) == timesyncBootSignature
+	}
+	magic := binary.LittleEndian.Uint32(header)
+	return magic == uuidtextSignature || magic == dscSignature
+}
+
+// Parse parses a tracev3 file and returns a slice of events
+func (p *MacOSTraceV3Parser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Support files are read through the tracev3 files that reference them
+	if isUnifiedLogSupportFile(filePath, data) {
+		fmt.Printf("Skipped macOS Unified Log support file: %s (resolved through tracev3 files)\n", filePath)
+		return []*core.Event{}, nil
+	}
+
+	chunks, truncated := readTracev3Chunks(data)
+	if len(chunks) == 0 || chunks[0].tag != tracev3HeaderTag {
+		return nil, fmt.Errorf("not a tracev3 file: %w", ErrUnsupportedFormat)
+	}
+	if truncated {
+		fmt.Printf("Warning: tracev3 file %s is truncated\n", filePath)
+	}
+
+	s := &tracev3Session{
+		archive:  openUnifiedLogArchive(filePath),
+		oversize: make(map[tracev3OversizeKey][]firehoseArg),
+	}
+	bootUUID := tracev3BootUUID(chunks[0].data)
+	boot := s.archive.boots[bootUUID]
+	var fileTime time.Time
+	if boot == nil {
+		fmt.Printf("Warning: no timesync data for boot %s in %s, using file modification time\n", bootUUID, filePath)
+		if info, err := os.Stat(filePath); err == nil {
+			fileTime = info.ModTime().UTC()
+		}
+	}
+
+	for _, c := range chunks[1:] {
+		switch c.tag {
+		case tracev3CatalogTag:
+			if s.catalog, err = parseTracev3Catalog(c.data); err != nil {
+				fmt.Printf("Warning: %v in %s\n", err, filePath)
+			}
+		case tracev3ChunksetTag:
+			decompressed, err := decompressTracev3Chunkset(c.data)
+			if err != nil {
+				fmt.Printf("Warning: failed to decompress chunkset in %s: %v\n", filePath, err)
+				continue
+			}
+			inner, _ := readTracev3Chunks(decompressed)
+			for _, ic := range inner {
+				s.readChunk(ic)
+			}
+		}
+	}
+
+	events := make([]*core.Event, 0, len(s.entries))
+	source := filepath.Base(filePath)
+	unresolved := 0
+
+	for i, e := range s.entries {
+		var message, sender string
+		if e.text != "" {
+			message, sender = e.text, e.sender
+		} else {
+			if e.dataRef != nil {
+				e.args = s.oversize[*e.dataRef]
+			}
+			format, formatSender, ok := s.resolveFormat(e)
+			sender = formatSender
+			if ok {
+				message = formatUnifiedLogMessage(format, e.args)
+			} else {
+				unresolved++
+				message = fmt.Sprintf("<format string not found at 0x%x>", e.formatLocation)
+			}
+		}
+
+		var pid uint64
+		var user, processPath, subsystem, category string
+		if e.process != nil {
+			pid = uint64(e.process.pid)
+			user = strconv.FormatUint(uint64(e.process.euid), 10)
+			processPath = s.archive.imagePath(e.process.mainUUID)
+			if e.hasSubsystem {
+				names := e.process.subsystems[e.subsystemID]
+				subsystem, category = names[0], names[1]
+			}
+		}
+		if e.activityType == firehoseActivity {
+			message = "Activity: " + message
+		}
+
+		timestamp := fileTime
+		if boot != nil {
+			timestamp = boot.wallClock(e.continuousTime)
+		}
+
+		event := core.NewEvent(
+			timestamp,
+			source,
+			"UnifiedLog",
+			i+1,
+			user,
+			"",
+			unifiedLogMessage(processPath, pid, sender, subsystem, category, message),
+			filePath,
+		)
+		if level, ok := firehoseLogTypes[e.logType]; ok && e.text == "" && e.activityType == firehoseNonActivity {
+			event.Tags = append(event.Tags, "level:"+strings.ToLower(level))
+		}
+		if boot == nil {
+			markFileMtime(event)
+		}
+		events = append(events, event)
+	}
+
+	fmt.Printf("Parsed macOS Unified Log tracev3 file: %s (found %d events, %d unresolved formats, %d skipped entries)\n",
+		filePath, len(events), unresolved, s.skipped)
+	return events, nil
+}
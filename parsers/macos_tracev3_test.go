@@ -0,0 +1,166 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// tracev3TestChunk builds a tagged chunk with 8-byte alignment padding
+func tracev3TestChunk(tag, subtag uint32, data []byte) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, tag)
+	binary.Write(&b, binary.LittleEndian, subtag)
+	binary.Write(&b, binary.LittleEndian, uint64(len(data)))
+	b.Write(data)
+	b.Write(make([]byte, padding8(len(data))))
+	return b.Bytes()
+}
+
+// lz4LiteralBlock encodes data as a single literal-only LZ4 sequence
+func lz4LiteralBlock(data []byte) []byte {
+	if len(data) < 15 {
+		return append([]byte{byte(len(data) << 4)}, data...)
+	}
+	block := []byte{0xf0}
+	rest := len(data) - 15
+	for ; rest >= 255; rest -= 255 {
+		block = append(block, 255)
+	}
+	return append(append(block, byte(rest)), data...)
+}
+
+func TestMacOSTraceV3Parser(t *testing.T) {
+	le := binary.LittleEndian
+	mainUUID, _ := hex.DecodeString("4C2F9E1A0B6D4E8F9A1B2C3D4E5F6071")
+	bootUUID, _ := hex.DecodeString("A1B2C3D4E5F60718293A4B5C6D7E8F90")
+	bootTime := time.Date(2023, 4, 21, 15, 0, 0, 0, time.UTC)
+
+	root := filepath.Join(t.TempDir(), "system_logs.logarchive")
+	for _, dir := range []string{"Persist", "timesync", "dsc", "4C"} {
+		os.MkdirAll(filepath.Join(root, dir), 0755)
+	}
+
+	// uuidtext: one range at image offset 0x100 followed by the binary path
+	format := "Connection from %{public}s failed: %d\x00"
+	var uuidtext bytes.Buffer
+	for _, v := range []uint32{uuidtextSignature, 2, 1, 1, 0x100, uint32(len(format))} {
+		binary.Write(&uuidtext, le, v)
+	}
+	uuidtext.WriteString(format + "/usr/sbin/sshd\x00")
+	os.WriteFile(filepath.Join(root, "4C", "2F9E1A0B6D4E8F9A1B2C3D4E5F6071"), uuidtext.Bytes(), 0644)
+
+	// timesync: boot record plus a sync record at continuous time 0, 1:1 timebase
+	var timesync bytes.Buffer
+	binary.Write(&timesync, le, uint16(timesyncBootSignature))
+	binary.Write(&timesync, le, uint16(timesyncBootLen))
+	binary.Write(&timesync, le, uint32(0))
+	timesync.Write(bootUUID)
+	binary.Write(&timesync, le, []uint32{1, 1})
+	binary.Write(&timesync, le, bootTime.UnixNano())
+	binary.Write(&timesync, le, []uint32{0, 0})
+	binary.Write(&timesync, le, uint32(timesyncRecordSignature))
+	binary.Write(&timesync, le, uint32(0))
+	binary.Write(&timesync, le, uint64(0))
+	binary.Write(&timesync, le, bootTime.UnixNano())
+	binary.Write(&timesync, le, []uint32{0, 0})
+	os.WriteFile(filepath.Join(root, "timesync", "0000000000000001.timesync"), timesync.Bytes(), 0644)
+
+	// Header chunk with only the generation sub-chunk carrying the boot UUID
+	header := make([]byte, 40)
+	header = le.AppendUint32(header, tracev3GenerationSubtag)
+	header = le.AppendUint32(header, 24)
+	header = append(append(header, bootUUID...), make([]byte, 8)...)
+
+	// Catalog: one UUID, subsystem strings, one process with one subsystem
+	strs := "com.openssh.sshd\x00auth\x00\x00\x00\x00\x00\x00\x00"
+	var catalog bytes.Buffer
+	binary.Write(&catalog, le, []uint16{16, uint16(16 + len(strs)), 1, 0, 0})
+	catalog.Write(make([]byte, 6+8))
+	catalog.Write(mainUUID)
+	catalog.WriteString(strs)
+	binary.Write(&catalog, le, []uint16{0, 0, 0, 0})
+	binary.Write(&catalog, le, uint64(7))
+	binary.Write(&catalog, le, []uint32{9, 412, 0, 0, 0, 0})
+	binary.Write(&catalog, le, []uint32{1, 0})
+	binary.Write(&catalog, le, []uint16{3, 0, 17})
+	catalog.Write(make([]byte, padding8(6)))
+
+	// Firehose entry: main_exe format string, subsystem 3, a string and an int argument
+	var body bytes.Buffer
+	binary.Write(&body, le, uint32(0)) // PC ID
+	binary.Write(&body, le, uint16(3)) // Subsystem
+	body.Write([]byte{0x12, 2})
+	body.Write([]byte{0x22, 4})
+	binary.Write(&body, le, []uint16{0, 12})
+	body.Write([]byte{0x00, 4})
+	binary.Write(&body, le, int32(22))
+	body.WriteString("203.0.113.5\x00")
+
+	var entry bytes.Buffer
+	entry.Write([]byte{firehoseNonActivity, 0x10})
+	binary.Write(&entry, le, uint16(firehoseMainExe|firehoseHasSubsystem))
+	binary.Write(&entry, le, uint32(0x100))
+	binary.Write(&entry, le, uint64(0x1234))
+	binary.Write(&entry, le, uint32(500_000_000)) // Half a second after the base time
+	binary.Write(&entry, le, uint16(0))
+	binary.Write(&entry, le, uint16(body.Len()))
+	entry.Write(body.Bytes())
+	entry.Write(make([]byte, padding8(body.Len())))
+
+	var firehose bytes.Buffer
+	binary.Write(&firehose, le, uint64(7))
+	binary.Write(&firehose, le, uint32(9))
+	firehose.Write(make([]byte, 4))
+	binary.Write(&firehose, le, []uint16{uint16(16 + entry.Len()), firehosePrivateVirtualEnd, 0, 0})
+	binary.Write(&firehose, le, uint64(60_000_000_000)) // One minute after boot
+	firehose.Write(entry.Bytes())
+
+	decompressed := tracev3TestChunk(tracev3FirehoseTag, 0, firehose.Bytes())
+	compressed := lz4LiteralBlock(decompressed)
+	var chunkset bytes.Buffer
+	chunkset.WriteString("bv41")
+	binary.Write(&chunkset, le, []uint32{uint32(len(decompressed)), uint32(len(compressed))})
+	chunkset.Write(compressed)
+	chunkset.WriteString("bv4$")
+
+	var tracev3 bytes.Buffer
+	tracev3.Write(tracev3TestChunk(tracev3HeaderTag, tracev3HeaderSubtag, header))
+	tracev3.Write(tracev3TestChunk(tracev3CatalogTag, 0x11, catalog.Bytes()))
+	tracev3.Write(tracev3TestChunk(tracev3ChunksetTag, 0x11, chunkset.Bytes()))
+	tracePath := filepath.Join(root, "Persist", "0000000000000001.tracev3")
+	os.WriteFile(tracePath, tracev3.Bytes(), 0644)
+
+	parser, err := GetParserForFile(tracePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*MacOSTraceV3Parser); !ok {
+		t.Fatalf("Expected MacOSTraceV3Parser, got %T", parser)
+	}
+
+	events, err := parser.Parse(tracePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	want := "[sshd(412)] [com.openssh.sshd:auth] Connection from 203.0.113.5 failed: 22"
+	if events[0].Message != want {
+		t.Errorf("Expected message %q, got %q", want, events[0].Message)
+	}
+	if wantTime := bootTime.Add(60500 * time.Millisecond); !events[0].Timestamp.Equal(wantTime) {
+		t.Errorf("Expected timestamp %s, got %s", wantTime, events[0].Timestamp)
+	}
+
+	// Support files are claimed so they are not parsed as text logs
+	events, err = parser.Parse(filepath.Join(root, "timesync", "0000000000000001.timesync"))
+	if err != nil || len(events) != 0 {
+		t.Errorf("Expected timesync file to produce no events, got %d (%v)", len(events), err)
+	}
+}
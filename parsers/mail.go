@@ -0,0 +1,439 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for mail server logs
+var (
+	// Mail log file names: maillog, mail.log, mail.info, maillog.1, maillog-20230421
+	mailLogNamePattern = regexp.MustCompile(`^(?:maillog|mail\.(?:log|info|warn|err))(?:[.-]\d+)?$`)
+
+	// Syslog line from a mail daemon
+	// Example: Apr 21 15:30:45 mx1 postfix/smtpd[1234]: 3F1A2B4C5D: client=mail.example.com[203.0.113.5]
+	mailLinePattern = regexp.MustCompile(`^([A-Z][a-z]{2}\s+\d{1,2}\s+\d{2}:\d{2}:\d{2}|\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)\s+(\S+)\s+([^\s:\[]+)(?:\[(\d+)\])?:\s+(.*)$`)
+
+	// Queue ID prefix of a message line: "3F1A2B4C5D: ..." (Postfix) or "u3LFUjQe012345: ..." (Sendmail)
+	mailQueueIDPattern = regexp.MustCompile(`^([0-9A-Za-z]{5,20}):\s+(.*)$`)
+
+	// NOQUEUE: reject: RCPT from unknown[203.0.113.5]: 554 5.7.1 <bob@corp.com>: Relay access denied; from=<a@b> to=<c@d> proto=ESMTP helo=<x>
+	mailNoQueuePattern = regexp.MustCompile(`^NOQUEUE:\s+(\w+):\s+(?:(\w+) from ([^:]+):\s+)?(.*?)(?:;\s+(from=.*))?$`)
+
+	// Header/body check log: "warning: header Subject: Invoice overdue from ..." or "reject: header Subject: ..."
+	mailHeaderCheckPattern = regexp.MustCompile(`^(?:warning|info|reject|discard|hold|redirect):\s+header\s+Subject:\s+(.*?)\s+from\s+\S+;`)
+
+	// Exchange message tracking file names: MSGTRK2023042115-1.LOG, MSGTRKMD2023042115-1.LOG
+	msgTrackNamePattern = regexp.MustCompile(`(?i)^msgtrk[a-z]*\d+(?:-\d+)?\.log$`)
+)
+
+// mailFieldOrder is the rendering order for correlated Postfix/Sendmail message fields
+var mailFieldOrder = []string{"client", "sasl_username", "message-id", "msgid", "subject", "size", "nrcpt", "nrcpts", "proto"}
+
+// ============================================================================
+// Postfix/Sendmail Mail Log Parser
+// ============================================================================
+
+// MailLogParser implements the Parser interface for Postfix and Sendmail syslog output (maillog, mail.log)
+// Lines sharing a queue ID across smtpd, cleanup, qmgr and smtp/local are folded into one message event,
+// which is emitted when qmgr removes the message so a later reuse of the queue ID starts a new one
+type MailLogParser struct{}
+
+// mailMessage accumulates the log lines of one queued message
+type mailMessage struct {
+	queueID    string
+	first      time.Time
+	host       string
+	lineNum    int
+	fields     map[string]string
+	recipients []string
+	rejected   bool
+	removed    bool
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *MailLogParser) CanParse(filePath string) bool {
+	if mailLogNamePattern.MatchString(strings.ToLower(filepath.Base(filePath))) {
+		return true
+	}
+
+	// Mail lines are often forwarded into other syslog files; require several queue ID lines
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	matches := 0
+	for _, line := range lines {
+		if m := mailLinePattern.FindStringSubmatch(line); m != nil && isMailDaemon(m[3]) {
+			if q := mailQueueIDPattern.FindStringSubmatch(m[5]); q != nil && isMailQueueID(q[1]) {
+				matches++
+			}
+		}
+	}
+	return matches >= 3
+}
+
+// Parse parses a mail log file and returns a slice of events
+func (p *MailLogParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	// Pre-allocate slice with estimated capacity (avg 150 bytes per mail log line, several lines per message)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 600))
+	lineNum := 0
+	source := filepath.Base(filePath)
+	messages := make(map[string]*mailMessage)
+	var order []*mailMessage
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Truncate line before regex matching to prevent ReDoS
+		matches := mailLinePattern.FindStringSubmatch(truncateLine(line))
+		if matches == nil {
+			continue
+		}
+		timestamp, _ := parseSyslogPrefix(matches[1], yearlessPlaceholder)
+		host, program, text := matches[2], matches[3], matches[5]
+
+		if !isMailDaemon(program) {
+			// Other daemons logging to the mail facility (dovecot, amavis, opendkim)
+			events = append(events, core.NewEvent(timestamp, source, "MailLog", lineNum, "", host, fmt.Sprintf("[%s] %s", program, text), filePath))
+			continue
+		}
+
+		if m := mailNoQueuePattern.FindStringSubmatch(text); m != nil {
+			fields := splitMailFields(strings.ReplaceAll(m[5], " ", ", "))
+			msg := fmt.Sprintf("Mail %s: %s", m[1], m[4])
+			if m[2] != "" {
+				msg = fmt.Sprintf("Mail %s at %s from %s: %s", m[1], m[2], m[3], m[4])
+			}
+			if fields["from"] != "" || fields["to"] != "" {
+				msg += fmt.Sprintf(" from=%s to=%s", fields["from"], fields["to"])
+			}
+			if fields["helo"] != "" {
+				msg += " helo=" + fields["helo"]
+			}
+			event := core.NewEvent(timestamp, source, "MailReject", lineNum, "", host, msg, filePath)
+			event.Score = 0.5
+			events = append(events, event)
+			continue
+		}
+
+		q := mailQueueIDPattern.FindStringSubmatch(text)
+		if q == nil || !isMailQueueID(q[1]) {
+			events = append(events, core.NewEvent(timestamp, source, "MailLog", lineNum, "", host, fmt.Sprintf("[%s] %s", program, text), filePath))
+			continue
+		}
+
+		queueID, detail := q[1], q[2]
+		message, ok := messages[queueID]
+		if !ok {
+			message = &mailMessage{queueID: queueID, first: timestamp, host: host, lineNum: lineNum, fields: make(map[string]string)}
+			messages[queueID] = message
+			order = append(order, message)
+		}
+		if detail == "removed" {
+			message.removed = true
+			delete(messages, queueID)
+			events = append(events, message.toEvent(source, filePath))
+			continue
+		}
+		message.addLine(detail)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	// Messages still queued when the log ends
+	for _, message := range order {
+		if !message.removed {
+			events = append(events, message.toEvent(source, filePath))
+		}
+	}
+
+	// Message events are emitted after the lines around them, so years are inferred in line order
+	years := newYearInferrer(filePath)
+	ordered := slices.Clone(events)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].EventID < ordered[j].EventID })
+	for _, event := range ordered {
+		years.add(event)
+	}
+	years.apply()
+
+	fmt.Printf("Parsed mail log: %s (found %d events, %d messages)\n", filePath, len(events), len(order))
+	return events, nil
+}
+
+// addLine merges the fields of one queue ID line into the message
+func (m *mailMessage) addLine(detail string) {
+	if sub := mailHeaderCheckPattern.FindStringSubmatch(detail); sub != nil {
+		m.fields["subject"] = sub[1]
+		if strings.HasPrefix(detail, "reject:") || strings.HasPrefix(detail, "discard:") {
+			m.rejected = true
+		}
+		return
+	}
+	if strings.HasPrefix(detail, "milter-reject:") || strings.HasPrefix(detail, "reject:") {
+		m.rejected = true
+		m.fields["reject"] = detail
+		return
+	}
+
+	fields := splitMailFields(detail)
+	if to := fields["to"]; to != "" {
+		// Delivery attempt: one entry per recipient with its relay and status
+		entry := "to=" + to
+		if relay := fields["relay"]; relay != "" {
+			entry += " relay=" + relay
+		}
+		if status := firstNonEmpty(fields, "status", "stat"); status != "" {
+			entry += " status=" + status
+		}
+		m.recipients = append(m.recipients, entry)
+		return
+	}
+	for key, value := range fields {
+		// qmgr appends the queue state: "nrcpt=1 (queue active)"
+		if key == "nrcpt" || key == "size" {
+			value, _, _ = strings.Cut(value, " ")
+		}
+		if _, exists := m.fields[key]; !exists {
+			m.fields[key] = value
+		}
+	}
+}
+
+// toEvent renders the correlated message as a single event
+func (m *mailMessage) toEvent(source, filePath string) *core.Event {
+	parts := []string{fmt.Sprintf("Mail %s:", m.queueID)}
+	if from, ok := m.fields["from"]; ok {
+		parts = append(parts, "from="+from)
+	}
+	parts = append(parts, m.recipients...)
+	if m.fields["relay"] != "" {
+		// Sendmail records the sending relay on the from= line
+		parts = append(parts, "sender_relay="+m.fields["relay"])
+	}
+	for _, key := range mailFieldOrder {
+		if value := m.fields[key]; value != "" {
+			if key == "subject" {
+				value = fmt.Sprintf("%q", value)
+			}
+			parts = append(parts, key+"="+value)
+		}
+	}
+	if m.fields["reject"] != "" {
+		parts = append(parts, m.fields["reject"])
+	}
+
+	event := core.NewEvent(m.first, source, "MailMessage", m.lineNum, m.fields["sasl_username"], m.host, strings.Join(parts, " "), filePath)
+	if m.rejected {
+		event.Score = 0.5
+	}
+	return event
+}
+
+// isMailDaemon reports whether a syslog program tag belongs to Postfix or Sendmail
+func isMailDaemon(program string) bool {
+	return strings.HasPrefix(program, "postfix") || program == "sendmail" || strings.HasPrefix(program, "sm-")
+}
+
+// isMailQueueID distinguishes queue IDs from words like "warning" or "disconnect"
+// Postfix short IDs are uppercase hex; long Postfix and Sendmail IDs are 10+ mixed-case characters with digits
+func isMailQueueID(id string) bool {
+	if strings.Trim(id, "0123456789ABCDEF") == "" {
+		return true
+	}
+	return len(id) >= 10 && strings.ContainsAny(id, "0123456789")
+}
+
+// splitMailFields splits "key=value, key=<v, w>, status=sent (250 2.0.0 Ok, queued)" into a map,
+// keeping commas inside angle brackets and parentheses
+func splitMailFields(s string) map[string]string {
+	fields := make(map[string]string)
+	depth := 0
+	start := 0
+	add := func(part string) {
+		part = strings.TrimSpace(part)
+		if key, value, ok := strings.Cut(part, "="); ok && key != "" && !strings.ContainsAny(key, " <(") {
+			fields[key] = value
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '<', '(':
+			depth++
+		case '>', ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				add(s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(s[start:])
+	return fields
+}
+
+// ============================================================================
+// Exchange Message Tracking Log Parser
+// ============================================================================
+
+// ExchangeMessageTrackingParser implements the Parser interface for Exchange Server message tracking logs
+// (TransportRoles\Logs\MessageTracking\MSGTRK*.LOG, W3C-style #Fields header with CSV records)
+type ExchangeMessageTrackingParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *ExchangeMessageTrackingParser) CanParse(filePath string) bool {
+	if msgTrackNamePattern.MatchString(filepath.Base(filePath)) {
+		return true
+	}
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#Log-type: Message Tracking Log") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses an Exchange message tracking log and returns a slice of events
+func (p *ExchangeMessageTrackingParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	// Pre-allocate slice with estimated capacity (avg 500 bytes per tracking record)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 500))
+	lineNum := 0
+	source := filepath.Base(filePath)
+	var columns []string
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = string(stripBOM([]byte(line)))
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if fieldList, ok := strings.CutPrefix(line, "#Fields:"); ok {
+				columns = strings.Split(strings.TrimSpace(fieldList), ",")
+			}
+			continue
+		}
+		if columns == nil {
+			continue
+		}
+
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.LazyQuotes = true
+		reader.FieldsPerRecord = -1
+		values, err := reader.Read()
+		if err != nil {
+			fmt.Printf("Warning: failed to parse message tracking line %d: %v\n", lineNum, err)
+			continue
+		}
+		fields := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if i < len(values) {
+				fields[column] = values[i]
+			}
+		}
+
+		timestamp, err := parseLogTime(time.RFC3339Nano, fields["date-time"])
+		if err != nil {
+			fmt.Printf("Warning: invalid message tracking timestamp on line %d: %q\n", lineNum, fields["date-time"])
+			continue
+		}
+
+		events = append(events, core.NewEvent(
+			timestamp,
+			source,
+			"ExchangeMessageTracking",
+			lineNum,
+			fields["sender-address"],
+			fields["server-hostname"],
+			exchangeTrackingMessage(fields),
+			filePath,
+		))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	fmt.Printf("Parsed Exchange message tracking log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// exchangeTrackingMessage renders a tracking record: "RECEIVE via SMTP: sender -> recipients subject=..."
+func exchangeTrackingMessage(fields map[string]string) string {
+	parts := []string{fmt.Sprintf("%s via %s:", fields["event-id"], fields["source"])}
+	recipients := strings.ReplaceAll(fields["recipient-address"], ";", ", ")
+	parts = append(parts, fmt.Sprintf("%s -> %s", fields["sender-address"], recipients))
+	if subject := fields["message-subject"]; subject != "" {
+		parts = append(parts, fmt.Sprintf("subject=%q", subject))
+	}
+	if fields["message-id"] != "" {
+		parts = append(parts, "message-id="+fields["message-id"])
+	}
+	if fields["client-ip"] != "" {
+		client := fields["client-ip"]
+		if fields["client-hostname"] != "" {
+			client += " (" + fields["client-hostname"] + ")"
+		}
+		parts = append(parts, "client="+client)
+	}
+	if fields["original-client-ip"] != "" {
+		parts = append(parts, "original_client="+fields["original-client-ip"])
+	}
+	if fields["recipient-status"] != "" {
+		parts = append(parts, "status="+fields["recipient-status"])
+	}
+	if fields["total-bytes"] != "" {
+		parts = append(parts, "bytes="+fields["total-bytes"])
+	}
+	if fields["directionality"] != "" {
+		parts = append(parts, "direction="+fields["directionality"])
+	}
+	return strings.Join(parts, " ")
+}
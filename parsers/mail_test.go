@@ -0,0 +1,148 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailLogParser(t *testing.T) {
+	content := strings.Join([]string{
+		`Apr 21 15:30:45 mx1 postfix/smtpd[1234]: connect from mail.example.com[203.0.113.5]`,
+		`Apr 21 15:30:45 mx1 postfix/smtpd[1234]: 3F1A2B4C5D: client=mail.example.com[203.0.113.5], sasl_method=PLAIN, sasl_username=alice`,
+		`Apr 21 15:30:46 mx1 postfix/cleanup[1240]: 3F1A2B4C5D: message-id=<20230421153045.abc@example.com>`,
+		`Apr 21 15:30:46 mx1 postfix/cleanup[1240]: 3F1A2B4C5D: warning: header Subject: Invoice overdue from mail.example.com[203.0.113.5]; from=<alice@example.com> to=<bob@corp.com> proto=ESMTP helo=<mail.example.com>`,
+		`Apr 21 15:30:46 mx1 postfix/qmgr[900]: 3F1A2B4C5D: from=<alice@example.com>, size=5120, nrcpt=1 (queue active)`,
+		`Apr 21 15:30:47 mx1 postfix/smtp[1250]: 3F1A2B4C5D: to=<bob@corp.com>, relay=mail.corp.com[10.0.0.5]:25, delay=1.2, delays=0.1/0/0.5/0.6, dsn=2.0.0, status=sent (250 2.0.0 Ok: queued as 9E8D7C6B5A)`,
+		`Apr 21 15:30:47 mx1 postfix/qmgr[900]: 3F1A2B4C5D: removed`,
+		`Apr 21 15:31:02 mx1 postfix/smtpd[1260]: NOQUEUE: reject: RCPT from unknown[198.51.100.7]: 554 5.7.1 <ceo@corp.com>: Relay access denied; from=<spoof@corp.com> to=<ceo@corp.com> proto=ESMTP helo=<corp.com>`,
+		`Apr 21 15:31:05 mx1 dovecot: imap-login: Login: user=<bob>, method=PLAIN, rip=10.0.0.9`,
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "maillog")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write maillog: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*MailLogParser); !ok {
+		t.Fatalf("Expected MailLogParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	byType := make(map[string][]string)
+	for _, event := range events {
+		byType[event.EventType] = append(byType[event.EventType], event.Message)
+	}
+	if len(byType["MailMessage"]) != 1 || len(byType["MailReject"]) != 1 || len(byType["MailLog"]) != 2 {
+		t.Fatalf("Unexpected events by type: %v", byType)
+	}
+
+	want := `Mail 3F1A2B4C5D: from=<alice@example.com> to=<bob@corp.com> relay=mail.corp.com[10.0.0.5]:25 status=sent (250 2.0.0 Ok: queued as 9E8D7C6B5A) ` +
+		`client=mail.example.com[203.0.113.5] sasl_username=alice message-id=<20230421153045.abc@example.com> subject="Invoice overdue" size=5120 nrcpt=1`
+	if byType["MailMessage"][0] != want {
+		t.Errorf("Expected message %q, got %q", want, byType["MailMessage"][0])
+	}
+	wantReject := `Mail reject at RCPT from unknown[198.51.100.7]: 554 5.7.1 <ceo@corp.com>: Relay access denied from=<spoof@corp.com> to=<ceo@corp.com> helo=<corp.com>`
+	if byType["MailReject"][0] != wantReject {
+		t.Errorf("Expected reject %q, got %q", wantReject, byType["MailReject"][0])
+	}
+
+	for _, event := range events {
+		if event.EventType == "MailMessage" {
+			if event.User != "alice" || event.Host != "mx1" || event.Timestamp.Format("Jan 2 15:04:05") != "Apr 21 15:30:45" {
+				t.Errorf("Unexpected message attribution: user=%q host=%q time=%s", event.User, event.Host, event.Timestamp)
+			}
+		}
+	}
+}
+
+func TestMailLogParserReusedQueueID(t *testing.T) {
+	content := strings.Join([]string{
+		`Apr 21 15:30:45 mx1 postfix/smtpd[1234]: 3F1A2B4C5D: client=mail.example.com[203.0.113.5], sasl_method=PLAIN, sasl_username=alice`,
+		`Apr 21 15:30:46 mx1 postfix/qmgr[900]: 3F1A2B4C5D: from=<alice@example.com>, size=5120, nrcpt=1 (queue active)`,
+		`Apr 21 15:30:47 mx1 postfix/smtp[1250]: 3F1A2B4C5D: to=<bob@corp.com>, relay=mail.corp.com[10.0.0.5]:25, status=sent (250 Ok)`,
+		`Apr 21 15:30:47 mx1 postfix/qmgr[900]: 3F1A2B4C5D: removed`,
+		`Apr 23 09:12:01 mx1 postfix/pickup[2000]: 3F1A2B4C5D: uid=1000 from=<cron@corp.com>`,
+		`Apr 23 09:12:01 mx1 postfix/qmgr[900]: 3F1A2B4C5D: from=<cron@corp.com>, size=800, nrcpt=1 (queue active)`,
+		`Apr 23 09:12:02 mx1 postfix/local[2010]: 3F1A2B4C5D: to=<root@corp.com>, relay=local, status=sent (delivered to mailbox)`,
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "maillog")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write maillog: %v", err)
+	}
+	events, err := (&MailLogParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(events))
+	}
+
+	want := []struct {
+		lineNum int
+		day     int
+		message string
+	}{
+		{1, 21, `Mail 3F1A2B4C5D: from=<alice@example.com> to=<bob@corp.com> relay=mail.corp.com[10.0.0.5]:25 status=sent (250 Ok) ` +
+			`client=mail.example.com[203.0.113.5] sasl_username=alice size=5120 nrcpt=1`},
+		{5, 23, `Mail 3F1A2B4C5D: from=<cron@corp.com> to=<root@corp.com> relay=local status=sent (delivered to mailbox) size=800 nrcpt=1`},
+	}
+	for i, w := range want {
+		event := events[i]
+		if event.EventID != w.lineNum || event.Timestamp.Day() != w.day || event.Message != w.message {
+			t.Errorf("Message %d: expected line %d on day %d %q, got line %d at %s %q",
+				i, w.lineNum, w.day, w.message, event.EventID, event.Timestamp, event.Message)
+		}
+	}
+}
+
+func TestExchangeMessageTrackingParser(t *testing.T) {
+	content := strings.Join([]string{
+		"\ufeff#Software: Microsoft Exchange Server",
+		"#Version: 15.01.2507.006",
+		"#Log-type: Message Tracking Log",
+		"#Date: 2023-04-21T15:00:00.000Z",
+		"#Fields: date-time,client-ip,client-hostname,server-ip,server-hostname,source-context,connector-id,source,event-id,internal-message-id,message-id,network-message-id,recipient-address,recipient-status,total-bytes,recipient-count,related-recipient-address,reference,message-subject,sender-address,return-path,message-info,directionality",
+		`2023-04-21T15:30:45.123Z,203.0.113.5,mail.example.com,10.0.0.5,EXCH01,08DB4A,EXCH01\Default Frontend,SMTP,RECEIVE,123456,<20230421153045.abc@example.com>,1d2c3b4a,bob@corp.com;carol@corp.com,,5120,2,,,"Invoice overdue, action required",alice@example.com,alice@example.com,,Incoming`,
+	}, "\r\n")
+
+	filePath := filepath.Join(t.TempDir(), "MSGTRK2023042115-1.LOG")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write tracking log: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	want := `RECEIVE via SMTP: alice@example.com -> bob@corp.com, carol@corp.com subject="Invoice overdue, action required" ` +
+		`message-id=<20230421153045.abc@example.com> client=203.0.113.5 (mail.example.com) bytes=5120 direction=Incoming`
+	if event.EventType != "ExchangeMessageTracking" || event.Message != want {
+		t.Errorf("Expected %q, got %s %q", want, event.EventType, event.Message)
+	}
+	if event.User != "alice@example.com" || event.Host != "EXCH01" {
+		t.Errorf("Unexpected user/host: %q %q", event.User, event.Host)
+	}
+	if wantTime := time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC); !event.Timestamp.Equal(wantTime) {
+		t.Errorf("Expected timestamp %s, got %s", wantTime, event.Timestamp)
+	}
+}
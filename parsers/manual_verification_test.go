@@ -63,6 +63,96 @@ func TestManualVerification(t *testing.T) {
 			wantHost: "",
 			wantMsg:  "[1234] 5678 Misc Validating signature...", // Regex might capture first word as type
 		},
+		{
+			name:     "Suricata EVE Alert",
+			filename: "eve.json",
+			content:  `{"timestamp":"2023-04-21T15:30:45.123456+0000","flow_id":1234,"event_type":"alert","src_ip":"10.0.0.5","src_port":51234,"dest_ip":"192.0.2.10","dest_port":80,"proto":"TCP","community_id":"1:abc=","alert":{"action":"allowed","gid":1,"signature_id":2100498,"rev":7,"signature":"GPL ATTACK_RESPONSE id check returned root","category":"Potentially Bad Traffic","severity":2}}`,
+			wantType: "SuricataAlert",
+			wantHost: "10.0.0.5",
+			wantMsg:  "10.0.0.5:51234 -> 192.0.2.10:80 [TCP] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root",
+		},
+		{
+			name:     "Suricata Fast Alert",
+			filename: "fast.log",
+			content:  `04/21/2023-15:30:45.123456  [**] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root [**] [Classification: Potentially Bad Traffic] [Priority: 2] {TCP} 192.0.2.10:80 -> 10.0.0.5:51234`,
+			wantType: "SuricataAlert",
+			wantHost: "192.0.2.10",
+			wantMsg:  "[1:2100498:7] GPL ATTACK_RESPONSE id check returned root category=Potentially Bad Traffic priority=2",
+		},
+		{
+			name:     "CEF over Syslog",
+			filename: "appliance.log",
+			content:  `<134>Apr 21 15:30:45 fw01 CEF:0|Palo Alto Networks|PAN-OS|10.1|end|TRAFFIC|3|rt=Apr 21 2023 15:30:45 GMT src=10.0.0.5 spt=51234 dst=192.0.2.10 dpt=443 proto=TCP suser=corp\\jdoe act=allow cs1Label=Rule cs1=allow web\=out`,
+			wantType: "CEF",
+			wantHost: "10.0.0.5",
+			wantMsg:  "[Palo Alto Networks PAN-OS] TRAFFIC act=allow 10.0.0.5:51234 -> 192.0.2.10:443 [TCP] (sig=end sev=3) suser=corp\\jdoe cs1Label=Rule cs1=allow web=out",
+		},
+		{
+			name:     "FortiGate Key Value",
+			filename: "fortigate.log",
+			content:  `date=2023-04-21 time=15:30:45 devname="FGT60E" devid="FGT60E0000000001" logid="0000000013" type="traffic" subtype="forward" level="notice" srcip=10.0.0.5 srcport=51234 dstip=192.0.2.10 dstport=443 action="deny" policyid=3`,
+			wantType: "KeyValue",
+			wantHost: "10.0.0.5",
+			wantMsg:  "[FGT60E] traffic/forward act=deny 10.0.0.5:51234 -> 192.0.2.10:443 (sig=0000000013 sev=notice)",
+		},
+		{
+			name:     "Syslog With Key Value Message",
+			filename: "messages",
+			content:  `Apr 21 15:30:45 host app[12]: session opened user=bob action=login src=1.2.3.4 dst=5.6.7.8`,
+			wantType: "Syslog",
+			wantHost: "host",
+			wantMsg:  "[app[12]] session opened user=bob action=login src=1.2.3.4 dst=5.6.7.8",
+		},
+		{
+			name:     "App Log With Key Value Message",
+			filename: "app2.log",
+			content:  `2023-04-21T15:30:45.003Z web01 billing[4242]: charge failed user=bob action=charge src=10.0.0.5 dst=10.0.0.9`,
+			wantType: "LogEntry",
+			wantHost: "",
+			wantMsg:  "web01 billing[4242]: charge failed user=bob action=charge src=10.0.0.5 dst=10.0.0.9",
+		},
+		{
+			name:     "Windows DNS Debug Log",
+			filename: "dns.log",
+			content:  "4/21/2023 3:30:45 PM 0E60 PACKET  000000B2E3A2F180 UDP Rcv 10.0.0.5        c2b9   Q [0001   D   NOERROR] A      (3)www(6)google(3)com(0)",
+			wantType: "WindowsDNSQuery",
+			wantHost: "10.0.0.5",
+			wantMsg:  "query from 10.0.0.5 [UDP] A www.google.com id=c2b9 rcode=NOERROR flags=D",
+		},
+		{
+			name:     "Windows DHCP Audit Log",
+			filename: "DhcpSrvLog-Fri.log",
+			content:  "\t\tMicrosoft DHCP Service Activity Log\n\nEvent ID  Meaning\n00\tThe log was started.\n\nID,Date,Time,Description,IP Address,Host Name,MAC Address,User Name, TransactionID, QResult,Probationtime, CorrelationID,Dhcid,VendorClass(Hex),VendorClass(ASCII),UserClass(Hex),UserClass(ASCII),RelayAgentInformation,DnsRegError.\n10,04/21/23,15:30:45,Assign,10.0.0.50,laptop01.corp.local,0050568A1B2C,,1234567,0,,,,0x4D53465420352E30,MSFT 5.0,,,,0",
+			wantType: "WindowsDHCP",
+			wantHost: "laptop01.corp.local",
+			wantMsg:  "[10] Assign (New lease granted) ip=10.0.0.50 host=laptop01.corp.local mac=00:50:56:8A:1B:2C vendor_class=MSFT 5.0",
+		},
+		{
+			name:     "macOS log show NDJSON",
+			filename: "system.ndjson",
+			content: `{"traceID":4295013380,"eventMessage":"Authentication failed for user jdoe","eventType":"logEvent","subsystem":"com.apple.opendirectoryd","category":"auth","threadID":1234,"senderImagePath":"/System/Library/PrivateFrameworks/Heimdal.framework/Heimdal","processImagePath":"/usr/libexec/opendirectoryd","timestamp":"2023-04-21 15:30:45.123456-0700","machTimestamp":93871233,"messageType":"Error","processID":112}
+{"count":1,"finished":1}`,
+			wantType: "UnifiedLog",
+			wantHost: "",
+			wantMsg:  "[opendirectoryd(112)] (Heimdal) [com.apple.opendirectoryd:auth] Authentication failed for user jdoe",
+		},
+		{
+			name:     "macOS log show JSON",
+			filename: "unified_export.json",
+			content: `[{
+  "eventMessage" : "Activated: com.apple.screensaver",
+  "eventType" : "logEvent",
+  "processImagePath" : "/usr/libexec/loginwindow",
+  "senderImagePath" : "/usr/libexec/loginwindow",
+  "timestamp" : "2023-04-21 15:30:45.000000-0700",
+  "machTimestamp" : 93871233,
+  "messageType" : "Default",
+  "processID" : 150
+}]`,
+			wantType: "UnifiedLog",
+			wantHost: "",
+			wantMsg:  "[loginwindow(150)] Activated: com.apple.screensaver",
+		},
 	}
 
 	for _, tt := range tests {
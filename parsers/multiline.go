@@ -0,0 +1,133 @@
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ============================================================================
+// Multi-line Record Assembly
+// ============================================================================
+
+// Pre-compiled regex patterns for record boundaries
+var (
+	// A line that opens with a timestamp, optionally bracketed or after a level keyword
+	// Example: 2023-04-21 15:30:45,123 ERROR ..., [21/Apr/2023:15:30:45 +0000] ..., INFO  4/21/2023 3:30:45 PM ...
+	recordTimestampPrefixPattern = regexp.MustCompile(`^[\[(]?(?:[A-Z]{3,8}\s+[\[(]?)?(?:\d{4}[-/.]\d{1,2}[-/.]\d{1,2}[T ]\d{1,2}:\d{2}|\d{1,2}[/.-]\d{1,2}[/.-]\d{2,4}[ ,]+\d{1,2}:\d{2}|\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}|[A-Z][a-z]{2}\s+\d{1,2}\s+\d{2}:\d{2}:\d{2}|\d{2}-[A-Z][a-z]{2}-\d{4}\s+\d{2}:\d{2}|[A-Z][a-z]{2} [A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2})`)
+)
+
+// maxContinuationLines caps the stack trace lines kept per record
+const maxContinuationLines = 200
+
+// minTimestampedHeaderLines is how many header lines must open with a timestamp before
+// lines without one are treated as continuations
+const minTimestampedHeaderLines = 2
+
+// customMultilineStart is set once at startup (before parsing begins) by SetMultilineStartPattern
+var customMultilineStart *regexp.Regexp
+
+// SetMultilineStartPattern configures the regex that marks the first line of a record in plain text logs
+// Lines that do not match are folded into the preceding record. An empty pattern restores timestamp detection.
+func SetMultilineStartPattern(expr string) error {
+	if expr == "" {
+		customMultilineStart = nil
+		return nil
+	}
+	compiled, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid multi-line start pattern: %w", err)
+	}
+	customMultilineStart = compiled
+	return nil
+}
+
+// everyLineStartsRecord is the record boundary for files without multi-line records
+func everyLineStartsRecord(string) bool {
+	return true
+}
+
+// recordStartFunc chooses how a line-based file is split into records
+// The configured start pattern wins when it matches the header; otherwise records begin at
+// timestamped lines if the header is timestamped, and at every line if it is not
+func recordStartFunc(filePath string) func(line string) bool {
+	if customMultilineStart != nil && countHeaderMatches(filePath, customMultilineStart) > 0 {
+		return customMultilineStart.MatchString
+	}
+	if countHeaderMatches(filePath, recordTimestampPrefixPattern) >= minTimestampedHeaderLines {
+		return recordTimestampPrefixPattern.MatchString
+	}
+	return everyLineStartsRecord
+}
+
+// logRecord is a record-starting line plus the continuation lines that follow it
+type logRecord struct {
+	lineNum      int
+	line         string
+	continuation []string
+	dropped      int // Continuation lines past maxContinuationLines, counted but not kept
+}
+
+// scanLogRecords groups a file's lines into records that begin with a line accepted by isStart
+// Continuation lines (stack traces, wrapped messages) are attached to the preceding record;
+// lines before the first record start their own records
+func scanLogRecords(filePath string, isStart func(line string) bool, emit func(record *logRecord)) error {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	var current *logRecord
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if current == nil || isStart(truncateLine(line)) {
+			if current != nil {
+				emit(current)
+			}
+			current = &logRecord{lineNum: lineNum, line: line}
+			continue
+		}
+		if len(current.continuation) < maxContinuationLines {
+			current.continuation = append(current.continuation, line)
+		} else {
+			current.dropped++
+		}
+	}
+	if current != nil {
+		emit(current)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	return nil
+}
+
+// withContinuation appends a record's continuation lines to msg, one per line
+func (r *logRecord) withContinuation(msg string) string {
+	if len(r.continuation) == 0 {
+		return msg
+	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, line := range r.continuation {
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+	if r.dropped > 0 {
+		fmt.Fprintf(&b, "\n... (%d more lines)", r.dropped)
+	}
+	return b.String()
+}
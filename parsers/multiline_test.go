@@ -0,0 +1,121 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMultilineRecordAssembly(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app.log": strings.Join([]string{
+			"2023-04-21 15:30:45,123 ERROR [main] c.a.Billing - Payment failed",
+			"java.lang.IllegalStateException: card declined",
+			"\tat com.acme.Billing.charge(Billing.java:42)",
+			"Caused by: java.net.SocketTimeoutException: Read timed out",
+			"\t... 12 more",
+			"2023-04-21 15:30:46,000 INFO [main] c.a.Billing - Retrying",
+		}, "\n"),
+		"service.txt": strings.Join([]string{
+			"[2023-04-21 15:30:45] Unhandled exception",
+			"System.NullReferenceException: Object reference not set to an instance of an object.",
+			"   at Acme.Service.Run() in C:\\src\\Service.cs:line 17",
+			"   --- End of inner exception stack trace ---",
+			"[2023-04-21 15:30:47] Service stopped",
+		}, "\n"),
+		"notes.txt": "first free-text line\nsecond free-text line\n",
+		"CBS.log": strings.Join([]string{
+			"2023-04-21 15:30:45, Info                  CBS    Exec: Processing package",
+			"    Package: Package_for_KB5025221~31bf3856ad364e35~amd64~~19041.2846.1.8",
+			"2023-04-21 15:30:46, Info                  CBS    Exec: Done",
+		}, "\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		parser  Parser
+		count   int
+		message string
+	}{
+		{"app.log", &LogParser{}, 2, ",123 ERROR [main] c.a.Billing - Payment failed\njava.lang.IllegalStateException: card declined\n" +
+			"\tat com.acme.Billing.charge(Billing.java:42)\nCaused by: java.net.SocketTimeoutException: Read timed out\n\t... 12 more"},
+		{"service.txt", &LogParser{}, 2, "[] Unhandled exception\nSystem.NullReferenceException: Object reference not set to an instance of an object.\n" +
+			"   at Acme.Service.Run() in C:\\src\\Service.cs:line 17\n   --- End of inner exception stack trace ---"},
+		{"notes.txt", &LogParser{}, 2, "first free-text line"},
+		{"CBS.log", &WindowsTextParser{}, 2, "[Info] CBS    Exec: Processing package\n    Package: Package_for_KB5025221~31bf3856ad364e35~amd64~~19041.2846.1.8"},
+	}
+	for _, tt := range tests {
+		events, err := tt.parser.Parse(filepath.Join(dir, tt.name))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.name, err)
+		}
+		if len(events) != tt.count {
+			t.Fatalf("%s: expected %d events, got %d", tt.name, tt.count, len(events))
+		}
+		if events[0].Message != tt.message {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.message, events[0].Message)
+		}
+		if events[0].Timestamp.IsZero() != (tt.name == "notes.txt") {
+			t.Errorf("%s: unexpected timestamp %s", tt.name, events[0].Timestamp)
+		}
+	}
+}
+
+func TestMultilineContinuationCap(t *testing.T) {
+	lines := []string{"2023-04-21 15:30:45,123 ERROR [main] c.a.Worker - Recursion too deep"}
+	for i := 0; i < maxContinuationLines+50; i++ {
+		lines = append(lines, "\tat com.acme.Worker.step(Worker.java:10)")
+	}
+	lines = append(lines, "2023-04-21 15:30:46,000 INFO [main] c.a.Worker - Restarted")
+	filePath := filepath.Join(t.TempDir(), "worker.log")
+	if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	var records []*logRecord
+	err := scanLogRecords(filePath, recordTimestampPrefixPattern.MatchString, func(record *logRecord) {
+		records = append(records, record)
+	})
+	if err != nil {
+		t.Fatalf("Failed to scan: %v", err)
+	}
+	if len(records) != 2 || len(records[0].continuation) != maxContinuationLines || records[0].dropped != 50 {
+		t.Fatalf("Expected 2 records with %d kept and 50 dropped lines, got %d records", maxContinuationLines, len(records))
+	}
+	msg := records[0].withContinuation(records[0].line)
+	if strings.Count(msg, "\n") != maxContinuationLines+1 || !strings.HasSuffix(msg, "\n... (50 more lines)") {
+		t.Errorf("Unexpected capped message ending %q", msg[len(msg)-60:])
+	}
+}
+
+func TestMultilineStartPattern(t *testing.T) {
+	if err := SetMultilineStartPattern(`^>>> `); err != nil {
+		t.Fatalf("Failed to set start pattern: %v", err)
+	}
+	t.Cleanup(func() { SetMultilineStartPattern("") })
+
+	filePath := filepath.Join(t.TempDir(), "batch.log")
+	content := ">>> job 1 started\nstep a\nstep b\n>>> job 2 started\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	events, err := (&LogParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 || events[0].Message != ">>> job 1 started\nstep a\nstep b" {
+		t.Fatalf("Unexpected records: %d events, first %q", len(events), events[0].Message)
+	}
+
+	if err := SetMultilineStartPattern(`(`); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,180 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestNetFlowParserDecodesV5AndV9(t *testing.T) {
+	const exportSecs = 1682091045
+	const sysUptime = 600000
+
+	var buf bytes.Buffer
+
+	// NetFlow v5 packet with a single record
+	v5 := make([]byte, netflowV5HeaderLen+netflowV5RecordLen)
+	binary.BigEndian.PutUint16(v5[0:2], 5)
+	binary.BigEndian.PutUint16(v5[2:4], 1)
+	binary.BigEndian.PutUint32(v5[4:8], sysUptime)
+	binary.BigEndian.PutUint32(v5[8:12], exportSecs)
+	rec := v5[netflowV5HeaderLen:]
+	copy(rec[0:4], []byte{10, 0, 0, 5})
+	copy(rec[4:8], []byte{192, 0, 2, 10})
+	binary.BigEndian.PutUint32(rec[16:20], 12)
+	binary.BigEndian.PutUint32(rec[20:24], 3400)
+	binary.BigEndian.PutUint32(rec[24:28], sysUptime-10000)
+	binary.BigEndian.PutUint32(rec[28:32], sysUptime-2000)
+	binary.BigEndian.PutUint16(rec[32:34], 51234)
+	binary.BigEndian.PutUint16(rec[34:36], 443)
+	rec[37] = tcpFlagSYN | tcpFlagACK | tcpFlagFIN
+	rec[38] = ipProtoTCP
+	buf.Write(v5)
+
+	// NetFlow v9 packet with a template FlowSet followed by a data FlowSet
+	fields := [][2]uint16{
+		{nfIPv4SrcAddr, 4}, {nfIPv4DstAddr, 4}, {nfL4SrcPort, 2}, {nfL4DstPort, 2},
+		{nfProtocol, 1}, {nfInBytes, 4}, {nfInPkts, 4}, {nfFirstSwitched, 4}, {nfLastSwitched, 4},
+	}
+	template := make([]byte, 8, 8+len(fields)*4)
+	binary.BigEndian.PutUint16(template[0:2], 0)
+	binary.BigEndian.PutUint16(template[2:4], uint16(8+len(fields)*4))
+	binary.BigEndian.PutUint16(template[4:6], 256)
+	binary.BigEndian.PutUint16(template[6:8], uint16(len(fields)))
+	for _, f := range fields {
+		template = binary.BigEndian.AppendUint16(template, f[0])
+		template = binary.BigEndian.AppendUint16(template, f[1])
+	}
+
+	data := make([]byte, 4, 36)
+	binary.BigEndian.PutUint16(data[0:2], 256)
+	data = append(data, 10, 0, 0, 6, 198, 51, 100, 7)
+	data = binary.BigEndian.AppendUint16(data, 40000)
+	data = binary.BigEndian.AppendUint16(data, 53)
+	data = append(data, ipProtoUDP)
+	data = binary.BigEndian.AppendUint32(data, 120)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = binary.BigEndian.AppendUint32(data, sysUptime-5000)
+	data = binary.BigEndian.AppendUint32(data, sysUptime-5000)
+	data = append(data, 0, 0, 0) // Padding to a 4-byte boundary
+	binary.BigEndian.PutUint16(data[2:4], uint16(len(data)))
+
+	v9 := make([]byte, netflowV9HeaderLen)
+	binary.BigEndian.PutUint16(v9[0:2], 9)
+	binary.BigEndian.PutUint16(v9[2:4], 2)
+	binary.BigEndian.PutUint32(v9[4:8], sysUptime)
+	binary.BigEndian.PutUint32(v9[8:12], exportSecs)
+	v9 = append(v9, template...)
+	v9 = append(v9, data...)
+	buf.Write(v9)
+
+	filePath := filepath.Join(t.TempDir(), "router1.netflow")
+	if err := os.WriteFile(filePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write test dump: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*NetFlowParser); !ok {
+		t.Fatalf("Expected NetFlowParser, got %T", parser)
+	}
+
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	exportTime := time.Unix(exportSecs, 0).UTC()
+	tests := []struct {
+		wantTime time.Time
+		wantMsg  string
+	}{
+		{exportTime.Add(-10 * time.Second), "10.0.0.5:51234 -> 192.0.2.10:443 [TCP] bytes=3400 packets=12"},
+		{exportTime.Add(-5 * time.Second), "10.0.0.6:40000 -> 198.51.100.7:53 [UDP] bytes=120 packets=1"},
+	}
+	for i, tt := range tests {
+		if events[i].EventType != "NetFlow" {
+			t.Errorf("Event %d: expected EventType NetFlow, got %s", i, events[i].EventType)
+		}
+		if !events[i].Timestamp.Equal(tt.wantTime) {
+			t.Errorf("Event %d: expected timestamp %s, got %s", i, tt.wantTime, events[i].Timestamp)
+		}
+		if !strings.Contains(events[i].Message, tt.wantMsg) {
+			t.Errorf("Event %d: expected message to contain %q, got %q", i, tt.wantMsg, events[i].Message)
+		}
+	}
+}
+
+// netflowTestV9Template builds a v9 template FlowSet
+func netflowTestV9Template(templateID uint16, fields [][2]uint16) []byte {
+	set := make([]byte, 8, 8+len(fields)*4)
+	binary.BigEndian.PutUint16(set[2:4], uint16(8+len(fields)*4))
+	binary.BigEndian.PutUint16(set[4:6], templateID)
+	binary.BigEndian.PutUint16(set[6:8], uint16(len(fields)))
+	for _, f := range fields {
+		set = binary.BigEndian.AppendUint16(set, f[0])
+		set = binary.BigEndian.AppendUint16(set, f[1])
+	}
+	return set
+}
+
+// netflowTestV9Data builds a v9 data FlowSet holding one record
+func netflowTestV9Data(templateID uint16, record []byte) []byte {
+	set := binary.BigEndian.AppendUint16(nil, templateID)
+	set = binary.BigEndian.AppendUint16(set, uint16(4+len(record)))
+	return append(set, record...)
+}
+
+func TestNetFlowV9FlowTimes(t *testing.T) {
+	const exportSecs = 1682091045
+	const sysUptime = 600000
+	start := time.Date(2023, 4, 21, 15, 29, 0, 250e6, time.UTC)
+
+	addresses := [][2]uint16{{nfIPv4SrcAddr, 4}, {nfIPv4DstAddr, 4}, {nfProtocol, 1}}
+	absolute := append(append([][2]uint16{}, addresses...), [2]uint16{nfFlowStartMillis, 8}, [2]uint16{nfFlowEndMillis, 8})
+
+	packet := make([]byte, netflowV9HeaderLen)
+	binary.BigEndian.PutUint16(packet[0:2], 9)
+	binary.BigEndian.PutUint16(packet[2:4], 4)
+	binary.BigEndian.PutUint32(packet[4:8], sysUptime)
+	binary.BigEndian.PutUint32(packet[8:12], exportSecs)
+	packet = append(packet, netflowTestV9Template(256, absolute)...)
+	packet = append(packet, netflowTestV9Template(257, addresses)...)
+
+	record := []byte{10, 0, 0, 5, 192, 0, 2, 10, ipProtoUDP}
+	record = binary.BigEndian.AppendUint64(record, uint64(start.UnixMilli()))
+	record = binary.BigEndian.AppendUint64(record, uint64(start.Add(3*time.Second).UnixMilli()))
+	packet = append(packet, netflowTestV9Data(256, record)...)
+	packet = append(packet, netflowTestV9Data(257, []byte{10, 0, 0, 6, 192, 0, 2, 11, 1})...)
+
+	filePath := filepath.Join(t.TempDir(), "router2.netflow")
+	if err := os.WriteFile(filePath, packet, 0644); err != nil {
+		t.Fatalf("Failed to write test dump: %v", err)
+	}
+	events, err := (&NetFlowParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if !events[0].Timestamp.Equal(start) || events[0].TimestampQuality != core.TimestampExact {
+		t.Errorf("Expected absolute start %s (exact), got %s (%s)", start, events[0].Timestamp, events[0].TimestampQuality)
+	}
+	// No time elements: the export time, not router boot (export time minus sysUptime)
+	if exportTime := time.Unix(exportSecs, 0).UTC(); !events[1].Timestamp.Equal(exportTime) || events[1].TimestampQuality != core.TimestampInferred {
+		t.Errorf("Expected export time %s (inferred), got %s (%s)", exportTime, events[1].Timestamp, events[1].TimestampQuality)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -35,7 +36,7 @@ func getFileHeader(filePath string) ([]string, error) {
 	}
 
 	// Read file header
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +104,16 @@ func estimateLineCapacity(filePath string, avgBytesPerLine int64) int {
 	return estimated
 }
 
+// markFileMtime records that events were stamped with their file's modification time for want of their own
+func markFileMtime(events ...*core.Event) {
+	for _, event := range events {
+		if !event.Timestamp.IsZero() {
+			event.TimestampQuality = core.TimestampFileMtime
+			event.TimestampDesc = core.TimestampDescModification
+		}
+	}
+}
+
 // Parser defines the interface for all file parsers
 type Parser interface {
 	// Parse parses a file and returns a slice of events
@@ -112,171 +123,212 @@ type Parser interface {
 	CanParse(filePath string) bool
 }
 
-// GetParserForFile returns the appropriate parser for the given file
-func GetParserForFile(filePath string) (Parser, error) {
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	switch ext {
-	case ".evtx":
-		return &EvtxParser{}, nil
-	case ".pf": // Prefetch
-		return &PrefetchParser{}, nil
-	}
+// ParserInfo describes a parser available for detection
+type ParserInfo struct {
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+	Origin string `json:"origin,omitempty"` // Definition file of a custom parser
+}
 
-	// Check for XML-based logs and artifacts (before other specific parsers)
-	if ext == ".xml" {
-		// Windows Event XML (from wevtutil or Get-WinEvent -AsXML)
-		windowsXMLParser := &WindowsXMLEventParser{}
-		if windowsXMLParser.CanParse(filePath) {
-			return windowsXMLParser, nil
-		}
+// parserRule is one step of built-in parser detection
+type parserRule struct {
+	parser     Parser
+	extensions []string                   // When set, the rule only applies to files with one of these extensions
+	match      func(filePath string) bool // Claims the file; nil asks the parser's CanParse
+}
 
-		// Scheduled Task XML
-		scheduledTaskParser := &ScheduledTaskXMLParser{}
-		if scheduledTaskParser.CanParse(filePath) {
-			return scheduledTaskParser, nil
-		}
+// claims reports whether the rule selects its parser for the file
+func (r parserRule) claims(filePath, ext string) bool {
+	if len(r.extensions) > 0 && !slices.Contains(r.extensions, ext) {
+		return false
+	}
+	if r.match != nil {
+		return r.match(filePath)
+	}
+	return r.parser.CanParse(filePath)
+}
 
-		// Sysmon Configuration or Events XML
-		sysmonParser := &SysmonXMLParser{}
-		if sysmonParser.CanParse(filePath) {
-			return sysmonParser, nil
-		}
+// anyFile matches every file; it ends extension groups and the table with a fallback parser
+func anyFile(string) bool { return true }
 
-		// Fall back to generic XML parser
-		return &GenericXMLParser{}, nil
-	}
+// jsonExtensions are handled by the JSON parsers, falling back to the generic JSON parser
+var jsonExtensions = []string{".json", ".jsonl", ".ndjson"}
 
-	// Check for cloud platform logs (before generic JSON parser)
-	// These have specific JSON structures that need specialized parsing
-	if ext == ".json" || ext == ".jsonl" {
-		// AWS CloudTrail
-		cloudTrailParser := &CloudTrailParser{}
-		if cloudTrailParser.CanParse(filePath) {
-			return cloudTrailParser, nil
-		}
+// parserRules lists the built-in parsers in detection order; the first rule claiming a file wins
+var parserRules = []parserRule{
+	// Files named by a JSON field mapping are parsed as JSON whatever their extension
+	{parser: &JsonParser{}, match: claimsJSONFile},
 
-		// Azure Activity Log
-		azureParser := &AzureActivityParser{}
-		if azureParser.CanParse(filePath) {
-			return azureParser, nil
-		}
+	// Check for Recycle Bin metadata first; $I files keep the deleted file's extension
+	{parser: &RecycleBinParser{}},
+	{parser: &RecycleBinINFO2Parser{}},
 
-		// GCP Cloud Audit Log
-		gcpParser := &GCPAuditParser{}
-		if gcpParser.CanParse(filePath) {
-			return gcpParser, nil
-		}
+	{parser: &EvtxParser{}, extensions: []string{".evtx"}, match: anyFile},
+	{parser: &PrefetchParser{}, extensions: []string{".pf"}, match: anyFile},
+	{parser: &PcapParser{}, extensions: []string{".pcap", ".pcapng", ".cap"}, match: anyFile},
 
-		// Fall back to generic JSON parser for other JSON files
-		if ext == ".json" {
-			return &JsonParser{}, nil
-		}
-	}
+	// Check for XML-based logs and artifacts (before other specific parsers)
+	// Windows Event XML (from wevtutil or Get-WinEvent -AsXML), Scheduled Task XML, Sysmon configuration or events XML,
+	// falling back to the generic XML parser
+	{parser: &WindowsXMLEventParser{}, extensions: []string{".xml"}},
+	{parser: &ScheduledTaskXMLParser{}, extensions: []string{".xml"}},
+	{parser: &SysmonXMLParser{}, extensions: []string{".xml"}},
+	{parser: &GenericXMLParser{}, extensions: []string{".xml"}, match: anyFile},
+
+	// Check for macOS Unified Log exports and cloud platform logs (before generic JSON parser)
+	// These have specific JSON structures that need specialized parsing
+	{parser: &MacOSUnifiedLogJSONParser{}, extensions: jsonExtensions},
+	{parser: &CloudTrailParser{}, extensions: jsonExtensions},
+	{parser: &AzureActivityParser{}, extensions: jsonExtensions},
+	{parser: &GCPAuditParser{}, extensions: jsonExtensions},
+	{parser: &SuricataEVEParser{}, extensions: jsonExtensions},
+	{parser: &JsonParser{}, extensions: jsonExtensions, match: anyFile},
+
+	// Check for packet captures by magic number (rotated or extensionless tcpdump output)
+	{parser: &PcapParser{}},
+	// Check for raw NetFlow v5/v9 and IPFIX export dumps
+	{parser: &NetFlowParser{}},
+	// Check for native macOS Unified Log files (tracev3 plus timesync/uuidtext/dsc support files)
+	{parser: &MacOSTraceV3Parser{}},
+	// Check for macOS FSEvents pages (gzip files named by hex event ID)
+	{parser: &MacOSFSEventsParser{}},
 
 	// Check for browser history databases (SQLite)
 	// Must be before other checks as these files may have no extension
-	browserHistoryParser := &BrowserHistoryParser{}
-	if browserHistoryParser.CanParse(filePath) {
-		return browserHistoryParser, nil
-	}
+	{parser: &BrowserHistoryParser{}},
+
+	// Check for macOS SQLite artifacts (detected by schema)
+	{parser: &MacOSKnowledgeCParser{}},
+	{parser: &MacOSQuarantineParser{}},
+	{parser: &MacOSTCCParser{}},
+
+	// Check for Windows Timeline (ActivitiesCache.db) and SRUM (SRUDB.dat, ESE) databases
+	{parser: &WindowsTimelineParser{}},
+	{parser: &SRUMParser{}},
 
 	// Check for specific file patterns
-	baseName := strings.ToLower(filepath.Base(filePath))
-	if strings.Contains(baseName, "shellbag") {
-		return &ShellbagsParser{}, nil
-	}
+	{parser: &ShellbagsParser{}, match: func(filePath string) bool {
+		return strings.Contains(strings.ToLower(filepath.Base(filePath)), "shellbag")
+	}},
 
-	// Check for rotated logs (e.g., app.log.1)
-	if strings.Contains(baseName, ".log.") {
-		return &LogParser{}, nil
-	}
+	// Check for Windows DNS Server debug and DHCP Server audit logs
+	{parser: &WindowsDNSDebugParser{}},
+	{parser: &WindowsDHCPParser{}},
 
-	// Check for PowerShell Transcript files
-	psTranscriptParser := &PowerShellTranscriptParser{}
-	if psTranscriptParser.CanParse(filePath) {
-		return psTranscriptParser, nil
-	}
+	// Check for Windows Defender MPLog support logs and DetectionHistory files
+	{parser: &DefenderMPLogParser{}},
+	{parser: &DefenderDetectionHistoryParser{}},
 
-	// Check for PowerShell Script Block logs
-	psScriptBlockParser := &PowerShellScriptBlockParser{}
-	if psScriptBlockParser.CanParse(filePath) {
-		return psScriptBlockParser, nil
-	}
+	// Check for Postfix/Sendmail mail logs and Exchange message tracking logs
+	{parser: &MailLogParser{}},
+	{parser: &ExchangeMessageTrackingParser{}},
 
-	// Check for macOS Install Log (before unified log due to more specific filename)
-	macInstallParser := &MacOSInstallLogParser{}
-	if macInstallParser.CanParse(filePath) {
-		return macInstallParser, nil
-	}
+	// Check for MySQL/MariaDB, PostgreSQL and SQL Server logs (before rotated logs so mysql.log.1 is recognized)
+	{parser: &MSSQLErrorLogParser{}},
+	{parser: &MySQLLogParser{}},
+	{parser: &PostgreSQLLogParser{}},
 
-	// Check for macOS ASL (Apple System Log) - legacy format
-	macASLParser := &MacOSASLParser{}
-	if macASLParser.CanParse(filePath) {
-		return macASLParser, nil
-	}
+	// Check for Squid access logs, web proxy CSV feeds and OpenVPN logs
+	// Squid must be before WebAccessParser, which would take access.log by name
+	{parser: &SquidAccessParser{}},
+	{parser: &WebProxyCSVParser{}},
+	{parser: &OpenVPNParser{}},
 
-	// Check for macOS Unified Log (from `log show` command)
-	macUnifiedParser := &MacOSUnifiedLogParser{}
-	if macUnifiedParser.CanParse(filePath) {
-		return macUnifiedParser, nil
-	}
+	// Check for Suricata EVE and Snort/Suricata fast alert logs
+	// Must be before the rotated log check so eve.json.1 and fast.log.1 are recognized
+	{parser: &SuricataEVEParser{}},
+	{parser: &SnortFastAlertParser{}},
 
-	// Check for IIS Logs (must be before generic Web Access Logs)
-	iisParser := &IISParser{}
-	if iisParser.CanParse(filePath) {
-		return iisParser, nil
-	}
+	// Check for `log show --style ndjson` exports saved as .ndjson, .log or without extension
+	{parser: &MacOSUnifiedLogJSONParser{}},
 
-	// Check for Zeek (Bro) Network Logs
-	zeekParser := &ZeekParser{}
-	if zeekParser.CanParse(filePath) {
-		return zeekParser, nil
-	}
+	// Check for CEF/LEEF and appliance key=value logs (content-based, any filename)
+	{parser: &CEFParser{}},
 
-	// Check for Web Access Logs
-	webParser := &WebAccessParser{}
-	if webParser.CanParse(filePath) {
-		return webParser, nil
-	}
+	// Check for web server error logs and Tomcat/Java application logs (before rotated logs so error.log.1 is recognized)
+	{parser: &ApacheErrorLogParser{}},
+	{parser: &NginxErrorLogParser{}},
+	{parser: &TomcatLogParser{}},
 
-	// Check for Linux Syslog
-	syslogParser := &LinuxSyslogParser{}
-	if syslogParser.CanParse(filePath) {
-		return syslogParser, nil
-	}
+	// Check for rotated logs (e.g., app.log.1)
+	{parser: &LogParser{}, match: func(filePath string) bool {
+		return strings.Contains(strings.ToLower(filepath.Base(filePath)), ".log.")
+	}},
 
-	// Check for Windows Text Logs
-	winTextParser := &WindowsTextParser{}
-	if winTextParser.CanParse(filePath) {
-		return winTextParser, nil
-	}
+	// Check for PowerShell Transcript files and Script Block logs
+	{parser: &PowerShellTranscriptParser{}},
+	{parser: &PowerShellScriptBlockParser{}},
 
-	// Check for Windows Firewall logs
-	winFirewallParser := &WindowsFirewallParser{}
-	if winFirewallParser.CanParse(filePath) {
-		return winFirewallParser, nil
-	}
+	// Check for macOS Install Log (before unified log due to more specific filename),
+	// ASL (Apple System Log, legacy format) and Unified Log (from `log show` command)
+	{parser: &MacOSInstallLogParser{}},
+	{parser: &MacOSASLParser{}},
+	{parser: &MacOSUnifiedLogParser{}},
 
-	// Check for iptables/UFW logs
-	iptablesParser := &IptablesParser{}
-	if iptablesParser.CanParse(filePath) {
-		return iptablesParser, nil
-	}
+	// Check for IIS Logs (must be before generic Web Access Logs)
+	{parser: &IISParser{}},
+	// Check for Zeek (Bro) Network Logs
+	{parser: &ZeekParser{}},
+	// Check for Web Access Logs
+	{parser: &WebAccessParser{}},
+	// Check for Linux Syslog
+	{parser: &LinuxSyslogParser{}},
+	// Check for Windows Text Logs
+	{parser: &WindowsTextParser{}},
 
-	// Check for Cisco ASA logs
-	ciscoASAParser := &CiscoASAParser{}
-	if ciscoASAParser.CanParse(filePath) {
-		return ciscoASAParser, nil
-	}
+	// Check for Windows Firewall, iptables/UFW and Cisco ASA logs
+	{parser: &WindowsFirewallParser{}},
+	{parser: &IptablesParser{}},
+	{parser: &CiscoASAParser{}},
 
 	// Check for CSV artifact files (low priority - check after specific parsers)
-	csvParser := &CSVArtifactParser{}
-	if csvParser.CanParse(filePath) {
-		return csvParser, nil
-	}
+	{parser: &CSVArtifactParser{}},
 
 	// Fallback: If it has no extension or an unknown extension, treat it as a log file
 	// This ensures "any type of log file" can be entered as requested
+	{parser: &LogParser{}, match: anyFile},
+}
+
+// builtinParsers lists each built-in parser once, in the order of its first detection rule
+var builtinParsers = func() []Parser {
+	var parsers []Parser
+	seen := make(map[string]bool)
+	for _, rule := range parserRules {
+		if name := parserName(rule.parser); !seen[name] {
+			seen[name] = true
+			parsers = append(parsers, rule.parser)
+		}
+	}
+	return parsers
+}()
+
+// ListParsers returns the custom parsers, which are tried first, followed by the built-in parsers
+func ListParsers() []ParserInfo {
+	infos := make([]ParserInfo, 0, len(customParsers)+len(builtinParsers))
+	for _, custom := range customParsers {
+		infos = append(infos, ParserInfo{Name: custom.Name(), Custom: true, Origin: custom.origin})
+	}
+	for _, parser := range builtinParsers {
+		infos = append(infos, ParserInfo{Name: parserName(parser)})
+	}
+	return infos
+}
+
+// GetParserForFile returns the appropriate parser for the given file
+func GetParserForFile(filePath string) (Parser, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+
+	// User-defined parsers take precedence so they can claim files a built-in parser would misread
+	for _, custom := range customParsers {
+		if custom.CanParse(filePath) {
+			return custom, nil
+		}
+	}
+
+	for _, rule := range parserRules {
+		if rule.claims(filePath, ext) {
+			return rule.parser, nil
+		}
+	}
 	return &LogParser{}, nil
 }
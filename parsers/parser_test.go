@@ -0,0 +1,77 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGetParserForFileOrder pins which parser claims each fixture, so changes to the
+// detection order show up as a failing fixture rather than a silently misrouted file
+func TestGetParserForFileOrder(t *testing.T) {
+	const (
+		eveLine     = `{"timestamp":"2023-04-21T15:30:45.123456+0000","event_type":"alert","src_ip":"10.0.0.5","dest_ip":"192.0.2.10","proto":"TCP","alert":{"signature_id":2100498,"signature":"GPL ATTACK_RESPONSE"}}`
+		unifiedLine = `{"timestamp":"2023-04-21 15:30:45.123456+0000","eventMessage":"Session started","messageType":"Default","processImagePath":"/usr/libexec/sshd-session","subsystem":"com.openssh.sshd","eventType":"logEvent"}`
+		fastLine    = `04/21/2023-15:30:45.123456  [**] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root [**] [Priority: 2] {TCP} 192.0.2.10:80 -> 10.0.0.5:51234`
+		fortiLine   = `date=2023-04-21 time=15:30:45 devname="FGT60E" logid="0000000013" type="traffic" srcip=10.0.0.5 dstip=192.0.2.10 action="deny"`
+		syslogLine  = `Apr 21 15:30:45 host01 sshd[456]: Failed password for root from 203.0.113.5 port 22 ssh2`
+		webLine     = `127.0.0.1 - jdoe [21/Apr/2023:15:30:45 +0000] "GET /index.html HTTP/1.1" 200 1234 "-" "Mozilla/5.0"`
+		squidLine   = `1682091045.123    245 10.0.0.5 TCP_MISS/200 1520 GET http://example.com/index.html - HIER_DIRECT/93.184.216.34 text/html`
+		apacheLine  = `[Fri Apr 21 15:30:45 2023] [error] [client 203.0.113.5] File does not exist: /var/www/html/shell.php`
+		vpnLine     = `2023-04-21 15:30:46 203.0.113.5:51234 [alice] Peer Connection Initiated with [AF_INET]203.0.113.5:51234`
+	)
+	recycleBinV2 := []byte{2, 0, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		path    string
+		content []byte
+		want    string
+	}{
+		{"$Recycle.Bin/$IQ8Z3K1.json", recycleBinV2, "RecycleBinParser"},
+		{"Security.evtx", []byte("not really evtx"), "EvtxParser"},
+		{"CALC.EXE-1234ABCD.pf", nil, "PrefetchParser"},
+		{"capture.pcapng", nil, "PcapParser"},
+		{"events.xml", []byte(`<Events><Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event"><System><EventID>4624</EventID></System></Event></Events>`), "WindowsXMLEventParser"},
+		{"other.xml", []byte(`<root><item>1</item></root>`), "GenericXMLParser"},
+		{"unified.ndjson", []byte(unifiedLine + "\n"), "MacOSUnifiedLogJSONParser"},
+		{"cloudtrail-export.json", []byte(`{"Records":[]}`), "CloudTrailParser"},
+		{"eve.json", []byte(eveLine + "\n"), "SuricataEVEParser"},
+		{"data.json", []byte(`{"time":"2023-04-21T15:30:45Z","msg":"hello"}` + "\n"), "JsonParser"},
+		{"shellbags.csv", []byte("Path,LastWriteTime\nC:\\Users,2023-04-21 15:30:45\n"), "ShellbagsParser"},
+		{"access.log", []byte(squidLine + "\n"), "SquidAccessParser"},
+		{"openvpn.log", []byte(vpnLine + "\n"), "OpenVPNParser"},
+		{"eve.json.1", []byte(eveLine + "\n"), "SuricataEVEParser"},
+		{"fast.log.1", []byte(fastLine + "\n"), "SnortFastAlertParser"},
+		{"unified_export.log", []byte(unifiedLine + "\n"), "MacOSUnifiedLogJSONParser"},
+		{"forti.log", []byte(fortiLine + "\n" + fortiLine + "\n"), "CEFParser"},
+		{"error_log.1", []byte(apacheLine + "\n"), "ApacheErrorLogParser"},
+		{"app.log.1", []byte("2023-04-21 15:30:45 started\n"), "LogParser"},
+		{"install.log", []byte("2023-04-21 15:30:45-07 mac01 installd[123]: done\n"), "MacOSInstallLogParser"},
+		{"u_ex230421.log", []byte("#Fields: date time cs-method\n"), "IISParser"},
+		{"conn.log", []byte("#separator \\x09\n"), "ZeekParser"},
+		{"web/access.log", []byte(webLine + "\n"), "WebAccessParser"},
+		{"syslog", []byte(syslogLine + "\n"), "LinuxSyslogParser"},
+		{"pfirewall.log", []byte("#Version: 1.5\n"), "WindowsFirewallParser"},
+		{"ufw.log", []byte("kernel: [UFW BLOCK] IN=eth0\n"), "IptablesParser"},
+		{"report.csv", []byte("a,b\n1,2\n"), "CSVArtifactParser"},
+		{"notes.txt", []byte("hello\n"), "LogParser"},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		filePath := filepath.Join(dir, filepath.FromSlash(tt.path))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", tt.path, err)
+		}
+		if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", tt.path, err)
+		}
+		parser, err := GetParserForFile(filePath)
+		if err != nil {
+			t.Fatalf("%s: failed to get parser: %v", tt.path, err)
+		}
+		if got := parserName(parser); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.path, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,1164 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Capture file magic numbers
+const (
+	pcapMagicMicros   = 0xa1b2c3d4 // Classic pcap, microsecond timestamps
+	pcapMagicNanos    = 0xa1b23c4d // Classic pcap, nanosecond timestamps
+	pcapngBlockSHB    = 0x0a0d0d0a // pcapng Section Header Block
+	pcapngByteOrder   = 0x1a2b3c4d // pcapng byte-order magic
+	pcapngBlockIDB    = 0x00000001 // Interface Description Block
+	pcapngBlockPB     = 0x00000002 // Packet Block (obsolete)
+	pcapngBlockEPB    = 0x00000006 // Enhanced Packet Block
+	pcapMaxPacketSize = 256 * 1024 // Upper bound on a sane captured packet length
+	pcapngMaxBlock    = 16 * 1024 * 1024
+)
+
+// Link-layer header types (LINKTYPE_* values from tcpdump.org)
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSLL2     = 276
+)
+
+// IP protocol numbers used by the packet decoder
+const (
+	ipProtoTCP = 6
+	ipProtoUDP = 17
+)
+
+// TCP flag bits
+const (
+	tcpFlagFIN = 0x01
+	tcpFlagSYN = 0x02
+	tcpFlagRST = 0x04
+	tcpFlagACK = 0x10
+)
+
+// Flow inactivity timeouts (same defaults as Zeek)
+const (
+	pcapTCPFlowTimeout   = 5 * time.Minute
+	pcapOtherFlowTimeout = 1 * time.Minute
+)
+
+// errPcapCorrupt is returned when a capture file contains impossible lengths
+var errPcapCorrupt = errors.New("corrupt capture file")
+
+// capturedPacket is a single packet read from a pcap or pcapng file
+type capturedPacket struct {
+	timestamp time.Time
+	linkType  uint32
+	data      []byte
+}
+
+// packetReader yields packets from a capture file in file order
+type packetReader interface {
+	nextPacket() (*capturedPacket, error)
+}
+
+// openPacketReader detects the capture format and returns a reader for it
+func openPacketReader(r io.Reader) (packetReader, error) {
+	br := bufio.NewReaderSize(r, 256*1024)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture header: %w", err)
+	}
+
+	if binary.LittleEndian.Uint32(magic) == pcapngBlockSHB {
+		return &pcapngReader{r: br}, nil
+	}
+
+	reader := &pcapFileReader{r: br}
+	if err := reader.readHeader(); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// isCaptureFile checks the first bytes of a file for a pcap or pcapng magic number
+func isCaptureFile(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return false
+	}
+
+	le := binary.LittleEndian.Uint32(magic)
+	be := binary.BigEndian.Uint32(magic)
+	return le == pcapngBlockSHB ||
+		le == pcapMagicMicros || be == pcapMagicMicros ||
+		le == pcapMagicNanos || be == pcapMagicNanos
+}
+
+// pcapFileReader reads classic libpcap capture files
+type pcapFileReader struct {
+	r        *bufio.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType uint32
+}
+
+// readHeader reads and validates the 24-byte pcap global header
+func (p *pcapFileReader) readHeader() error {
+	header := make([]byte, 24)
+	if _, err := io.ReadFull(p.r, header); err != nil {
+		return fmt.Errorf("failed to read pcap header: %w", err)
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(header) == pcapMagicMicros:
+		p.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(header) == pcapMagicMicros:
+		p.order = binary.BigEndian
+	case binary.LittleEndian.Uint32(header) == pcapMagicNanos:
+		p.order, p.nanos = binary.LittleEndian, true
+	case binary.BigEndian.Uint32(header) == pcapMagicNanos:
+		p.order, p.nanos = binary.BigEndian, true
+	default:
+		return fmt.Errorf("%w: not a pcap or pcapng file", ErrUnsupportedFormat)
+	}
+
+	// The upper 16 bits of the link type field may carry FCS flags
+	p.linkType = p.order.Uint32(header[20:24]) & 0x0fffffff
+	return nil
+}
+
+// nextPacket reads the next packet record
+func (p *pcapFileReader) nextPacket() (*capturedPacket, error) {
+	record := make([]byte, 16)
+	if _, err := io.ReadFull(p.r, record); err != nil {
+		return nil, err
+	}
+
+	sec := int64(p.order.Uint32(record[0:4]))
+	frac := int64(p.order.Uint32(record[4:8]))
+	capLen := p.order.Uint32(record[8:12])
+	if capLen > pcapMaxPacketSize {
+		return nil, fmt.Errorf("%w: packet length %d", errPcapCorrupt, capLen)
+	}
+
+	data := make([]byte, capLen)
+	if _, err := io.ReadFull(p.r, data); err != nil {
+		return nil, err
+	}
+
+	nanos := frac * 1000
+	if p.nanos {
+		nanos = frac
+	}
+
+	return &capturedPacket{
+		timestamp: time.Unix(sec, nanos).UTC(),
+		linkType:  p.linkType,
+		data:      data,
+	}, nil
+}
+
+// pcapngInterface holds per-interface settings from an Interface Description Block
+type pcapngInterface struct {
+	linkType    uint32
+	unitsPerSec uint64 // Timestamp resolution (if_tsresol)
+	offsetSec   int64  // Timestamp offset (if_tsoffset)
+}
+
+// pcapngReader reads pcapng capture files
+type pcapngReader struct {
+	r          *bufio.Reader
+	order      binary.ByteOrder
+	interfaces []pcapngInterface
+}
+
+// nextPacket reads blocks until a packet block is found
+func (p *pcapngReader) nextPacket() (*capturedPacket, error) {
+	for {
+		blockType, body, err := p.readBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch blockType {
+		case pcapngBlockIDB:
+			p.interfaces = append(p.interfaces, p.parseInterface(body))
+
+		case pcapngBlockEPB:
+			if len(body) < 20 {
+				return nil, fmt.Errorf("%w: short enhanced packet block", errPcapCorrupt)
+			}
+			ifaceID := p.order.Uint32(body[0:4])
+			tsHigh := uint64(p.order.Uint32(body[4:8]))
+			tsLow := uint64(p.order.Uint32(body[8:12]))
+			capLen := p.order.Uint32(body[12:16])
+			if int(capLen) > len(body)-20 {
+				return nil, fmt.Errorf("%w: packet length %d", errPcapCorrupt, capLen)
+			}
+			return p.buildPacket(ifaceID, tsHigh<<32|tsLow, body[20:20+capLen])
+
+		case pcapngBlockPB:
+			if len(body) < 20 {
+				return nil, fmt.Errorf("%w: short packet block", errPcapCorrupt)
+			}
+			ifaceID := uint32(p.order.Uint16(body[0:2]))
+			tsHigh := uint64(p.order.Uint32(body[4:8]))
+			tsLow := uint64(p.order.Uint32(body[8:12]))
+			capLen := p.order.Uint32(body[12:16])
+			if int(capLen) > len(body)-20 {
+				return nil, fmt.Errorf("%w: packet length %d", errPcapCorrupt, capLen)
+			}
+			return p.buildPacket(ifaceID, tsHigh<<32|tsLow, body[20:20+capLen])
+		}
+
+		// Simple Packet Blocks carry no timestamp and are skipped along with
+		// name resolution, statistics and custom blocks
+	}
+}
+
+// readBlock reads one pcapng block and returns its type and body
+func (p *pcapngReader) readBlock() (uint32, []byte, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(p.r, header); err != nil {
+		return 0, nil, err
+	}
+
+	// The SHB type is a palindrome; its byte-order magic decides the section's endianness
+	if binary.LittleEndian.Uint32(header[0:4]) == pcapngBlockSHB {
+		bom, err := p.r.Peek(4)
+		if err != nil {
+			return 0, nil, err
+		}
+		switch {
+		case binary.LittleEndian.Uint32(bom) == pcapngByteOrder:
+			p.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(bom) == pcapngByteOrder:
+			p.order = binary.BigEndian
+		default:
+			return 0, nil, fmt.Errorf("%w: bad pcapng byte-order magic", errPcapCorrupt)
+		}
+		// Interface IDs are scoped to a section
+		p.interfaces = nil
+	}
+	if p.order == nil {
+		return 0, nil, fmt.Errorf("%w: pcapng block before section header", errPcapCorrupt)
+	}
+
+	blockType := p.order.Uint32(header[0:4])
+	totalLen := p.order.Uint32(header[4:8])
+	if totalLen < 12 || totalLen > pcapngMaxBlock || totalLen%4 != 0 {
+		return 0, nil, fmt.Errorf("%w: block length %d", errPcapCorrupt, totalLen)
+	}
+
+	// Body plus trailing copy of the block length
+	rest := make([]byte, totalLen-8)
+	if _, err := io.ReadFull(p.r, rest); err != nil {
+		return 0, nil, err
+	}
+
+	return blockType, rest[:len(rest)-4], nil
+}
+
+// parseInterface parses an Interface Description Block body
+func (p *pcapngReader) parseInterface(body []byte) pcapngInterface {
+	iface := pcapngInterface{unitsPerSec: 1000000}
+	if len(body) < 8 {
+		return iface
+	}
+	iface.linkType = uint32(p.order.Uint16(body[0:2]))
+
+	// Walk options looking for if_tsresol (9) and if_tsoffset (14)
+	opts := body[8:]
+	for len(opts) >= 4 {
+		code := p.order.Uint16(opts[0:2])
+		length := int(p.order.Uint16(opts[2:4]))
+		if code == 0 || 4+length > len(opts) {
+			break
+		}
+		value := opts[4 : 4+length]
+
+		switch {
+		case code == 9 && length >= 1:
+			exp := uint64(value[0] & 0x7f)
+			if value[0]&0x80 != 0 {
+				if exp < 64 {
+					iface.unitsPerSec = 1 << exp
+				}
+			} else if exp <= 19 {
+				iface.unitsPerSec = 1
+				for i := uint64(0); i < exp; i++ {
+					iface.unitsPerSec *= 10
+				}
+			}
+		case code == 14 && length >= 8:
+			iface.offsetSec = int64(p.order.Uint64(value[0:8]))
+		}
+
+		// Options are padded to 32 bits
+		padded := (length + 3) &^ 3
+		if 4+padded > len(opts) {
+			break
+		}
+		opts = opts[4+padded:]
+	}
+
+	return iface
+}
+
+// buildPacket converts a pcapng timestamp for the given interface into a packet
+func (p *pcapngReader) buildPacket(ifaceID uint32, ts uint64, data []byte) (*capturedPacket, error) {
+	if int(ifaceID) >= len(p.interfaces) {
+		return nil, fmt.Errorf("%w: unknown interface %d", errPcapCorrupt, ifaceID)
+	}
+	iface := p.interfaces[ifaceID]
+
+	sec := ts / iface.unitsPerSec
+	frac := ts % iface.unitsPerSec
+	var nanos uint64
+	if iface.unitsPerSec <= 1e9 && 1e9%iface.unitsPerSec == 0 {
+		nanos = frac * (1e9 / iface.unitsPerSec)
+	} else {
+		nanos = uint64(float64(frac) / float64(iface.unitsPerSec) * 1e9)
+	}
+
+	// Copy out of the block buffer so callers may retain the slice
+	packetData := make([]byte, len(data))
+	copy(packetData, data)
+
+	return &capturedPacket{
+		timestamp: time.Unix(int64(sec)+iface.offsetSec, int64(nanos)).UTC(),
+		linkType:  iface.linkType,
+		data:      packetData,
+	}, nil
+}
+
+// decodedPacket holds the network and transport layer fields of a packet
+type decodedPacket struct {
+	srcIP    netip.Addr
+	dstIP    netip.Addr
+	proto    uint8
+	srcPort  uint16
+	dstPort  uint16
+	tcpFlags uint8
+	ipLen    int    // Length of the IP datagram
+	payload  []byte // Transport payload (nil for non-first fragments)
+}
+
+// decodePacket decodes link, network and transport headers
+// Returns nil for non-IP packets or packets too short to decode
+func decodePacket(linkType uint32, data []byte) *decodedPacket {
+	var etherType uint16
+	switch linkType {
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[12:14])
+		data = data[14:]
+		// Strip 802.1Q / 802.1ad VLAN tags
+		for (etherType == 0x8100 || etherType == 0x88a8 || etherType == 0x9100) && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:4])
+			data = data[4:]
+		}
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return nil
+		}
+		// Address family is in the capturing host's byte order
+		family := binary.LittleEndian.Uint32(data[0:4])
+		if family > 0xffff {
+			family = binary.BigEndian.Uint32(data[0:4])
+		}
+		data = data[4:]
+		switch family {
+		case 2:
+			etherType = 0x0800
+		case 10, 24, 28, 30:
+			etherType = 0x86dd
+		default:
+			return nil
+		}
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[14:16])
+		data = data[16:]
+	case linkTypeSLL2:
+		if len(data) < 20 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[0:2])
+		data = data[20:]
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6, 12, 14:
+		if len(data) < 1 {
+			return nil
+		}
+		switch data[0] >> 4 {
+		case 4:
+			etherType = 0x0800
+		case 6:
+			etherType = 0x86dd
+		default:
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	switch etherType {
+	case 0x0800:
+		return decodeIPv4(data)
+	case 0x86dd:
+		return decodeIPv6(data)
+	}
+	return nil
+}
+
+// decodeIPv4 decodes an IPv4 header and its transport payload
+func decodeIPv4(data []byte) *decodedPacket {
+	if len(data) < 20 || data[0]>>4 != 4 {
+		return nil
+	}
+	headerLen := int(data[0]&0x0f) * 4
+	totalLen := int(binary.BigEndian.Uint16(data[2:4]))
+	if headerLen < 20 || len(data) < headerLen {
+		return nil
+	}
+	// Trim link-layer padding; tolerate captures truncated by snaplen
+	if totalLen >= headerLen && totalLen < len(data) {
+		data = data[:totalLen]
+	}
+
+	pkt := &decodedPacket{
+		proto: data[9],
+		ipLen: totalLen,
+	}
+	pkt.srcIP, _ = netip.AddrFromSlice(data[12:16])
+	pkt.dstIP, _ = netip.AddrFromSlice(data[16:20])
+
+	// Only the first fragment carries the transport header
+	fragOffset := binary.BigEndian.Uint16(data[6:8]) & 0x1fff
+	if fragOffset != 0 {
+		return pkt
+	}
+
+	decodeTransport(pkt, data[headerLen:])
+	return pkt
+}
+
+// decodeIPv6 decodes an IPv6 header, skipping extension headers
+func decodeIPv6(data []byte) *decodedPacket {
+	if len(data) < 40 || data[0]>>4 != 6 {
+		return nil
+	}
+	payloadLen := int(binary.BigEndian.Uint16(data[4:6]))
+	if 40+payloadLen < len(data) {
+		data = data[:40+payloadLen]
+	}
+
+	pkt := &decodedPacket{ipLen: 40 + payloadLen}
+	pkt.srcIP, _ = netip.AddrFromSlice(data[8:24])
+	pkt.dstIP, _ = netip.AddrFromSlice(data[24:40])
+
+	nextHeader := data[6]
+	rest := data[40:]
+	for {
+		switch nextHeader {
+		case 0, 43, 60: // Hop-by-hop, routing, destination options
+			if len(rest) < 8 {
+				return pkt
+			}
+			extLen := (int(rest[1]) + 1) * 8
+			if len(rest) < extLen {
+				return pkt
+			}
+			nextHeader, rest = rest[0], rest[extLen:]
+			continue
+		case 44: // Fragment
+			if len(rest) < 8 {
+				return pkt
+			}
+			fragOffset := binary.BigEndian.Uint16(rest[2:4]) >> 3
+			nextHeader, rest = rest[0], rest[8:]
+			if fragOffset != 0 {
+				pkt.proto = nextHeader
+				return pkt
+			}
+			continue
+		case 51: // Authentication header
+			if len(rest) < 8 {
+				return pkt
+			}
+			extLen := (int(rest[1]) + 2) * 4
+			if len(rest) < extLen {
+				return pkt
+			}
+			nextHeader, rest = rest[0], rest[extLen:]
+			continue
+		}
+		break
+	}
+
+	pkt.proto = nextHeader
+	decodeTransport(pkt, rest)
+	return pkt
+}
+
+// decodeTransport fills in TCP/UDP ports, flags and payload
+func decodeTransport(pkt *decodedPacket, data []byte) {
+	switch pkt.proto {
+	case ipProtoTCP:
+		if len(data) < 20 {
+			return
+		}
+		pkt.srcPort = binary.BigEndian.Uint16(data[0:2])
+		pkt.dstPort = binary.BigEndian.Uint16(data[2:4])
+		pkt.tcpFlags = data[13]
+		dataOffset := int(data[12]>>4) * 4
+		if dataOffset >= 20 && dataOffset <= len(data) {
+			pkt.payload = data[dataOffset:]
+		}
+	case ipProtoUDP:
+		if len(data) < 8 {
+			return
+		}
+		pkt.srcPort = binary.BigEndian.Uint16(data[0:2])
+		pkt.dstPort = binary.BigEndian.Uint16(data[2:4])
+		pkt.payload = data[8:]
+	}
+}
+
+// ipProtoName returns a printable name for an IP protocol number
+func ipProtoName(proto uint8) string {
+	switch proto {
+	case 1:
+		return "ICMP"
+	case ipProtoTCP:
+		return "TCP"
+	case ipProtoUDP:
+		return "UDP"
+	case 47:
+		return "GRE"
+	case 50:
+		return "ESP"
+	case 58:
+		return "ICMPv6"
+	case 132:
+		return "SCTP"
+	}
+	return fmt.Sprintf("PROTO-%d", proto)
+}
+
+// ============================================================================
+// PCAP/PCAPNG Timeline Parser
+// ============================================================================
+
+// PcapParser implements the Parser interface for pcap and pcapng packet captures
+// Packets are summarized into connection start/end, DNS, HTTP request and TLS SNI events
+type PcapParser struct{}
+
+// CanParse checks if this parser can handle the given file
+// Detection is by magic number so rotated tcpdump output (trace.pcap0, trace.pcap.1)
+// and extensionless captures are recognized too
+func (p *PcapParser) CanParse(filePath string) bool {
+	return isCaptureFile(filePath)
+}
+
+// flowKey identifies a bidirectional flow independent of packet direction
+type flowKey struct {
+	proto uint8
+	lo    netip.AddrPort
+	hi    netip.AddrPort
+}
+
+// newFlowKey builds a canonical flow key from a decoded packet
+func newFlowKey(pkt *decodedPacket) flowKey {
+	a := netip.AddrPortFrom(pkt.srcIP, pkt.srcPort)
+	b := netip.AddrPortFrom(pkt.dstIP, pkt.dstPort)
+	if b.Compare(a) < 0 {
+		a, b = b, a
+	}
+	return flowKey{proto: pkt.proto, lo: a, hi: b}
+}
+
+// pcapFlow tracks the state of a single connection
+type pcapFlow struct {
+	orig     netip.AddrPort
+	resp     netip.AddrPort
+	proto    uint8
+	start    time.Time
+	last     time.Time
+	packets  uint64
+	bytes    uint64
+	origFIN  bool
+	respFIN  bool
+	closed   bool
+	closedBy string
+}
+
+// pcapSession holds the state for summarizing a single capture file
+type pcapSession struct {
+	filePath string
+	source   string
+	flows    map[flowKey]*pcapFlow
+	events   []*core.Event
+	netflow  *netflowDecoder // Created on the first NetFlow/IPFIX export packet
+}
+
+// Parse parses a packet capture and returns a slice of events
+func (p *PcapParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := openPacketReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &pcapSession{
+		filePath: filePath,
+		source:   filepath.Base(filePath),
+		flows:    make(map[flowKey]*pcapFlow),
+		// Pre-allocate slice with estimated capacity (avg 2KB of capture per event)
+		events: make([]*core.Event, 0, estimateLineCapacity(filePath, 2048)),
+	}
+
+	packetNum := 0
+	decodedCount := 0
+	for {
+		packet, err := reader.nextPacket()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				// Truncated captures are common in evidence; keep what was read
+				fmt.Printf("Warning: capture %s is truncated after packet %d\n", filePath, packetNum)
+				break
+			}
+			return nil, fmt.Errorf("error reading capture: %w", err)
+		}
+		packetNum++
+
+		pkt := decodePacket(packet.linkType, packet.data)
+		if pkt == nil || !pkt.srcIP.IsValid() || !pkt.dstIP.IsValid() {
+			continue
+		}
+		decodedCount++
+
+		session.trackFlow(pkt, packet.timestamp, packetNum)
+		session.inspectPayload(pkt, packet.timestamp, packetNum)
+	}
+
+	// Flows still open at the end of the capture, in start order for stable output
+	openFlows := make([]*pcapFlow, 0, len(session.flows))
+	for _, flow := range session.flows {
+		if !flow.closed {
+			openFlows = append(openFlows, flow)
+		}
+	}
+	sort.Slice(openFlows, func(i, j int) bool { return openFlows[i].start.Before(openFlows[j].start) })
+	for _, flow := range openFlows {
+		flow.closedBy = "end of capture"
+		session.emitFlowEnd(flow, 0)
+	}
+
+	fmt.Printf("Parsed packet capture: %s (%d packets, %d decoded, found %d events)\n",
+		filePath, packetNum, decodedCount, len(session.events))
+	return session.events, nil
+}
+
+// trackFlow updates connection state and emits start/end events
+func (s *pcapSession) trackFlow(pkt *decodedPacket, timestamp time.Time, packetNum int) {
+	key := newFlowKey(pkt)
+	src := netip.AddrPortFrom(pkt.srcIP, pkt.srcPort)
+	dst := netip.AddrPortFrom(pkt.dstIP, pkt.dstPort)
+	isTCP := pkt.proto == ipProtoTCP
+	isSYN := isTCP && pkt.tcpFlags&tcpFlagSYN != 0 && pkt.tcpFlags&tcpFlagACK == 0
+
+	flow, ok := s.flows[key]
+	if ok {
+		timeout := pcapOtherFlowTimeout
+		if isTCP {
+			timeout = pcapTCPFlowTimeout
+		}
+		switch {
+		case !flow.closed && timestamp.Sub(flow.last) > timeout:
+			flow.closedBy = "inactivity timeout"
+			s.emitFlowEnd(flow, 0)
+			ok = false
+		case flow.closed && (isSYN || timestamp.Sub(flow.last) > timeout):
+			// A new connection reusing the same 5-tuple
+			ok = false
+		}
+	}
+
+	if !ok {
+		flow = &pcapFlow{orig: src, resp: dst, proto: pkt.proto, start: timestamp}
+		// A SYN-ACK as the first packet means the capture missed the SYN
+		if isTCP && pkt.tcpFlags&(tcpFlagSYN|tcpFlagACK) == tcpFlagSYN|tcpFlagACK {
+			flow.orig, flow.resp = dst, src
+		}
+		s.flows[key] = flow
+		s.emitFlowStart(flow, packetNum)
+	}
+
+	flow.last = timestamp
+	flow.packets++
+	flow.bytes += uint64(pkt.ipLen)
+
+	if !isTCP || flow.closed {
+		return
+	}
+
+	if pkt.tcpFlags&tcpFlagRST != 0 {
+		flow.closedBy = "RST"
+		s.emitFlowEnd(flow, packetNum)
+		return
+	}
+	if pkt.tcpFlags&tcpFlagFIN != 0 {
+		if src == flow.orig {
+			flow.origFIN = true
+		} else {
+			flow.respFIN = true
+		}
+		if flow.origFIN && flow.respFIN {
+			flow.closedBy = "FIN"
+			s.emitFlowEnd(flow, packetNum)
+		}
+	}
+}
+
+// emitFlowStart records a connection start event
+func (s *pcapSession) emitFlowStart(flow *pcapFlow, packetNum int) {
+	msg := fmt.Sprintf("%s [%s] connection start",
+		formatConnection(flow.orig.Addr().String(), int(flow.orig.Port()), flow.resp.Addr().String(), int(flow.resp.Port())),
+		ipProtoName(flow.proto))
+
+	event := core.NewEvent(
+		flow.start,
+		s.source,
+		"PcapConnectionStart",
+		packetNum,
+		"",
+		flow.orig.Addr().String(),
+		msg,
+		s.filePath,
+	)
+	event.TimestampDesc = core.TimestampDescStart
+	s.events = append(s.events, event)
+}
+
+// emitFlowEnd records a connection end event and marks the flow closed
+func (s *pcapSession) emitFlowEnd(flow *pcapFlow, packetNum int) {
+	flow.closed = true
+	msg := fmt.Sprintf("%s [%s] connection end duration=%s packets=%d bytes=%d (%s)",
+		formatConnection(flow.orig.Addr().String(), int(flow.orig.Port()), flow.resp.Addr().String(), int(flow.resp.Port())),
+		ipProtoName(flow.proto),
+		flow.last.Sub(flow.start),
+		flow.packets,
+		flow.bytes,
+		flow.closedBy)
+
+	event := core.NewEvent(
+		flow.last,
+		s.source,
+		"PcapConnectionEnd",
+		packetNum,
+		"",
+		flow.orig.Addr().String(),
+		msg,
+		s.filePath,
+	)
+	event.TimestampDesc = core.TimestampDescEnd
+	s.events = append(s.events, event)
+}
+
+// inspectNetflow decodes a NetFlow/IPFIX export datagram using templates scoped to its exporter
+func (s *pcapSession) inspectNetflow(pkt *decodedPacket, packetNum int) {
+	if s.netflow == nil {
+		s.netflow = newNetflowDecoder()
+	}
+
+	records, _, err := s.netflow.decode(pkt.payload, pkt.srcIP.String())
+	if err != nil && len(records) == 0 {
+		return
+	}
+	for i := range records {
+		s.events = append(s.events, records[i].toEvent(s.source, s.filePath, packetNum))
+	}
+}
+
+// inspectPayload extracts DNS, HTTP request and TLS SNI details from a packet payload
+func (s *pcapSession) inspectPayload(pkt *decodedPacket, timestamp time.Time, packetNum int) {
+	if len(pkt.payload) == 0 {
+		return
+	}
+
+	connStr := formatConnection(pkt.srcIP.String(), int(pkt.srcPort), pkt.dstIP.String(), int(pkt.dstPort))
+	addEvent := func(eventType, host, detail string) {
+		s.events = append(s.events, core.NewEvent(
+			timestamp,
+			s.source,
+			eventType,
+			packetNum,
+			"",
+			host,
+			fmt.Sprintf("%s [%s] %s", connStr, ipProtoName(pkt.proto), detail),
+			s.filePath,
+		))
+	}
+
+	// NetFlow/IPFIX export packets: one event per flow record instead of payload details
+	if pkt.proto == ipProtoUDP && netflowPorts[pkt.dstPort] {
+		s.inspectNetflow(pkt, packetNum)
+		return
+	}
+
+	// DNS over UDP/TCP port 53 (and mDNS)
+	if pkt.srcPort == 53 || pkt.dstPort == 53 || pkt.srcPort == 5353 || pkt.dstPort == 5353 {
+		payload := pkt.payload
+		if pkt.proto == ipProtoTCP {
+			// DNS over TCP is prefixed with a 2-byte message length
+			if len(payload) < 2 {
+				return
+			}
+			payload = payload[2:]
+		}
+		if msg := parseDNSMessage(payload); msg != nil {
+			if msg.response {
+				addEvent("PcapDNSResponse", pkt.dstIP.String(), msg.summary())
+			} else {
+				addEvent("PcapDNSQuery", pkt.srcIP.String(), msg.summary())
+			}
+		}
+		return
+	}
+
+	if pkt.proto != ipProtoTCP {
+		return
+	}
+
+	if request := parseHTTPRequest(pkt.payload); request != "" {
+		addEvent("PcapHTTPRequest", pkt.srcIP.String(), request)
+		return
+	}
+
+	if sni := parseTLSClientHelloSNI(pkt.payload); sni != "" {
+		addEvent("PcapTLSClientHello", pkt.srcIP.String(), fmt.Sprintf("SNI=%s", sni))
+	}
+}
+
+// dnsMessage is the subset of a DNS message used for timeline events
+type dnsMessage struct {
+	id       uint16
+	response bool
+	rcode    uint8
+	qname    string
+	qtype    uint16
+	answers  []string
+}
+
+// summary formats the DNS message for an event message
+func (m *dnsMessage) summary() string {
+	var parts []string
+	if m.response {
+		parts = append(parts, "response")
+	} else {
+		parts = append(parts, "query")
+	}
+	parts = append(parts, fmt.Sprintf("query=%s", m.qname))
+	parts = append(parts, fmt.Sprintf("type=%s", dnsTypeName(m.qtype)))
+	if m.response && m.rcode != 0 {
+		parts = append(parts, fmt.Sprintf("rcode=%s", dnsRcodeName(m.rcode)))
+	}
+	if len(m.answers) > 0 {
+		parts = append(parts, fmt.Sprintf("answers=[%s]", strings.Join(m.answers, ",")))
+	}
+	parts = append(parts, fmt.Sprintf("id=0x%04x", m.id))
+	return strings.Join(parts, " ")
+}
+
+// parseDNSMessage parses a DNS query or response; returns nil if malformed
+func parseDNSMessage(data []byte) *dnsMessage {
+	if len(data) < 12 {
+		return nil
+	}
+
+	flags := binary.BigEndian.Uint16(data[2:4])
+	qdCount := int(binary.BigEndian.Uint16(data[4:6]))
+	anCount := int(binary.BigEndian.Uint16(data[6:8]))
+	if qdCount == 0 || qdCount > 16 {
+		return nil
+	}
+
+	msg := &dnsMessage{
+		id:       binary.BigEndian.Uint16(data[0:2]),
+		response: flags&0x8000 != 0,
+		rcode:    uint8(flags & 0x000f),
+	}
+
+	offset := 12
+	for i := 0; i < qdCount; i++ {
+		name, next, ok := readDNSName(data, offset)
+		if !ok || next+4 > len(data) {
+			return nil
+		}
+		if i == 0 {
+			msg.qname = name
+			msg.qtype = binary.BigEndian.Uint16(data[next : next+2])
+		}
+		offset = next + 4
+	}
+
+	for i := 0; i < anCount && i < 32; i++ {
+		_, next, ok := readDNSName(data, offset)
+		if !ok || next+10 > len(data) {
+			break
+		}
+		rrType := binary.BigEndian.Uint16(data[next : next+2])
+		rdLen := int(binary.BigEndian.Uint16(data[next+8 : next+10]))
+		rdStart := next + 10
+		if rdStart+rdLen > len(data) {
+			break
+		}
+		rdata := data[rdStart : rdStart+rdLen]
+
+		switch rrType {
+		case 1, 28: // A, AAAA
+			if addr, ok := netip.AddrFromSlice(rdata); ok {
+				msg.answers = append(msg.answers, addr.String())
+			}
+		case 5, 2, 12: // CNAME, NS, PTR
+			if name, _, ok := readDNSName(data, rdStart); ok {
+				msg.answers = append(msg.answers, name)
+			}
+		}
+		offset = rdStart + rdLen
+	}
+
+	return msg
+}
+
+// readDNSName reads a possibly compressed domain name starting at offset
+// Returns the name, the offset after the name in the original position, and success
+func readDNSName(data []byte, offset int) (string, int, bool) {
+	var labels []string
+	next := -1
+	jumps := 0
+
+	for {
+		if offset >= len(data) {
+			return "", 0, false
+		}
+		length := int(data[offset])
+
+		switch {
+		case length == 0:
+			if next == -1 {
+				next = offset + 1
+			}
+			if len(labels) == 0 {
+				return ".", next, true
+			}
+			return strings.Join(labels, "."), next, true
+		case length&0xc0 == 0xc0:
+			if offset+1 >= len(data) {
+				return "", 0, false
+			}
+			if next == -1 {
+				next = offset + 2
+			}
+			jumps++
+			if jumps > 32 {
+				return "", 0, false
+			}
+			offset = int(binary.BigEndian.Uint16(data[offset:offset+2]) & 0x3fff)
+		case length > 63:
+			return "", 0, false
+		default:
+			if offset+1+length > len(data) {
+				return "", 0, false
+			}
+			labels = append(labels, string(data[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+}
+
+// dnsTypeName returns the mnemonic for common DNS record types
+func dnsTypeName(qtype uint16) string {
+	names := map[uint16]string{
+		1: "A", 2: "NS", 5: "CNAME", 6: "SOA", 12: "PTR", 15: "MX", 16: "TXT",
+		28: "AAAA", 33: "SRV", 35: "NAPTR", 43: "DS", 48: "DNSKEY", 64: "SVCB",
+		65: "HTTPS", 252: "AXFR", 255: "ANY",
+	}
+	if name, ok := names[qtype]; ok {
+		return name
+	}
+	return fmt.Sprintf("TYPE%d", qtype)
+}
+
+// dnsRcodeName returns the mnemonic for DNS response codes
+func dnsRcodeName(rcode uint8) string {
+	names := []string{"NOERROR", "FORMERR", "SERVFAIL", "NXDOMAIN", "NOTIMP", "REFUSED"}
+	if int(rcode) < len(names) {
+		return names[rcode]
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// httpMethods are request methods recognized at the start of a TCP payload
+var httpMethods = []string{"GET ", "POST ", "HEAD ", "PUT ", "DELETE ", "OPTIONS ", "CONNECT ", "PATCH ", "TRACE ", "PROPFIND "}
+
+// parseHTTPRequest returns a summary of an HTTP/1.x request line and Host/User-Agent headers
+func parseHTTPRequest(payload []byte) string {
+	isRequest := false
+	for _, method := range httpMethods {
+		if bytes.HasPrefix(payload, []byte(method)) {
+			isRequest = true
+			break
+		}
+	}
+	if !isRequest {
+		return ""
+	}
+
+	// Limit header inspection to the first segment
+	if len(payload) > 8192 {
+		payload = payload[:8192]
+	}
+	lines := strings.Split(string(payload), "\r\n")
+	requestLine := strings.Fields(lines[0])
+	if len(requestLine) != 3 || !strings.HasPrefix(requestLine[2], "HTTP/") {
+		return ""
+	}
+	method, uri := requestLine[0], requestLine[1]
+
+	host := ""
+	userAgent := ""
+	for _, line := range lines[1:] {
+		if line == "" {
+			break
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "host":
+			host = strings.TrimSpace(value)
+		case "user-agent":
+			userAgent = strings.TrimSpace(value)
+		}
+	}
+
+	msg := method
+	if host != "" && strings.HasPrefix(uri, "/") {
+		msg += fmt.Sprintf(" http://%s%s", host, uri)
+	} else {
+		msg += " " + uri
+	}
+	if userAgent != "" {
+		msg += fmt.Sprintf(" UA=%s", userAgent)
+	}
+	return msg
+}
+
+// parseTLSClientHelloSNI extracts the server_name extension from a TLS ClientHello
+func parseTLSClientHelloSNI(payload []byte) string {
+	// TLS record: type(1)=handshake, version(2), length(2); handshake: type(1)=ClientHello, length(3)
+	if len(payload) < 9 || payload[0] != 0x16 || payload[1] != 0x03 || payload[5] != 0x01 {
+		return ""
+	}
+
+	data := payload[9:]
+	// client_version(2) + random(32)
+	if len(data) < 34 {
+		return ""
+	}
+	data = data[34:]
+
+	// session_id
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return ""
+	}
+	data = data[1+int(data[0]):]
+
+	// cipher_suites
+	if len(data) < 2 {
+		return ""
+	}
+	cipherLen := int(binary.BigEndian.Uint16(data[0:2]))
+	if len(data) < 2+cipherLen {
+		return ""
+	}
+	data = data[2+cipherLen:]
+
+	// compression_methods
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return ""
+	}
+	data = data[1+int(data[0]):]
+
+	// extensions
+	if len(data) < 2 {
+		return ""
+	}
+	extTotal := int(binary.BigEndian.Uint16(data[0:2]))
+	data = data[2:]
+	if extTotal < len(data) {
+		data = data[:extTotal]
+	}
+
+	for len(data) >= 4 {
+		extType := binary.BigEndian.Uint16(data[0:2])
+		extLen := int(binary.BigEndian.Uint16(data[2:4]))
+		if len(data) < 4+extLen {
+			return ""
+		}
+		ext := data[4 : 4+extLen]
+		data = data[4+extLen:]
+
+		if extType != 0 {
+			continue
+		}
+		// server_name_list: length(2), then entries of type(1) length(2) name
+		if len(ext) < 5 || ext[2] != 0 {
+			return ""
+		}
+		nameLen := int(binary.BigEndian.Uint16(ext[3:5]))
+		if len(ext) < 5+nameLen {
+			return ""
+		}
+		return string(ext[5 : 5+nameLen])
+	}
+
+	return ""
+}
@@ -0,0 +1,119 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildTestIPv4Frame builds an Ethernet/IPv4 frame around a transport segment
+func buildTestIPv4Frame(proto byte, src, dst [4]byte, segment []byte) []byte {
+	frame := make([]byte, 14, 14+20+len(segment))
+	binary.BigEndian.PutUint16(frame[12:14], 0x0800)
+
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(segment)))
+	ip[8] = 64
+	ip[9] = proto
+	copy(ip[12:16], src[:])
+	copy(ip[16:20], dst[:])
+
+	frame = append(frame, ip...)
+	return append(frame, segment...)
+}
+
+// buildTestTCP builds a TCP segment with the given flags and payload
+func buildTestTCP(srcPort, dstPort uint16, flags byte, payload []byte) []byte {
+	seg := make([]byte, 20, 20+len(payload))
+	binary.BigEndian.PutUint16(seg[0:2], srcPort)
+	binary.BigEndian.PutUint16(seg[2:4], dstPort)
+	seg[12] = 5 << 4
+	seg[13] = flags
+	return append(seg, payload...)
+}
+
+// buildTestUDP builds a UDP datagram
+func buildTestUDP(srcPort, dstPort uint16, payload []byte) []byte {
+	seg := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint16(seg[0:2], srcPort)
+	binary.BigEndian.PutUint16(seg[2:4], dstPort)
+	binary.BigEndian.PutUint16(seg[4:6], uint16(8+len(payload)))
+	return append(seg, payload...)
+}
+
+func TestPcapParserSummarizesFlows(t *testing.T) {
+	client := [4]byte{10, 0, 0, 5}
+	server := [4]byte{192, 0, 2, 10}
+	resolver := [4]byte{10, 0, 0, 1}
+
+	dnsQuery := []byte{0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	dnsQuery = append(dnsQuery, 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0, 0x00, 0x01, 0x00, 0x01)
+
+	httpRequest := []byte("GET /index.html HTTP/1.1\r\nHost: example.com\r\nUser-Agent: curl/8.0\r\n\r\n")
+
+	frames := [][]byte{
+		buildTestIPv4Frame(ipProtoUDP, client, resolver, buildTestUDP(53000, 53, dnsQuery)),
+		buildTestIPv4Frame(ipProtoTCP, client, server, buildTestTCP(51234, 80, tcpFlagSYN, nil)),
+		buildTestIPv4Frame(ipProtoTCP, server, client, buildTestTCP(80, 51234, tcpFlagSYN|tcpFlagACK, nil)),
+		buildTestIPv4Frame(ipProtoTCP, client, server, buildTestTCP(51234, 80, tcpFlagACK, httpRequest)),
+		buildTestIPv4Frame(ipProtoTCP, client, server, buildTestTCP(51234, 80, tcpFlagFIN|tcpFlagACK, nil)),
+		buildTestIPv4Frame(ipProtoTCP, server, client, buildTestTCP(80, 51234, tcpFlagFIN|tcpFlagACK, nil)),
+	}
+
+	var buf bytes.Buffer
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:4], pcapMagicMicros)
+	binary.LittleEndian.PutUint16(header[4:6], 2)
+	binary.LittleEndian.PutUint16(header[6:8], 4)
+	binary.LittleEndian.PutUint32(header[16:20], 65535)
+	binary.LittleEndian.PutUint32(header[20:24], linkTypeEthernet)
+	buf.Write(header)
+	for i, frame := range frames {
+		record := make([]byte, 16)
+		binary.LittleEndian.PutUint32(record[0:4], uint32(1682091045+i))
+		binary.LittleEndian.PutUint32(record[8:12], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(record[12:16], uint32(len(frame)))
+		buf.Write(record)
+		buf.Write(frame)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "capture.pcap")
+	if err := os.WriteFile(filePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write test capture: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*PcapParser); !ok {
+		t.Fatalf("Expected PcapParser, got %T", parser)
+	}
+
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	want := map[string]string{
+		"PcapDNSQuery":        "query=example.com type=A",
+		"PcapHTTPRequest":     "GET http://example.com/index.html UA=curl/8.0",
+		"PcapConnectionStart": "10.0.0.5:51234 -> 192.0.2.10:80 [TCP] connection start",
+		"PcapConnectionEnd":   "10.0.0.5:51234 -> 192.0.2.10:80 [TCP] connection end duration=4s packets=5",
+	}
+	found := make(map[string]bool)
+	for _, event := range events {
+		if substr, ok := want[event.EventType]; ok && strings.Contains(event.Message, substr) {
+			found[event.EventType] = true
+		}
+	}
+	for eventType, substr := range want {
+		if !found[eventType] {
+			t.Errorf("Missing %s event containing %q", eventType, substr)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -75,7 +74,7 @@ func (p *PowerShellTranscriptParser) CanParse(filePath string) bool {
 
 // Parse parses a PowerShell transcript file and returns a slice of events
 func (p *PowerShellTranscriptParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -253,6 +252,7 @@ func (p *PowerShellTranscriptParser) Parse(filePath string) ([]*core.Event, erro
 			fmt.Sprintf("PowerShell session started. Host: %s, RunAs: %s", hostApplication, runAsUser),
 			filePath,
 		)
+		sessionEvent.TimestampDesc = core.TimestampDescStart
 		// Insert at the beginning
 		events = append([]*core.Event{sessionEvent}, events...)
 	}
@@ -269,6 +269,7 @@ func (p *PowerShellTranscriptParser) Parse(filePath string) ([]*core.Event, erro
 			fmt.Sprintf("PowerShell session ended. Duration: %v", endTime.Sub(startTime)),
 			filePath,
 		)
+		sessionEndEvent.TimestampDesc = core.TimestampDescEnd
 		events = append(events, sessionEndEvent)
 	}
 
@@ -329,12 +330,12 @@ func parseTranscriptTimestamp(timeStr string) time.Time {
 		return time.Time{}
 	}
 
-	timestamp, err := time.Parse("20060102150405", timeStr)
+	timestamp, err := parseLogTime("20060102150405", timeStr)
 	if err != nil {
 		return time.Time{}
 	}
 
-	return timestamp.UTC()
+	return timestamp
 }
 
 // CanParse checks if this parser can handle the given file as a PowerShell Script Block log
@@ -370,7 +371,7 @@ func (p *PowerShellScriptBlockParser) CanParse(filePath string) bool {
 
 // Parse parses a PowerShell Script Block log file and returns a slice of events
 func (p *PowerShellScriptBlockParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -407,14 +408,14 @@ func (p *PowerShellScriptBlockParser) Parse(filePath string) ([]*core.Event, err
 
 		// Try to extract timestamp
 		if matches := scriptBlockTimestamp.FindStringSubmatch(lineForRegex); matches != nil {
-			ts, err := time.Parse(time.RFC3339, matches[1])
+			ts, err := parseLogTime(time.RFC3339, matches[1])
 			if err == nil {
-				currentTimestamp = ts.UTC()
+				currentTimestamp = ts
 			}
 		} else if matches := scriptBlockTimestamp2.FindStringSubmatch(lineForRegex); matches != nil {
-			ts, err := time.Parse("2006-01-02 15:04:05", matches[1])
+			ts, err := parseLogTime("2006-01-02 15:04:05", matches[1])
 			if err == nil {
-				currentTimestamp = ts.UTC()
+				currentTimestamp = ts
 			}
 		}
 
@@ -575,11 +576,6 @@ func (p *PowerShellScriptBlockParser) createScriptBlockEvent(
 	}
 	msgBuilder.WriteString(fmt.Sprintf("Script: %s", content))
 
-	// Use current time if no timestamp was found
-	if timestamp.IsZero() {
-		timestamp = time.Now().UTC()
-	}
-
 	return core.NewEvent(
 		timestamp,
 		source,
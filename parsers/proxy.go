@@ -0,0 +1,253 @@
+package parsers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for proxy logs
+var (
+	// Squid native access.log: time elapsed client code/status bytes method URL user hierarchy/peer type
+	// Example: 1682091045.123    245 10.0.0.5 TCP_MISS/200 1520 GET http://example.com/ - HIER_DIRECT/93.184.216.34 text/html
+	squidAccessPattern = regexp.MustCompile(`^(\d{9,10}\.\d{3})\s+(-?\d+)\s+(\S+)\s+([A-Z_]+)/(\d{3})\s+(\d+)\s+(\S+)\s+(\S+)\s+(\S+)\s+(\S+)(?:\s+(\S+))?`)
+)
+
+// ============================================================================
+// Squid Access Log Parser
+// ============================================================================
+
+// SquidAccessParser implements the Parser interface for Squid native-format access.log files
+type SquidAccessParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *SquidAccessParser) CanParse(filePath string) bool {
+	baseName := strings.ToLower(filepath.Base(filePath))
+	count := countHeaderMatches(filePath, squidAccessPattern)
+	if strings.Contains(baseName, "access") || strings.Contains(baseName, "squid") {
+		return count > 0
+	}
+	return count >= 3
+}
+
+// Parse parses a Squid access log and returns a slice of events
+func (p *SquidAccessParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
+	source := filepath.Base(filePath)
+
+	err := scanLogRecords(filePath, func(string) bool { return true }, func(record *logRecord) {
+		matches := squidAccessPattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			events = append(events, core.NewEvent(time.Time{}, source, "SquidAccessRaw", record.lineNum, "", "", record.line, filePath))
+			return
+		}
+
+		var timestamp time.Time
+		if secs, err := strconv.ParseFloat(matches[1], 64); err == nil {
+			timestamp = time.UnixMilli(int64(secs * 1000)).UTC()
+		}
+		elapsed, client, cacheResult, status, size := matches[2], matches[3], matches[4], matches[5], matches[6]
+		method, url, user, hierarchy, contentType := matches[7], matches[8], matches[9], matches[10], matches[11]
+		if user == "-" {
+			user = ""
+		}
+
+		msg := fmt.Sprintf("%s %s (%s/%s, %s bytes, %s ms) via %s", method, url, cacheResult, status, size, elapsed, hierarchy)
+		if contentType != "" && contentType != "-" {
+			msg += " " + contentType
+		}
+
+		event := core.NewEvent(timestamp, source, "SquidAccess", record.lineNum, user, client, msg, filePath)
+		if _, err := strconv.Atoi(status); err == nil {
+			event.Tags = append(event.Tags, "status:"+status)
+		}
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed Squid access log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// Web Proxy CSV Parser (Zscaler NSS and similar feeds)
+// ============================================================================
+
+// WebProxyCSVParser implements the Parser interface for CSV web proxy feeds with a header row
+// such as Zscaler NSS web logs and cloud proxy exports. Headerless feeds fall through to the generic CSV parser.
+type WebProxyCSVParser struct{}
+
+// webProxyColumns maps normalized header names to proxy fields
+var webProxyColumns = map[string][]string{
+	"time":      {"datetime", "time", "timestamp", "eventtime", "logtime", "epochtime", "date"},
+	"user":      {"user", "login", "username", "csusername", "userid"},
+	"client":    {"cip", "clientip", "csip", "srcip", "sourceip", "clientipaddress"},
+	"server":    {"sip", "serverip", "dstip", "destinationip", "serveripaddress"},
+	"url":       {"url", "eurl", "requesturl", "csuri", "uri"},
+	"method":    {"reqmethod", "requestmethod", "method", "csmethod"},
+	"status":    {"respcode", "responsecode", "statuscode", "status", "scstatus"},
+	"action":    {"action", "policyaction", "saction", "disposition"},
+	"category":  {"urlcat", "urlcategory", "category", "cscategories"},
+	"threat":    {"threatname", "threat", "malwarename", "virusname"},
+	"useragent": {"ua", "useragent", "csuseragent"},
+}
+
+// normalizeProxyHeader lowercases a header name and drops separators ("Client IP" -> "clientip")
+func normalizeProxyHeader(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// mapWebProxyHeader returns the column index of each proxy field present in the header
+func mapWebProxyHeader(header []string) map[string]int {
+	normalized := make(map[string]int, len(header))
+	for i, name := range header {
+		key := normalizeProxyHeader(name)
+		if _, exists := normalized[key]; !exists {
+			normalized[key] = i
+		}
+	}
+	columns := make(map[string]int)
+	for field, aliases := range webProxyColumns {
+		for _, alias := range aliases {
+			if i, ok := normalized[alias]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// isWebProxyHeader reports whether the mapped header looks like a web proxy feed
+func isWebProxyHeader(columns map[string]int) bool {
+	_, hasTime := columns["time"]
+	_, hasURL := columns["url"]
+	_, hasAction := columns["action"]
+	_, hasClient := columns["client"]
+	_, hasCategory := columns["category"]
+	return hasTime && hasURL && (hasAction || hasCategory) && hasClient
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *WebProxyCSVParser) CanParse(filePath string) bool {
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	for _, line := range lines {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "\ufeff")
+		if line == "" {
+			continue
+		}
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.LazyQuotes = true
+		header, err := reader.Read()
+		if err != nil || len(header) < 4 {
+			return false
+		}
+		return isWebProxyHeader(mapWebProxyHeader(header))
+	}
+	return false
+}
+
+// Parse parses a web proxy CSV feed and returns a slice of events
+func (p *WebProxyCSVParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	reader := csv.NewReader(strings.NewReader(string(stripBOM(content))))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := mapWebProxyHeader(header)
+
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 300))
+	source := filepath.Base(filePath)
+	timeFormat := ""
+	lineNum := 1
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNum++
+		if err != nil {
+			fmt.Printf("Warning: skipping malformed row %d in %s: %v\n", lineNum, filePath, err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				if v := strings.TrimSpace(row[i]); v != "-" && v != "None" {
+					return v
+				}
+			}
+			return ""
+		}
+
+		var timestamp time.Time
+		if value := field("time"); value != "" {
+			if t, err := parseLogTime(time.ANSIC, strings.Join(strings.Fields(value), " ")); err == nil {
+				timestamp = t
+			} else {
+				timestamp, timeFormat = parseTimestamp(value, timeFormat)
+			}
+		}
+
+		var msgParts []string
+		for _, name := range []string{"action", "method", "url"} {
+			if v := field(name); v != "" {
+				msgParts = append(msgParts, v)
+			}
+		}
+		if status := field("status"); status != "" {
+			msgParts = append(msgParts, fmt.Sprintf("(status %s)", status))
+		}
+		for _, name := range []string{"category", "threat", "server"} {
+			if v := field(name); v != "" {
+				msgParts = append(msgParts, fmt.Sprintf("%s=%s", name, v))
+			}
+		}
+		if ua := field("useragent"); ua != "" {
+			msgParts = append(msgParts, fmt.Sprintf("ua=%q", ua))
+		}
+
+		event := core.NewEvent(timestamp, source, "WebProxy", lineNum, field("user"), field("client"), strings.Join(msgParts, " "), filePath)
+		if _, err := strconv.Atoi(field("status")); err == nil {
+			event.Tags = append(event.Tags, "status:"+field("status"))
+		}
+		if field("threat") != "" {
+			event.Score = 0.5
+		}
+		events = append(events, event)
+	}
+
+	fmt.Printf("Parsed web proxy CSV: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSquidAccessParser(t *testing.T) {
+	content := strings.Join([]string{
+		"1682091045.123    245 10.0.0.5 TCP_MISS/200 1520 GET http://example.com/index.html - HIER_DIRECT/93.184.216.34 text/html",
+		"1682091046.500      0 10.0.0.9 TCP_DENIED/403 3900 CONNECT evil.example.net:443 bob HIER_NONE/- text/html",
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "access.log")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*SquidAccessParser); !ok {
+		t.Fatalf("Expected SquidAccessParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	first := events[0]
+	if first.Message != "GET http://example.com/index.html (TCP_MISS/200, 1520 bytes, 245 ms) via HIER_DIRECT/93.184.216.34 text/html" ||
+		first.Host != "10.0.0.5" || first.EventID != 1 || !slices.Contains(first.Tags, "status:200") {
+		t.Errorf("Unexpected event: host=%q id=%d tags=%v %q", first.Host, first.EventID, first.Tags, first.Message)
+	}
+	if want := time.Date(2023, 4, 21, 15, 30, 45, 123000000, time.UTC); !first.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %s, got %s", want, first.Timestamp)
+	}
+	if events[1].User != "bob" || events[1].EventID != 2 || !slices.Contains(events[1].Tags, "status:403") {
+		t.Errorf("Unexpected denied event: user=%q id=%d tags=%v", events[1].User, events[1].EventID, events[1].Tags)
+	}
+}
+
+func TestWebProxyCSVParser(t *testing.T) {
+	content := strings.Join([]string{
+		`"datetime","user","url","action","urlcat","cip","sip","reqmethod","respcode","ua","threatname"`,
+		`"Fri Apr 21 15:30:45 2023","jdoe@corp.com","malware.example.net/payload.exe","Blocked","Malware Sites","10.0.0.5","203.0.113.80","GET","403","curl/8.0","Win32.Trojan.Agent"`,
+		`"Fri Apr 21 15:31:00 2023","jdoe@corp.com","www.example.com/","Allowed","General Browsing","10.0.0.5","93.184.216.34","GET","200","Mozilla/5.0","None"`,
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "nss_web.csv")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write feed: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*WebProxyCSVParser); !ok {
+		t.Fatalf("Expected WebProxyCSVParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	event := events[0]
+	want := `Blocked GET malware.example.net/payload.exe (status 403) category=Malware Sites threat=Win32.Trojan.Agent server=203.0.113.80 ua="curl/8.0"`
+	if event.EventType != "WebProxy" || event.Message != want || event.User != "jdoe@corp.com" || event.Host != "10.0.0.5" || event.Score != 0.5 ||
+		event.EventID != 2 || !slices.Contains(event.Tags, "status:403") {
+		t.Errorf("Unexpected event: %s id=%d user=%q host=%q score=%v tags=%v %q", event.EventType, event.EventID, event.User, event.Host, event.Score, event.Tags, event.Message)
+	}
+	if wantTime := time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC); !event.Timestamp.Equal(wantTime) {
+		t.Errorf("Expected timestamp %s, got %s", wantTime, event.Timestamp)
+	}
+	if events[1].Score != 0 {
+		t.Errorf("Expected no score for a clean request, got %v", events[1].Score)
+	}
+}
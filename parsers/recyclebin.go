@@ -0,0 +1,193 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"LogZero/core"
+)
+
+// recycleBinSIDPattern extracts the owner SID from $Recycle.Bin\<SID>\ and RECYCLER\<SID>\ folders
+var recycleBinSIDPattern = regexp.MustCompile(`(?i)(?:\$recycle\.bin|recycler)[/\\](S-1-[\d-]+)[/\\]`)
+
+const (
+	recycleBinV1Size      = 544 // Vista/7/8.1: fixed 260-character path
+	recycleBinV2HeaderLen = 28  // Windows 10+: path length prefix then variable-length path
+	info2HeaderLen        = 20
+	info2RecordSizeXP     = 800 // ANSI path, index, drive, FILETIME, size, Unicode path
+	info2RecordSize9x     = 280 // ANSI path only
+)
+
+// ============================================================================
+// Windows Recycle Bin ($I) Parser
+// ============================================================================
+
+// RecycleBinParser implements the Parser interface for Vista+ Recycle Bin $I metadata files
+// ($Recycle.Bin\<SID>\$I<id>.<ext>, paired with the $R<id>.<ext> content file)
+type RecycleBinParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *RecycleBinParser) CanParse(filePath string) bool {
+	if !strings.HasPrefix(strings.ToUpper(filepath.Base(filePath)), "$I") {
+		return false
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 8)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return false
+	}
+	version := binary.LittleEndian.Uint64(header)
+	return version == 1 || version == 2
+}
+
+// Parse parses a $I file and returns its deletion event
+func (p *RecycleBinParser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recycle bin file: %w", err)
+	}
+	if len(data) < 24 {
+		return nil, fmt.Errorf("recycle bin file too short: %s", filePath)
+	}
+
+	le := binary.LittleEndian
+	version := le.Uint64(data[0:8])
+	size := le.Uint64(data[8:16])
+	deleted := filetimeToTime(le.Uint64(data[16:24]))
+
+	var originalPath string
+	switch version {
+	case 1:
+		if len(data) < recycleBinV1Size {
+			return nil, fmt.Errorf("truncated $I v1 file: %s", filePath)
+		}
+		originalPath = utf16LEString(data[24:recycleBinV1Size])
+	case 2:
+		if len(data) < recycleBinV2HeaderLen {
+			return nil, fmt.Errorf("truncated $I v2 file: %s", filePath)
+		}
+		pathLen := int(le.Uint32(data[24:28])) * 2
+		end := min(len(data), recycleBinV2HeaderLen+pathLen)
+		originalPath = utf16LEString(data[recycleBinV2HeaderLen:end])
+	default:
+		return nil, fmt.Errorf("unsupported $I version %d: %s", version, filePath)
+	}
+
+	baseName := filepath.Base(filePath)
+	recycledName := "$R" + baseName[2:]
+	msg := fmt.Sprintf("Deleted to Recycle Bin: %s (size=%d bytes, recycled as %s)", originalPath, size, recycledName)
+
+	event := core.NewEvent(deleted, baseName, "RecycleBinDelete", 0, recycleBinOwner(filePath), "", msg, filePath)
+	if deleted.IsZero() {
+		if info, err := os.Stat(filePath); err == nil {
+			event.Timestamp = info.ModTime().UTC()
+			markFileMtime(event)
+		}
+	}
+
+	fmt.Printf("Parsed Recycle Bin file: %s (found 1 events)\n", filePath)
+	return []*core.Event{event}, nil
+}
+
+// recycleBinOwner returns the SID of the Recycle Bin folder holding the file
+func recycleBinOwner(filePath string) string {
+	if m := recycleBinSIDPattern.FindStringSubmatch(filePath); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ============================================================================
+// Windows Recycle Bin (INFO2) Parser
+// ============================================================================
+
+// RecycleBinINFO2Parser implements the Parser interface for the Windows 95-XP RECYCLER\<SID>\INFO2 index
+// Deleted files are stored alongside as Dc<index>.<ext>
+type RecycleBinINFO2Parser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *RecycleBinINFO2Parser) CanParse(filePath string) bool {
+	if !strings.EqualFold(filepath.Base(filePath), "INFO2") {
+		return false
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, info2HeaderLen)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return false
+	}
+	recordSize := binary.LittleEndian.Uint32(header[12:16])
+	return recordSize == info2RecordSizeXP || recordSize == info2RecordSize9x
+}
+
+// Parse parses an INFO2 file and returns a deletion event per record
+func (p *RecycleBinINFO2Parser) Parse(filePath string) ([]*core.Event, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read INFO2 file: %w", err)
+	}
+	if len(data) < info2HeaderLen {
+		return nil, fmt.Errorf("INFO2 file too short: %s", filePath)
+	}
+
+	le := binary.LittleEndian
+	recordSize := int(le.Uint32(data[12:16]))
+	if recordSize != info2RecordSizeXP && recordSize != info2RecordSize9x {
+		return nil, fmt.Errorf("unsupported INFO2 record size %d: %s", recordSize, filePath)
+	}
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	owner := recycleBinOwner(filePath)
+
+	for offset := info2HeaderLen; offset+recordSize <= len(data); offset += recordSize {
+		record := data[offset : offset+recordSize]
+		ansiPath := record[:260]
+		index := le.Uint32(record[260:264])
+		drive := le.Uint32(record[264:268])
+		deleted := filetimeToTime(le.Uint64(record[268:276]))
+		size := le.Uint32(record[276:280])
+
+		originalPath := ""
+		if recordSize == info2RecordSizeXP {
+			originalPath = utf16LEString(record[280:800])
+		}
+		if originalPath == "" {
+			// Restored entries zero the drive letter, so skip it when looking for the terminator
+			if ansiPath[0] == 0 {
+				ansiPath = ansiPath[1:]
+			}
+			if i := bytes.IndexByte(ansiPath, 0); i >= 0 {
+				ansiPath = ansiPath[:i]
+			}
+			originalPath = string(ansiPath)
+		}
+
+		// Restored or purged entries have the first byte of the ANSI path zeroed
+		status := ""
+		if record[0] == 0 {
+			status = " [restored or purged]"
+		}
+
+		recycledName := fmt.Sprintf("D%c%d%s", 'a'+rune(drive%26), index, filepath.Ext(originalPath))
+		msg := fmt.Sprintf("Deleted to Recycle Bin: %s (size=%d bytes, recycled as %s)%s", originalPath, size, recycledName, status)
+		events = append(events, core.NewEvent(deleted, source, "RecycleBinDelete", int(index), owner, "", msg, filePath))
+	}
+
+	fmt.Printf("Parsed Recycle Bin INFO2: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
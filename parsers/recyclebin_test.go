@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecycleBinParsers(t *testing.T) {
+	le := binary.LittleEndian
+	deleted := time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC)
+	filetime := uint64(deleted.UnixNano()/100) + windowsFiletimeEpochDiff
+	dir := t.TempDir()
+
+	// Windows 10 $I v2: version, size, FILETIME, path length in characters, path
+	originalPath := `C:\Users\jdoe\Documents\passwords.xlsx`
+	v2 := le.AppendUint64(nil, 2)
+	v2 = le.AppendUint64(v2, 18432)
+	v2 = le.AppendUint64(v2, filetime)
+	v2 = le.AppendUint32(v2, uint32(len(originalPath)+1))
+	v2 = append(append(v2, utf16LEBytes(originalPath)...), 0, 0)
+	v2Path := filepath.Join(dir, "$Recycle.Bin", "S-1-5-21-1001-2002-3003-1001", "$IQ8Z3K1.xlsx")
+
+	// Windows 7 $I v1: fixed 260-character path field
+	v1 := le.AppendUint64(nil, 1)
+	v1 = le.AppendUint64(v1, 2048)
+	v1 = le.AppendUint64(v1, filetime)
+	v1 = append(v1, utf16LEBytes(`D:\tools\mimikatz.exe`)...)
+	v1 = append(v1, make([]byte, recycleBinV1Size-len(v1))...)
+	v1Path := filepath.Join(dir, "$Recycle.Bin", "S-1-5-21-1001-2002-3003-1001", "$IA1B2C3.exe")
+
+	// XP INFO2: 20-byte header then one 800-byte record
+	info2 := make([]byte, info2HeaderLen)
+	le.PutUint32(info2[0:], 5)
+	le.PutUint32(info2[12:], info2RecordSizeXP)
+	record := make([]byte, info2RecordSizeXP)
+	copy(record, `C:\Documents and Settings\jdoe\Desktop\notes.txt`)
+	le.PutUint32(record[260:], 3)
+	le.PutUint32(record[264:], 2)
+	le.PutUint64(record[268:], filetime)
+	le.PutUint32(record[276:], 4096)
+	copy(record[280:], utf16LEBytes(`C:\Documents and Settings\jdoe\Desktop\notes.txt`))
+	info2 = append(info2, record...)
+	info2Path := filepath.Join(dir, "RECYCLER", "S-1-5-21-1001-2002-3003-1003", "INFO2")
+
+	for path, data := range map[string][]byte{v2Path: v2, v1Path: v1, info2Path: info2} {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	tests := []struct {
+		path     string
+		wantUser string
+		wantMsg  string
+	}{
+		{v2Path, "S-1-5-21-1001-2002-3003-1001", `Deleted to Recycle Bin: C:\Users\jdoe\Documents\passwords.xlsx (size=18432 bytes, recycled as $RQ8Z3K1.xlsx)`},
+		{v1Path, "S-1-5-21-1001-2002-3003-1001", `Deleted to Recycle Bin: D:\tools\mimikatz.exe (size=2048 bytes, recycled as $RA1B2C3.exe)`},
+		{info2Path, "S-1-5-21-1001-2002-3003-1003", `Deleted to Recycle Bin: C:\Documents and Settings\jdoe\Desktop\notes.txt (size=4096 bytes, recycled as Dc3.txt)`},
+	}
+	for _, tt := range tests {
+		parser, err := GetParserForFile(tt.path)
+		if err != nil {
+			t.Fatalf("Failed to get parser for %s: %v", tt.path, err)
+		}
+		events, err := parser.Parse(tt.path)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.path, err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("%s: expected 1 event, got %d", tt.path, len(events))
+		}
+		event := events[0]
+		if event.EventType != "RecycleBinDelete" || event.Message != tt.wantMsg {
+			t.Errorf("%s: expected %q, got %s %q", tt.path, tt.wantMsg, event.EventType, event.Message)
+		}
+		if event.User != tt.wantUser {
+			t.Errorf("%s: expected user %s, got %q", tt.path, tt.wantUser, event.User)
+		}
+		if !event.Timestamp.Equal(deleted) {
+			t.Errorf("%s: expected timestamp %s, got %s", tt.path, deleted, event.Timestamp)
+		}
+	}
+}
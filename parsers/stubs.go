@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"bytes"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -39,6 +41,33 @@ func buildSQLiteConnectionString(dbPath string, readOnly bool) string {
 	return fmt.Sprintf("file:%s?mode=%s", encodedPath, mode)
 }
 
+// sqliteMagic is the 16-byte header at the start of every SQLite 3 database
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+// isSQLiteDatabase checks the file header for the SQLite 3 magic string
+func isSQLiteDatabase(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, len(sqliteMagic))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, sqliteMagic)
+}
+
+// Browser profile directory name patterns
+var (
+	// Chromium: Default, Profile 1, Guest Profile; Opera keeps its profile in "Opera Stable"
+	chromiumProfilePattern = regexp.MustCompile(`^(?:Default|Profile \d+|Guest Profile|System Profile|Opera(?: GX)? (?:Stable|Beta|Developer))$`)
+
+	// Firefox: <8 random chars>.<profile name> (e.g., abcd1234.default-release)
+	firefoxProfilePattern = regexp.MustCompile(`^[a-z0-9]{8}\.[\w-]+$`)
+)
+
 // Browser type constants
 type browserType int
 
@@ -49,6 +78,19 @@ const (
 	browserSafari              // Apple Safari
 )
 
+// Browser artifact constants identify which database within a profile is being parsed
+type browserArtifact int
+
+const (
+	artifactUnknown     browserArtifact = iota
+	artifactHistory                     // Chromium History, Firefox places.sqlite, Safari History.db
+	artifactCookies                     // Chromium Cookies, Firefox cookies.sqlite
+	artifactWebData                     // Chromium Web Data (autofill)
+	artifactLoginData                   // Chromium Login Data (metadata only)
+	artifactFormHistory                 // Firefox formhistory.sqlite
+	artifactDownloads                   // Legacy Firefox downloads.sqlite
+)
+
 // Time conversion constants
 const (
 	// WebKit timestamp: microseconds since 1601-01-01
@@ -109,35 +151,95 @@ func (p *ShellbagsParser) Parse(filePath string) ([]*core.Event, error) {
 	return nil, ErrShellbagsNotSupported
 }
 
-// BrowserHistoryParser implements the Parser interface for browser history SQLite databases
-// Supports Chrome/Edge (Chromium-based), Firefox, and Safari
+// BrowserHistoryParser implements the Parser interface for browser SQLite databases
+// Supports Chrome/Edge (Chromium-based), Firefox, and Safari history, downloads,
+// cookies, autofill/form history and saved login metadata
 type BrowserHistoryParser struct{}
 
 // CanParse checks if this parser can handle the given file
-// Detection is based on filename and path patterns:
-// - Chrome/Edge: filename "History" (no extension) in path containing "Chrome", "Edge", or "Chromium"
-// - Firefox: filename "places.sqlite" in path containing "Firefox" or "Mozilla"
-// - Safari: filename "History.db" in path containing "Safari"
+// Detection opens the SQLite database read-only and inspects its schema, so copied
+// evidence is recognized regardless of folder names:
+// - Chromium (Chrome, Edge, Brave, Opera, Vivaldi, ...): urls+visits, cookies.host_key, autofill, logins
+// - Firefox: moz_places+moz_historyvisits, moz_cookies, moz_formhistory, moz_downloads
+// - Safari: history_items+history_visits
+// If the schema cannot be read (e.g., locked database), filename and path patterns are used
 func (p *BrowserHistoryParser) CanParse(filePath string) bool {
+	if !isSQLiteDatabase(filePath) {
+		return false
+	}
+
+	db, err := sql.Open("sqlite3", buildSQLiteConnectionString(filePath, true))
+	if err == nil {
+		defer db.Close()
+		if bt, _ := p.detectFromSchema(db); bt != browserUnknown {
+			return true
+		}
+		// A readable schema without browser tables is some other SQLite database
+		var tableCount int
+		if db.QueryRow(`SELECT count(*) FROM sqlite_master`).Scan(&tableCount) == nil {
+			return false
+		}
+	}
+
 	return p.detectBrowserType(filePath) != browserUnknown
 }
 
-// detectBrowserType determines which browser the history file belongs to
+// detectFromSchema determines browser and artifact from the tables present in the database
+func (p *BrowserHistoryParser) detectFromSchema(db *sql.DB) (browserType, browserArtifact) {
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table'`)
+	if err != nil {
+		return browserUnknown, artifactUnknown
+	}
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err == nil {
+			tables[name] = true
+		}
+	}
+	rows.Close()
+
+	switch {
+	case tables["urls"] && tables["visits"]:
+		return browserChrome, artifactHistory
+	case tables["moz_places"] && tables["moz_historyvisits"]:
+		return browserFirefox, artifactHistory
+	case tables["history_items"] && tables["history_visits"]:
+		return browserSafari, artifactHistory
+	case tables["moz_cookies"]:
+		return browserFirefox, artifactCookies
+	case tables["moz_formhistory"]:
+		return browserFirefox, artifactFormHistory
+	case tables["moz_downloads"]:
+		return browserFirefox, artifactDownloads
+	case tables["cookies"] && tableColumns(db, "cookies")["host_key"]:
+		return browserChrome, artifactCookies
+	case tables["logins"] && tableColumns(db, "logins")["origin_url"]:
+		return browserChrome, artifactLoginData
+	case tables["autofill"] && tableColumns(db, "autofill")["date_created"]:
+		return browserChrome, artifactWebData
+	}
+	return browserUnknown, artifactUnknown
+}
+
+// detectBrowserType determines which browser the database belongs to from its path
 func (p *BrowserHistoryParser) detectBrowserType(filePath string) browserType {
 	baseName := strings.ToLower(filepath.Base(filePath))
 	pathLower := strings.ToLower(filePath)
 
-	// Chrome/Edge/Chromium: filename "History" (no extension) in Chromium path
-	if baseName == "history" {
-		if strings.Contains(pathLower, "chrome") ||
-			strings.Contains(pathLower, "edge") ||
-			strings.Contains(pathLower, "chromium") {
-			return browserChrome
+	// Chromium-based: extensionless profile databases in a Chromium browser path
+	switch baseName {
+	case "history", "cookies", "web data", "login data", "login data for account":
+		for _, name := range []string{"chrome", "edge", "chromium", "brave", "opera", "vivaldi", "yandex"} {
+			if strings.Contains(pathLower, name) {
+				return browserChrome
+			}
 		}
 	}
 
-	// Firefox: filename "places.sqlite" in Mozilla/Firefox path
-	if baseName == "places.sqlite" {
+	// Firefox: profile databases in Mozilla/Firefox path
+	switch baseName {
+	case "places.sqlite", "cookies.sqlite", "formhistory.sqlite", "downloads.sqlite":
 		if strings.Contains(pathLower, "firefox") ||
 			strings.Contains(pathLower, "mozilla") {
 			return browserFirefox
@@ -154,15 +256,87 @@ func (p *BrowserHistoryParser) detectBrowserType(filePath string) browserType {
 	return browserUnknown
 }
 
-// Parse parses a browser history SQLite database and returns a slice of events
-func (p *BrowserHistoryParser) Parse(filePath string) ([]*core.Event, error) {
-	browserType := p.detectBrowserType(filePath)
-	if browserType == browserUnknown {
-		return nil, fmt.Errorf("unable to detect browser type for file: %s", filePath)
+// detectArtifact determines which profile database the file is from its name
+func (p *BrowserHistoryParser) detectArtifact(filePath string) browserArtifact {
+	switch strings.ToLower(filepath.Base(filePath)) {
+	case "history", "places.sqlite", "history.db":
+		return artifactHistory
+	case "cookies", "cookies.sqlite":
+		return artifactCookies
+	case "web data":
+		return artifactWebData
+	case "login data", "login data for account":
+		return artifactLoginData
+	case "formhistory.sqlite":
+		return artifactFormHistory
+	case "downloads.sqlite":
+		return artifactDownloads
+	}
+	return artifactUnknown
+}
+
+// detectBrowserName determines the specific browser from its install/profile path
+// Falls back to the browser family when the path gives no hint (e.g., copied evidence)
+func (p *BrowserHistoryParser) detectBrowserName(filePath string, bt browserType) string {
+	pathLower := strings.ToLower(filepath.ToSlash(filePath))
+
+	switch bt {
+	case browserChrome:
+		for _, candidate := range []struct{ pattern, name string }{
+			{"brave", "Brave"},
+			{"opera", "Opera"},
+			{"vivaldi", "Vivaldi"},
+			{"yandex", "Yandex"},
+			{"edge", "Edge"},
+			{"chrome", "Chrome"},
+			{"chromium", "Chromium"},
+		} {
+			if strings.Contains(pathLower, candidate.pattern) {
+				return candidate.name
+			}
+		}
+		return "Chromium-based"
+	case browserFirefox:
+		for _, candidate := range []struct{ pattern, name string }{
+			{"waterfox", "Waterfox"},
+			{"librewolf", "LibreWolf"},
+			{"tor browser", "Tor Browser"},
+		} {
+			if strings.Contains(pathLower, candidate.pattern) {
+				return candidate.name
+			}
+		}
+		return "Firefox"
+	case browserSafari:
+		return "Safari"
 	}
+	return "Unknown"
+}
 
+// detectProfile returns the browser profile directory name, or "" if the file is not in one
+func (p *BrowserHistoryParser) detectProfile(filePath string, bt browserType) string {
+	dir := filepath.Dir(filePath)
+	switch bt {
+	case browserChrome:
+		// Newer Chromium versions keep Cookies in <profile>/Network
+		if filepath.Base(dir) == "Network" {
+			dir = filepath.Dir(dir)
+		}
+		if name := filepath.Base(dir); chromiumProfilePattern.MatchString(name) {
+			return name
+		}
+	case browserFirefox:
+		if name := filepath.Base(dir); firefoxProfilePattern.MatchString(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Parse parses a browser SQLite database and returns a slice of events
+func (p *BrowserHistoryParser) Parse(filePath string) ([]*core.Event, error) {
 	// Try to open database directly first, copy to temp if locked
-	dbPath, tempFile, err := p.prepareDatabase(filePath)
+	dbPath, tempFile, err := prepareSQLiteDatabase(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare database: %w", err)
 	}
@@ -179,33 +353,79 @@ func (p *BrowserHistoryParser) Parse(filePath string) ([]*core.Event, error) {
 	}
 	defer db.Close()
 
-	// Parse based on browser type
+	// Detect from schema first; fall back to filename and path patterns
+	browserType, artifact := p.detectFromSchema(db)
+	if browserType == browserUnknown {
+		browserType, artifact = p.detectBrowserType(filePath), p.detectArtifact(filePath)
+	}
+	if browserType == browserUnknown {
+		return nil, fmt.Errorf("unable to detect browser type for file: %s", filePath)
+	}
+
+	// Parse based on browser type and artifact
 	var events []*core.Event
-	switch browserType {
-	case browserChrome:
+	switch {
+	case browserType == browserChrome && artifact == artifactHistory:
 		events, err = p.parseChrome(db, filePath)
-	case browserFirefox:
+		if err == nil {
+			var downloads []*core.Event
+			downloads, err = p.parseChromeDownloads(db, filePath)
+			events = append(events, downloads...)
+		}
+	case browserType == browserChrome && artifact == artifactCookies:
+		events, err = p.parseChromeCookies(db, filePath)
+	case browserType == browserChrome && artifact == artifactWebData:
+		events, err = p.parseChromeAutofill(db, filePath)
+	case browserType == browserChrome && artifact == artifactLoginData:
+		events, err = p.parseChromeLogins(db, filePath)
+	case browserType == browserFirefox && artifact == artifactHistory:
 		events, err = p.parseFirefox(db, filePath)
-	case browserSafari:
+		if err == nil {
+			var downloads []*core.Event
+			downloads, err = p.parseFirefoxDownloads(db, filePath)
+			events = append(events, downloads...)
+		}
+	case browserType == browserFirefox && artifact == artifactCookies:
+		events, err = p.parseFirefoxCookies(db, filePath)
+	case browserType == browserFirefox && artifact == artifactFormHistory:
+		events, err = p.parseFirefoxFormHistory(db, filePath)
+	case browserType == browserFirefox && artifact == artifactDownloads:
+		events, err = p.parseFirefoxLegacyDownloads(db, filePath)
+	case browserType == browserSafari:
 		events, err = p.parseSafari(db, filePath)
 	default:
-		return nil, fmt.Errorf("unsupported browser type")
+		return nil, fmt.Errorf("unsupported browser artifact: %s", filepath.Base(filePath))
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
+	// Record the detected browser and profile on every event
+	browserName := p.detectBrowserName(filePath, browserType)
+	profile := p.detectProfile(filePath, browserType)
+	for _, event := range events {
+		event.Tags = append(event.Tags, "browser:"+browserName)
+		if profile != "" {
+			event.Tags = append(event.Tags, "profile:"+profile)
+		}
+	}
+
 	// Print summary
-	browserName := p.getBrowserName(browserType)
-	fmt.Printf("Parsed %s history file: %s (found %d events)\n", browserName, filePath, len(events))
+	profileInfo := ""
+	if profile != "" {
+		profileInfo = fmt.Sprintf(", profile %s", profile)
+	}
+	fmt.Printf("Parsed %s (%s) %s file: %s%s (found %d events)\n",
+		browserName, p.getBrowserName(browserType), p.getArtifactName(artifact), filePath, profileInfo, len(events))
 
 	return events, nil
 }
 
-// prepareDatabase prepares the database for reading
+// prepareSQLiteDatabase prepares an evidence SQLite database for reading
 // If the database is locked, it copies to a temp file
-func (p *BrowserHistoryParser) prepareDatabase(filePath string) (string, string, error) {
+// Returns the path to open and the temp file to remove (empty if none)
+func prepareSQLiteDatabase(filePath string) (string, string, error) {
 	// First try to open directly with safe connection string
 	db, err := sql.Open("sqlite3", buildSQLiteConnectionString(filePath, true))
 	if err == nil {
@@ -218,7 +438,7 @@ func (p *BrowserHistoryParser) prepareDatabase(filePath string) (string, string,
 	}
 
 	// Database might be locked, copy to temp file
-	tempFile, err := p.copyToTemp(filePath)
+	tempFile, err := copySQLiteToTemp(filePath)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to copy locked database to temp: %w", err)
 	}
@@ -226,15 +446,15 @@ func (p *BrowserHistoryParser) prepareDatabase(filePath string) (string, string,
 	return tempFile, tempFile, nil
 }
 
-// copyToTemp copies the database file to a temporary location
-func (p *BrowserHistoryParser) copyToTemp(filePath string) (string, error) {
+// copySQLiteToTemp copies the database file to a temporary location
+func copySQLiteToTemp(filePath string) (string, error) {
 	// Create temp file with same extension
 	ext := filepath.Ext(filePath)
 	if ext == "" {
 		ext = ".db"
 	}
 
-	tempFile, err := os.CreateTemp("", "logzero_browser_*"+ext)
+	tempFile, err := os.CreateTemp("", "logzero_sqlite_*"+ext)
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
@@ -416,7 +636,7 @@ func (p *BrowserHistoryParser) parseSafari(db *sql.DB, filePath string) ([]*core
 		}
 
 		// Convert Mac Absolute Time to Unix time
-		timestamp := p.macAbsoluteToTime(visitTime)
+		timestamp := macAbsoluteToTime(visitTime)
 
 		// Safari doesn't store titles in History.db, extract from URL
 		titleStr := p.extractTitleFromURL(url)
@@ -446,14 +666,22 @@ func (p *BrowserHistoryParser) parseSafari(db *sql.DB, filePath string) ([]*core
 }
 
 // webkitToTime converts WebKit timestamp (microseconds since 1601-01-01) to time.Time
+// Zero means "never" in Chromium databases and returns the zero time
 func (p *BrowserHistoryParser) webkitToTime(microseconds int64) time.Time {
-	// Convert to seconds and adjust for epoch difference
+	if microseconds == 0 {
+		return time.Time{}
+	}
+	// Convert to seconds and adjust for epoch difference, keeping microsecond precision
 	unixSeconds := (microseconds / 1000000) - webkitEpochOffset
-	return time.Unix(unixSeconds, 0).UTC()
+	nanos := (microseconds % 1000000) * 1000
+	return time.Unix(unixSeconds, nanos).UTC()
 }
 
 // prtimeToTime converts PRTime (microseconds since 1970-01-01) to time.Time
 func (p *BrowserHistoryParser) prtimeToTime(microseconds int64) time.Time {
+	if microseconds == 0 {
+		return time.Time{}
+	}
 	// Convert microseconds to seconds and nanoseconds
 	seconds := microseconds / 1000000
 	nanos := (microseconds % 1000000) * 1000
@@ -461,7 +689,8 @@ func (p *BrowserHistoryParser) prtimeToTime(microseconds int64) time.Time {
 }
 
 // macAbsoluteToTime converts Mac Absolute Time (seconds since 2001-01-01) to time.Time
-func (p *BrowserHistoryParser) macAbsoluteToTime(seconds float64) time.Time {
+// Shared by Safari and the macOS artifact parsers (knowledgeC, quarantine events)
+func macAbsoluteToTime(seconds float64) time.Time {
 	// Add offset to convert to Unix timestamp
 	unixSeconds := int64(seconds) + macAbsoluteEpochOffset
 	// Handle fractional seconds
@@ -505,3 +734,23 @@ func (p *BrowserHistoryParser) getBrowserName(bt browserType) string {
 		return "Unknown"
 	}
 }
+
+// getArtifactName returns a human-readable name for the browser artifact
+func (p *BrowserHistoryParser) getArtifactName(artifact browserArtifact) string {
+	switch artifact {
+	case artifactHistory:
+		return "history"
+	case artifactCookies:
+		return "cookies"
+	case artifactWebData:
+		return "autofill"
+	case artifactLoginData:
+		return "login data"
+	case artifactFormHistory:
+		return "form history"
+	case artifactDownloads:
+		return "downloads"
+	default:
+		return "unknown"
+	}
+}
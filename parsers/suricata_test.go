@@ -0,0 +1,119 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuricataEVEParser(t *testing.T) {
+	content := strings.Join([]string{
+		`{"timestamp":"2023-04-21T15:30:45.123456+0000","event_type":"alert","src_ip":"10.0.0.5","src_port":51234,"dest_ip":"192.0.2.10","dest_port":80,"proto":"TCP","app_proto":"http","community_id":"1:abc=","alert":{"action":"blocked","gid":1,"signature_id":2100498,"rev":7,"signature":"GPL ATTACK_RESPONSE id check returned root","category":"Potentially Bad Traffic","severity":2}}`,
+		`{"timestamp":"2023-04-21T15:30:46.000000+0000","event_type":"dns","src_ip":"10.0.0.5","src_port":51235,"dest_ip":"192.0.2.53","dest_port":53,"proto":"UDP","dns":{"type":"answer","rrname":"evil.example.com","rrtype":"A","rcode":"NXDOMAIN","answers":[{"rdata":"203.0.113.9"},{"rdata":"203.0.113.10"}]}}`,
+		`{"timestamp":"2023-04-21T15:30:47.000000+0000","event_type":"http","src_ip":"10.0.0.5","src_port":51236,"dest_ip":"192.0.2.80","dest_port":80,"proto":"TCP","app_proto":"http","http":{"hostname":"example.com","url":"/login","http_method":"POST","status":401,"http_user_agent":"curl/8.0"}}`,
+		`{"timestamp":"2023-04-21T15:30:48.000000+0000","event_type":"tls","host":"sensor01","src_ip":"10.0.0.5","src_port":51237,"dest_ip":"198.51.100.7","dest_port":443,"proto":"TCP","app_proto":"tls","tls":{"sni":"c2.example.net","version":"TLS 1.2","subject":"CN=c2.example.net","issuerdn":"CN=R3","ja3":{"hash":"e7d705a3286e19ea42f587b344ee6865"}}}`,
+		`{"timestamp":"2023-04-21T15:30:49.000000+0000","event_type":"flow","src_ip":"10.0.0.5","src_port":51237,"dest_ip":"198.51.100.7","dest_port":443,"proto":"TCP","app_proto":"tls","flow":{"pkts_toserver":10,"pkts_toclient":8,"bytes_toserver":1200,"bytes_toclient":64000,"start":"2023-04-21T15:29:45.000000+0000","end":"2023-04-21T15:30:45.000000+0000","state":"closed","reason":"timeout"}}`,
+		`{"timestamp":"2023-04-21T15:30:50.000000+0000","event_type":"fileinfo","src_ip":"192.0.2.80","src_port":80,"dest_ip":"10.0.0.5","dest_port":51236,"proto":"TCP","app_proto":"http","fileinfo":{"filename":"/payload.exe","magic":"PE32 executable","size":73802,"state":"CLOSED","sha1":"da39a3ee5e6b4b0d3255bfef95601890afd80709","md5":"d41d8cd98f00b204e9800998ecf8427e"}}`,
+		`{"timestamp":"2023-04-21T15:30:51.000000+0000","event_type":"anomaly","src_ip":"10.0.0.5","dest_ip":"192.0.2.10","proto":"TCP","anomaly":{"type":"decode","event":"ipv4.trunc_pkt","layer":"proto_detect"}}`,
+		`{"timestamp":"2023-04-21T15:30:52.000000+0000","event_type":"alert",`,
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "eve.json")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write eve.json: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*SuricataEVEParser); !ok {
+		t.Fatalf("Expected SuricataEVEParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	// The truncated last line is skipped
+	tests := []struct {
+		eventType string
+		eventID   int
+		host      string
+		second    int
+		message   string
+	}{
+		{"SuricataAlert", 2100498, "10.0.0.5", 45, "10.0.0.5:51234 -> 192.0.2.10:80 [TCP] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root " +
+			"category=Potentially Bad Traffic severity=2 action=blocked app=http community_id=1:abc="},
+		{"SuricataDNS", 2, "10.0.0.5", 46, "10.0.0.5:51235 -> 192.0.2.53:53 [UDP] answer query=evil.example.com type=A rcode=NXDOMAIN answers=[203.0.113.9,203.0.113.10]"},
+		{"SuricataHTTP", 3, "10.0.0.5", 47, "10.0.0.5:51236 -> 192.0.2.80:80 [TCP] POST http://example.com/login [401] UA=curl/8.0 app=http"},
+		{"SuricataTLS", 4, "sensor01", 48, "10.0.0.5:51237 -> 198.51.100.7:443 [TCP] SNI=c2.example.net ver=TLS 1.2 subject=CN=c2.example.net issuer=CN=R3 " +
+			"ja3=e7d705a3286e19ea42f587b344ee6865 app=tls"},
+		{"SuricataFlow", 5, "10.0.0.5", 45, "10.0.0.5:51237 -> 198.51.100.7:443 [TCP] service=tls state=closed reason=timeout pkts=10/8 bytes=1200/64000 duration=1m0s"},
+		{"SuricataFileInfo", 6, "192.0.2.80", 50, "192.0.2.80:80 -> 10.0.0.5:51236 [TCP] file=/payload.exe type=PE32 executable size=73802 state=CLOSED " +
+			"sha1=da39a3ee5e6b4b0d3255bfef95601890afd80709 app=http"},
+		{"SuricataAnomaly", 7, "10.0.0.5", 51, "10.0.0.5 -> 192.0.2.10 [TCP] [ANOMALY:decode] ipv4.trunc_pkt layer=proto_detect"},
+	}
+	if len(events) != len(tests) {
+		t.Fatalf("Expected %d events, got %d", len(tests), len(events))
+	}
+	for i, tt := range tests {
+		event := events[i]
+		if event.EventType != tt.eventType || event.EventID != tt.eventID || event.Host != tt.host || event.Message != tt.message {
+			t.Errorf("Line %d: expected %s id=%d host=%q %q, got %s id=%d host=%q %q",
+				i+1, tt.eventType, tt.eventID, tt.host, tt.message, event.EventType, event.EventID, event.Host, event.Message)
+		}
+		if event.Timestamp.Second() != tt.second || event.Timestamp.Location() != time.UTC {
+			t.Errorf("%s: unexpected timestamp %s", tt.eventType, event.Timestamp)
+		}
+	}
+
+	alert := events[0]
+	if alert.Score != 0.7 || !slices.Contains(alert.Tags, "community_id:1:abc=") {
+		t.Errorf("Unexpected alert score %v or tags %v", alert.Score, alert.Tags)
+	}
+}
+
+func TestSnortFastAlertEndpoints(t *testing.T) {
+	content := strings.Join([]string{
+		`04/21/2023-15:30:45.123456  [**] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root [**] [Classification: Potentially Bad Traffic] [Priority: 2] {TCP} 192.0.2.10:80 -> 10.0.0.5:51234`,
+		`04/21/2023-15:30:46.000000  [**] [1:2200000:1] ICMPv6 echo request [**] [Priority: 3] {IPV6-ICMP} 2001:db8::1 -> 2001:db8::2`,
+		`04/21/2023-15:30:47.000000  [**] [1:2200001:1] ICMP ping [**] [Priority: 3] {ICMP} 192.0.2.7 -> 10.0.0.5`,
+		`04/21/2023-15:30:48.000000  [**] [1:2200002:1] IPv6 TCP probe [**] [Priority: 2] {TCP} 2001:0db8:0000:0000:0000:0000:0000:0001:4444 -> 2001:0db8:0000:0000:0000:0000:0000:0002:22`,
+		`not an alert line`,
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "fast.log")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write fast.log: %v", err)
+	}
+	events, err := (&SnortFastAlertParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		host    string
+		message string
+	}{
+		{"192.0.2.10", "192.0.2.10:80 -> 10.0.0.5:51234 [TCP] [1:2100498:7] GPL ATTACK_RESPONSE id check returned root category=Potentially Bad Traffic priority=2"},
+		{"2001:db8::1", "2001:db8::1 -> 2001:db8::2 [IPV6-ICMP] [1:2200000:1] ICMPv6 echo request priority=3"},
+		{"192.0.2.7", "192.0.2.7 -> 10.0.0.5 [ICMP] [1:2200001:1] ICMP ping priority=3"},
+		{"2001:0db8:0000:0000:0000:0000:0000:0001", "[2001:0db8:0000:0000:0000:0000:0000:0001]:4444 -> [2001:0db8:0000:0000:0000:0000:0000:0002]:22 [TCP] [1:2200002:1] IPv6 TCP probe priority=2"},
+		{"", "not an alert line"},
+	}
+	if len(events) != len(tests) {
+		t.Fatalf("Expected %d events, got %d", len(tests), len(events))
+	}
+	for i, tt := range tests {
+		if events[i].Host != tt.host || events[i].Message != tt.message {
+			t.Errorf("Line %d: expected host=%q %q, got host=%q %q", i+1, tt.host, tt.message, events[i].Host, events[i].Message)
+		}
+	}
+	if events[4].EventType != "SuricataAlertRaw" {
+		t.Errorf("Expected a raw event for the malformed line, got %s", events[4].EventType)
+	}
+}
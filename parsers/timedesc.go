@@ -0,0 +1,43 @@
+package parsers
+
+import "LogZero/core"
+
+// ============================================================================
+// Timestamp Descriptions
+// ============================================================================
+
+// builtinTimestampDescs are what a parser's timestamps mark when it does not describe them per event
+// Parsers not listed here read logs, whose timestamps mark when each record was written.
+var builtinTimestampDescs = map[string]string{
+	"RecycleBinParser":       core.TimestampDescDeletion,
+	"RecycleBinINFO2Parser":  core.TimestampDescDeletion,
+	"PrefetchParser":         core.TimestampDescLastRun,
+	"ScheduledTaskXMLParser": core.TimestampDescRegistration,
+	"BrowserHistoryParser":   core.TimestampDescLastVisited,
+	"MacOSKnowledgeCParser":  core.TimestampDescStart,
+	"MacOSQuarantineParser":  core.TimestampDescDownloaded,
+	"MacOSTCCParser":         core.TimestampDescModification,
+	"NetFlowParser":          core.TimestampDescStart,
+}
+
+// timestampDesc returns the description of a parser's timestamps that are not described per event
+func timestampDesc(parser Parser) string {
+	if custom, ok := parser.(*CustomParser); ok && custom.config.TimestampDesc != "" {
+		return custom.config.TimestampDesc
+	}
+	if desc, ok := builtinTimestampDescs[parserName(parser)]; ok {
+		return desc
+	}
+	return core.TimestampDescRecorded
+}
+
+// DescribeTimestamps records what each event's timestamp marks
+// Events a parser described itself, such as the several times of one record, keep their description.
+func DescribeTimestamps(parser Parser, events []*core.Event) {
+	desc := timestampDesc(parser)
+	for _, event := range events {
+		if event.TimestampDesc == "" {
+			event.TimestampDesc = desc
+		}
+	}
+}
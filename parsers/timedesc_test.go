@@ -0,0 +1,95 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestScheduledTaskTimestampDescs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Updater.xml")
+	content := `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <Date>2023-04-21T15:30:45</Date>
+    <Author>CORP\jdoe</Author>
+    <URI>\Updater</URI>
+  </RegistrationInfo>
+  <Triggers>
+    <TimeTrigger>
+      <Enabled>true</Enabled>
+      <StartBoundary>2023-05-01T03:00:00</StartBoundary>
+    </TimeTrigger>
+    <BootTrigger>
+      <Enabled>true</Enabled>
+    </BootTrigger>
+  </Triggers>
+  <Actions Context="Author">
+    <Exec>
+      <Command>C:\Users\Public\updater.exe</Command>
+    </Exec>
+  </Actions>
+</Task>
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write task: %v", err)
+	}
+	parser := &ScheduledTaskXMLParser{}
+	events, err := parser.Parse(path)
+	if err != nil {
+		t.Fatalf("Failed to parse task: %v", err)
+	}
+	DescribeTimestamps(parser, events)
+
+	// Registration, action, time trigger, its start boundary and the boot trigger
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(events))
+	}
+	scheduled := 0
+	for _, event := range events {
+		switch event.TimestampDesc {
+		case core.TimestampDescRegistration:
+			if event.Timestamp.Day() != 21 {
+				t.Errorf("%s: expected the registration date, got %s", event.EventType, event.Timestamp)
+			}
+		case core.TimestampDescScheduledStart:
+			scheduled++
+			if event.EventType != "ScheduledTask:TimeTrigger" || !event.Timestamp.Equal(time.Date(2023, 5, 1, 3, 0, 0, 0, zonelessLocation)) {
+				t.Errorf("Unexpected start boundary event %s at %s", event.EventType, event.Timestamp)
+			}
+		default:
+			t.Errorf("%s: unexpected timestamp description %q", event.EventType, event.TimestampDesc)
+		}
+	}
+	if scheduled != 1 {
+		t.Errorf("Expected 1 start boundary event, got %d", scheduled)
+	}
+}
+
+func TestDescribeTimestampsDefaults(t *testing.T) {
+	tests := []struct {
+		parser Parser
+		desc   string
+	}{
+		{&LinuxSyslogParser{}, core.TimestampDescRecorded},
+		{&RecycleBinParser{}, core.TimestampDescDeletion},
+		{&BrowserHistoryParser{}, core.TimestampDescLastVisited},
+		{&CustomParser{config: CustomParserConfig{Name: "badge", TimestampDesc: "Badge Swipe"}}, "Badge Swipe"},
+		{&CustomParser{config: CustomParserConfig{Name: "app"}}, core.TimestampDescRecorded},
+	}
+	for _, tt := range tests {
+		described := core.NewEvent(time.Now(), "src", "Type", 0, "", "", "msg", "path")
+		described.TimestampDesc = core.TimestampDescEnd
+		undescribed := core.NewEvent(time.Now(), "src", "Type", 0, "", "", "msg", "path")
+		DescribeTimestamps(tt.parser, []*core.Event{described, undescribed})
+		if undescribed.TimestampDesc != tt.desc {
+			t.Errorf("%s: expected %q, got %q", parserName(tt.parser), tt.desc, undescribed.TimestampDesc)
+		}
+		if described.TimestampDesc != core.TimestampDescEnd {
+			t.Errorf("%s: parser-set description was replaced with %q", parserName(tt.parser), described.TimestampDesc)
+		}
+	}
+}
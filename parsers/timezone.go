@@ -0,0 +1,228 @@
+package parsers
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// ============================================================================
+// Timezone Assignment and Normalization
+// ============================================================================
+
+// zonelessLocation marks a timestamp read from a record without an offset
+// Its wall clock is reinterpreted in the zone resolved for the file by NormalizeTimezones.
+var zonelessLocation = time.FixedZone("zoneless", 0)
+
+// Pre-compiled regex patterns for timezone names
+var (
+	// Fixed offsets: +02:00, -0500, UTC+2, UTC-05:30
+	timezoneOffsetPattern = regexp.MustCompile(`^(?i:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+)
+
+// builtinParserTimezones are zones a log format is defined to use for its zone-less timestamps
+var builtinParserTimezones = map[string]string{
+	"IISParser": "UTC", // W3C extended logs are always written in UTC
+}
+
+// timezoneOverride assigns a zone to files matching a glob
+type timezoneOverride struct {
+	glob     string
+	location *time.Location
+}
+
+// Timezone settings, set once at startup (before parsing begins) by the Set*Timezone* functions
+var (
+	defaultTimezone   = time.UTC
+	timezoneOverrides []timezoneOverride
+	parserTimezones   = map[string]*time.Location{}
+)
+
+// loadTimezone resolves an IANA zone name, UTC, Local or a fixed offset such as +02:00 or UTC-5
+func loadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "utc", "z", "gmt":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+	if m := timezoneOffsetPattern.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid offset %q", name)
+		}
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(name, offset), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+	}
+	return location, nil
+}
+
+// splitTimezoneSpec splits a "key=Zone" specification
+func splitTimezoneSpec(spec string) (string, *time.Location, error) {
+	key, zone, ok := strings.Cut(spec, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", nil, fmt.Errorf("expected key=Zone, got %q", spec)
+	}
+	location, err := loadTimezone(zone)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, location, nil
+}
+
+// SetDefaultTimezone sets the zone assumed for zone-less timestamps that have no path or parser specific zone
+// An empty name restores UTC.
+func SetDefaultTimezone(name string) error {
+	location, err := loadTimezone(name)
+	if err != nil {
+		return err
+	}
+	defaultTimezone = location
+	return nil
+}
+
+// SetTimezoneOverrides assigns zones to files by glob ("glob=Zone"); the first matching glob wins
+// Globs match the base name, or trailing path segments for globs containing '/'.
+func SetTimezoneOverrides(specs []string) error {
+	var overrides []timezoneOverride
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		glob, location, err := splitTimezoneSpec(spec)
+		if err != nil {
+			return err
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+		overrides = append(overrides, timezoneOverride{glob: glob, location: location})
+	}
+	timezoneOverrides = overrides
+	return nil
+}
+
+// SetParserTimezones assigns zones to parsers by name ("Parser=Zone"), as shown by ListParsers
+func SetParserTimezones(specs []string) error {
+	zones := map[string]*time.Location{}
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		name, location, err := splitTimezoneSpec(spec)
+		if err != nil {
+			return err
+		}
+		zones[name] = location
+	}
+	parserTimezones = zones
+	return nil
+}
+
+// matchPathGlob matches a glob against the base name, or against trailing path segments for globs with '/'
+func matchPathGlob(glob, filePath string) bool {
+	segments := strings.Split(filepath.ToSlash(filePath), "/")
+	n := strings.Count(glob, "/") + 1
+	if n > len(segments) {
+		return false
+	}
+	ok, _ := path.Match(glob, strings.Join(segments[len(segments)-n:], "/"))
+	return ok
+}
+
+// parserName returns the name a parser is listed under
+func parserName(parser Parser) string {
+	if named, ok := parser.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", parser), "*parsers.")
+}
+
+// resolveTimezone picks the zone for a file's zone-less timestamps and how it was chosen
+// Path overrides win over parser zones, which win over the default zone.
+func resolveTimezone(filePath string, parser Parser) (*time.Location, string) {
+	for _, override := range timezoneOverrides {
+		if matchPathGlob(override.glob, filePath) {
+			return override.location, core.TimezoneOverridden
+		}
+	}
+
+	name := parserName(parser)
+	if location, ok := parserTimezones[name]; ok {
+		return location, core.TimezoneAssumed
+	}
+	if custom, ok := parser.(*CustomParser); ok && custom.location != nil {
+		return custom.location, core.TimezoneAssumed
+	}
+	if zone, ok := builtinParserTimezones[name]; ok {
+		if location, err := loadTimezone(zone); err == nil {
+			return location, core.TimezoneAssumed
+		}
+	}
+	return defaultTimezone, core.TimezoneAssumed
+}
+
+// NormalizeTimezones converts a file's event timestamps to UTC and records their timezone provenance
+// Timestamps that carried an offset are explicit; zone-less ones are read in the zone resolved for the file.
+func NormalizeTimezones(filePath string, parser Parser, events []*core.Event) {
+	var location *time.Location
+	var source string
+	for _, event := range events {
+		if event.Timestamp.IsZero() {
+			continue
+		}
+		if event.Timestamp.Location() != zonelessLocation {
+			event.Timestamp = event.Timestamp.UTC()
+			event.TimezoneSource = core.TimezoneExplicit
+			continue
+		}
+		if location == nil {
+			location, source = resolveTimezone(filePath, parser)
+		}
+		event.Timestamp = inTimezone(event.Timestamp, location).UTC()
+		event.Timezone = location.String()
+		event.TimezoneSource = source
+	}
+}
+
+// inTimezone returns the instant at which t's wall clock is read in location
+func inTimezone(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.Nanosecond(), location)
+}
+
+// layoutHasZone reports whether a time layout carries an offset, zone name or literal Z suffix
+func layoutHasZone(layout string) bool {
+	return strings.Contains(layout, "Z07") || strings.Contains(layout, "-07") ||
+		strings.Contains(layout, "MST") || strings.HasSuffix(layout, "Z")
+}
+
+// parseLogTime parses a log timestamp
+// Layouts with an offset give a UTC time; zone-less layouts keep their wall clock for NormalizeTimezones.
+func parseLogTime(layout, value string) (time.Time, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return t, err
+	}
+	if layoutHasZone(layout) {
+		return t.UTC(), nil
+	}
+	return inTimezone(t, zonelessLocation), nil
+}
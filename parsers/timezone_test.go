@@ -0,0 +1,108 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestNormalizeTimezones(t *testing.T) {
+	if err := SetDefaultTimezone("America/New_York"); err != nil {
+		t.Fatalf("Failed to set default timezone: %v", err)
+	}
+	if err := SetTimezoneOverrides([]string{"dc01/*.log=+02:00"}); err != nil {
+		t.Fatalf("Failed to set overrides: %v", err)
+	}
+	if err := SetParserTimezones([]string{"WindowsFirewallParser=Europe/Berlin"}); err != nil {
+		t.Fatalf("Failed to set parser timezones: %v", err)
+	}
+	t.Cleanup(func() {
+		SetDefaultTimezone("")
+		SetTimezoneOverrides(nil)
+		SetParserTimezones(nil)
+	})
+
+	dir := t.TempDir()
+	naive := "2023-04-21 15:30:45 Service started\n2023-04-21 15:31:00 Service stopped\n"
+	files := map[string]string{
+		filepath.Join("dc01", "app.log"):  naive,
+		filepath.Join("web01", "app.log"): naive,
+		"iso.log":                         "2023-04-21T15:30:45+02:00 login ok\n2023-04-21T15:31:00+02:00 logout\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		want     time.Time
+		timezone string
+		source   string
+	}{
+		{filepath.Join("dc01", "app.log"), time.Date(2023, 4, 21, 13, 30, 45, 0, time.UTC), "+02:00", core.TimezoneOverridden},
+		{filepath.Join("web01", "app.log"), time.Date(2023, 4, 21, 19, 30, 45, 0, time.UTC), "America/New_York", core.TimezoneAssumed},
+		{"iso.log", time.Date(2023, 4, 21, 13, 30, 45, 0, time.UTC), "", core.TimezoneExplicit},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		parser := &LogParser{}
+		events, err := parser.Parse(path)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.name, err)
+		}
+		NormalizeTimezones(path, parser, events)
+		event := events[0]
+		if !event.Timestamp.Equal(tt.want) || event.Timestamp.Location() != time.UTC {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, event.Timestamp)
+		}
+		if event.Timezone != tt.timezone || event.TimezoneSource != tt.source {
+			t.Errorf("%s: expected timezone %q (%s), got %q (%s)", tt.name, tt.timezone, tt.source, event.Timezone, event.TimezoneSource)
+		}
+	}
+
+	if location, source := resolveTimezone("pfirewall.log", &WindowsFirewallParser{}); location.String() != "Europe/Berlin" || source != core.TimezoneAssumed {
+		t.Errorf("Expected parser timezone, got %s (%s)", location, source)
+	}
+	if location, _ := resolveTimezone("u_ex230421.log", &IISParser{}); location != time.UTC {
+		t.Errorf("Expected IIS logs to default to UTC, got %s", location)
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+	}{
+		{"UTC", 0},
+		{"+02:00", 2 * 3600},
+		{"UTC-5", -5 * 3600},
+		{"-0330", -(3*3600 + 30*60)},
+		{"Asia/Tokyo", 9 * 3600},
+	}
+	for _, tt := range tests {
+		location, err := loadTimezone(tt.name)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if _, offset := time.Date(2023, 1, 15, 12, 0, 0, 0, location).Zone(); offset != tt.offset {
+			t.Errorf("%s: expected offset %d, got %d", tt.name, tt.offset, offset)
+		}
+	}
+	for _, bad := range []string{"Mars/Olympus", "+25:00"} {
+		if _, err := loadTimezone(bad); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+	if err := SetTimezoneOverrides([]string{"no-zone"}); err == nil {
+		t.Error("Expected an error for an override without a zone")
+	}
+}
@@ -0,0 +1,261 @@
+package parsers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for VPN logs
+var (
+	// OpenVPN server log line with ISO, ctime or syslog timestamp
+	// Example: 2023-04-21 15:30:46 alice/203.0.113.5:51234 MULTI_sva: pool returned IPv4=10.8.0.6, IPv6=(Not enabled)
+	// Example: Fri Apr 21 15:30:46 2023 203.0.113.5:51234 [alice] Peer Connection Initiated with [AF_INET]203.0.113.5:51234
+	// Example: Apr 21 15:30:46 vpn01 openvpn[1234]: alice/203.0.113.5:51234 SIGTERM[soft,remote-exit] received, client-instance exiting
+	openvpnLinePattern = regexp.MustCompile(`^(?:(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})|([A-Z][a-z]{2} [A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2} \d{4})|([A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2}) \S+ (?:openvpn|ovpn-\S+)(?:\[\d+\])?:)\s+(.*)$`)
+
+	// OpenVPN client instance prefix: common-name/real-ip:port or real-ip:port
+	openvpnClientPattern = regexp.MustCompile(`^(?:([^/\s]+)/)?(?:\[AF_INET6?\])?([0-9A-Fa-f.:]+):(\d+) (.*)$`)
+
+	// [alice] Peer Connection Initiated with [AF_INET]203.0.113.5:51234
+	openvpnPeerPattern = regexp.MustCompile(`^\[([^\]]*)\] Peer Connection Initiated`)
+
+	// MULTI_sva: pool returned IPv4=10.8.0.6 / MULTI: primary virtual IP for alice/203.0.113.5:51234: 10.8.0.6
+	openvpnVirtualIPPattern = regexp.MustCompile(`pool returned IPv4=([0-9.]+)|primary virtual IP for \S+: ([0-9A-Fa-f.:]+)`)
+
+	// Cisco ASA VPN identity formats
+	// Example: Group <GroupPolicy_VPN> User <jdoe> IP <203.0.113.5> AnyConnect parent session started.
+	// Example: Group = GroupPolicy_VPN, Username = jdoe, IP = 203.0.113.5, Session disconnected. Session Type: SSL, ...
+	// Example: AAA user authentication Rejected : reason = AAA failure : server = 10.0.0.1 : user = jdoe : user IP = 203.0.113.5
+	asaVPNAnglePattern  = regexp.MustCompile(`Group <([^>]*)> User <([^>]*)> IP <([^>]*)>`)
+	asaVPNEqualsPattern = regexp.MustCompile(`Group = ([^,]*), Username = ([^,]*), IP = ([^,]*),`)
+	asaAAAUserPattern   = regexp.MustCompile(`: user = ([^:]+?)(?: :|$)`)
+	asaAAAUserIPPattern = regexp.MustCompile(`user IP = (\S+)`)
+
+	// 722051: IPv4 Address <10.10.10.20> IPv6 address <::> assigned to session
+	asaAssignedIPPattern = regexp.MustCompile(`IPv4 Address <([^>]*)>`)
+)
+
+// ============================================================================
+// OpenVPN Server Log Parser
+// ============================================================================
+
+// OpenVPNParser implements the Parser interface for OpenVPN server logs
+type OpenVPNParser struct{}
+
+// openvpnMarkers are message fragments that identify OpenVPN server output
+var openvpnMarkers = []string{"OpenVPN ", "Peer Connection Initiated", "MULTI", "client-instance", "TLS: ", "VERIFY OK"}
+
+// CanParse checks if this parser can handle the given file
+func (p *OpenVPNParser) CanParse(filePath string) bool {
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	count := 0
+	for _, line := range lines {
+		if !openvpnLinePattern.MatchString(line) {
+			continue
+		}
+		for _, marker := range openvpnMarkers {
+			if strings.Contains(line, marker) {
+				count++
+				break
+			}
+		}
+	}
+	if strings.Contains(strings.ToLower(filepath.Base(filePath)), "openvpn") {
+		return count > 0
+	}
+	return count >= 2
+}
+
+// openvpnClient tracks the virtual address handed to a client instance
+type openvpnClient struct {
+	commonName string
+	virtualIP  string
+}
+
+// Parse parses an OpenVPN server log and returns a slice of events
+func (p *OpenVPNParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 120))
+	source := filepath.Base(filePath)
+	years := newYearInferrer(filePath)
+	clients := make(map[string]*openvpnClient)
+
+	err := scanLogRecords(filePath, openvpnLinePattern.MatchString, func(record *logRecord) {
+		matches := openvpnLinePattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			events = append(events, core.NewEvent(time.Time{}, source, "OpenVPNRaw", record.lineNum, "", "", record.line, filePath))
+			return
+		}
+
+		var timestamp time.Time
+		switch {
+		case matches[1] != "":
+			timestamp, _ = parseLogTime("2006-01-02 15:04:05", matches[1])
+		case matches[2] != "":
+			timestamp, _ = parseLogTime(time.ANSIC, strings.Join(strings.Fields(matches[2]), " "))
+		default:
+			timestamp = parseBSDTimestamp(matches[3], yearlessPlaceholder)
+		}
+		body := record.withContinuation(matches[4])
+
+		// Messages about a client instance carry its common name and real address
+		var user, realIP, realAddr string
+		text := body
+		if m := openvpnClientPattern.FindStringSubmatch(body); m != nil {
+			user, realIP, text = m[1], m[2], m[4]
+			realAddr = realIP + ":" + m[3]
+		}
+		if m := openvpnPeerPattern.FindStringSubmatch(text); m != nil && user == "" {
+			user = m[1]
+		}
+
+		client := clients[realAddr]
+		if realAddr != "" && client == nil {
+			client = &openvpnClient{}
+			clients[realAddr] = client
+		}
+		if client != nil {
+			if user != "" && user != "UNDEF" {
+				client.commonName = user
+			}
+			user = client.commonName
+		}
+
+		eventType := "OpenVPNLog"
+		score := 0.0
+		msg := text
+		switch {
+		case openvpnPeerPattern.MatchString(text):
+			eventType = "OpenVPNConnect"
+			msg = fmt.Sprintf("Client connected: %s from %s", user, realAddr)
+		case openvpnVirtualIPPattern.MatchString(text) && client != nil:
+			m := openvpnVirtualIPPattern.FindStringSubmatch(text)
+			virtualIP := m[1]
+			if virtualIP == "" {
+				virtualIP = m[2]
+			}
+			if virtualIP == client.virtualIP {
+				// MULTI_sva and "primary virtual IP" both report the same assignment
+				return
+			}
+			client.virtualIP = virtualIP
+			eventType = "OpenVPNAddressAssigned"
+			msg = fmt.Sprintf("Virtual IP %s assigned to %s (real %s)", virtualIP, user, realAddr)
+		case strings.Contains(text, "AUTH_FAILED") || strings.Contains(text, "verification failed") ||
+			strings.Contains(text, "VERIFY ERROR") || strings.Contains(text, "TLS Auth Error"):
+			eventType = "OpenVPNAuthFailure"
+			score = 0.5
+		case strings.Contains(text, "client-instance exiting") || strings.Contains(text, "client-instance restarting") ||
+			strings.Contains(text, "Connection reset, restarting") || strings.Contains(text, "Inactivity timeout"):
+			eventType = "OpenVPNDisconnect"
+			msg = fmt.Sprintf("Client disconnected: %s from %s", user, realAddr)
+			if client != nil && client.virtualIP != "" {
+				msg += fmt.Sprintf(" (virtual %s)", client.virtualIP)
+			}
+			msg += ": " + text
+			delete(clients, realAddr)
+		}
+
+		event := core.NewEvent(timestamp, source, eventType, record.lineNum, user, realIP, msg, filePath)
+		event.Score = score
+		years.add(event)
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	years.apply()
+
+	fmt.Printf("Parsed OpenVPN log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// Cisco ASA AnyConnect / WebVPN Sessions
+// ============================================================================
+
+// asaVPNSession is one remote access session reconstructed from ASA messages
+type asaVPNSession struct {
+	id         int
+	assignedIP string
+}
+
+// asaVPNEvent describes the VPN context of a single ASA message
+type asaVPNEvent struct {
+	user     string
+	publicIP string
+	session  *asaVPNSession
+	failed   bool
+}
+
+// asaVPNSessions correlates AnyConnect/WebVPN messages into sessions keyed by user and public IP
+// 113039 and 716001 open a session, 722051 records the assigned address, 113019 and 716002 close it
+type asaVPNSessions struct {
+	next   int
+	active map[string]*asaVPNSession
+}
+
+func newASAVPNSessions() *asaVPNSessions {
+	return &asaVPNSessions{active: make(map[string]*asaVPNSession)}
+}
+
+// track returns the VPN context of an ASA message, or nil when it is not a VPN message
+func (s *asaVPNSessions) track(msgID, message string) *asaVPNEvent {
+	vpn := &asaVPNEvent{}
+	if m := asaVPNAnglePattern.FindStringSubmatch(message); m != nil {
+		vpn.user, vpn.publicIP = m[2], m[3]
+	} else if m := asaVPNEqualsPattern.FindStringSubmatch(message); m != nil {
+		vpn.user, vpn.publicIP = strings.TrimSpace(m[2]), strings.TrimSpace(m[3])
+	} else if strings.HasPrefix(message, "AAA user") {
+		vpn.user = extractField(asaAAAUserPattern, message)
+		vpn.publicIP = extractField(asaAAAUserIPPattern, message)
+	} else {
+		return nil
+	}
+
+	switch msgID {
+	case "113005", "113015", "716039":
+		vpn.failed = true
+		return vpn
+	case "113004", "113012":
+		return vpn
+	}
+
+	key := vpn.user + "|" + vpn.publicIP
+	vpn.session = s.active[key]
+	if vpn.session == nil && (msgID == "113039" || msgID == "716001") {
+		s.next++
+		vpn.session = &asaVPNSession{id: s.next}
+		s.active[key] = vpn.session
+	}
+	if vpn.session == nil {
+		return vpn
+	}
+
+	switch msgID {
+	case "722051":
+		vpn.session.assignedIP = extractField(asaAssignedIPPattern, message)
+	case "113019", "716002":
+		delete(s.active, key)
+	}
+	return vpn
+}
+
+// describe formats the session context appended to the ASA message
+func (v *asaVPNEvent) describe() string {
+	if v.session == nil {
+		return ""
+	}
+	desc := fmt.Sprintf(" [vpn session %d", v.session.id)
+	if v.session.assignedIP != "" {
+		desc += ", assigned " + v.session.assignedIP
+	}
+	return desc + "]"
+}
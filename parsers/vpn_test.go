@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenVPNParser(t *testing.T) {
+	content := strings.Join([]string{
+		"2023-04-21 15:30:40 OpenVPN 2.5.8 x86_64-pc-linux-gnu [SSL (OpenSSL)] [LZO] [LZ4] [EPOLL] [MH/PKTINFO] [AEAD]",
+		"2023-04-21 15:30:45 203.0.113.5:51234 TLS: Initial packet from [AF_INET]203.0.113.5:51234, sid=1a2b3c4d 5e6f7a8b",
+		"2023-04-21 15:30:46 203.0.113.5:51234 [alice] Peer Connection Initiated with [AF_INET]203.0.113.5:51234",
+		"2023-04-21 15:30:46 alice/203.0.113.5:51234 MULTI_sva: pool returned IPv4=10.8.0.6, IPv6=(Not enabled)",
+		"2023-04-21 15:30:46 alice/203.0.113.5:51234 MULTI: primary virtual IP for alice/203.0.113.5:51234: 10.8.0.6",
+		"2023-04-21 15:45:00 198.51.100.7:40000 TLS Auth Error: Auth Username/Password verification failed for peer",
+		"2023-04-21 16:00:00 alice/203.0.113.5:51234 SIGTERM[soft,remote-exit] received, client-instance exiting",
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "openvpn.log")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*OpenVPNParser); !ok {
+		t.Fatalf("Expected OpenVPNParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 6 {
+		t.Fatalf("Expected 6 events, got %d", len(events))
+	}
+
+	tests := []struct {
+		index     int
+		eventType string
+		user      string
+		host      string
+		message   string
+	}{
+		{2, "OpenVPNConnect", "alice", "203.0.113.5", "Client connected: alice from 203.0.113.5:51234"},
+		{3, "OpenVPNAddressAssigned", "alice", "203.0.113.5", "Virtual IP 10.8.0.6 assigned to alice (real 203.0.113.5:51234)"},
+		{4, "OpenVPNAuthFailure", "", "198.51.100.7", "TLS Auth Error: Auth Username/Password verification failed for peer"},
+		{5, "OpenVPNDisconnect", "alice", "203.0.113.5", "Client disconnected: alice from 203.0.113.5:51234 (virtual 10.8.0.6): SIGTERM[soft,remote-exit] received, client-instance exiting"},
+	}
+	for _, tt := range tests {
+		event := events[tt.index]
+		if event.EventType != tt.eventType || event.User != tt.user || event.Host != tt.host || event.Message != tt.message {
+			t.Errorf("[%d]: expected %s user=%q host=%q %q, got %s user=%q host=%q %q", tt.index,
+				tt.eventType, tt.user, tt.host, tt.message, event.EventType, event.User, event.Host, event.Message)
+		}
+	}
+	if want := time.Date(2023, 4, 21, 16, 0, 0, 0, time.UTC); !events[5].Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %s, got %s", want, events[5].Timestamp)
+	}
+}
+
+func TestCiscoASAVPNSessions(t *testing.T) {
+	content := strings.Join([]string{
+		"Apr 21 2023 15:30:44: %ASA-6-113004: AAA user authentication Successful : server = 10.0.0.1 : user = jdoe",
+		"Apr 21 2023 15:30:45: %ASA-6-113039: Group <GroupPolicy_VPN> User <jdoe> IP <203.0.113.5> AnyConnect parent session started.",
+		"Apr 21 2023 15:30:45: %ASA-6-716001: Group <GroupPolicy_VPN> User <jdoe> IP <203.0.113.5> WebVPN session started.",
+		"Apr 21 2023 15:30:46: %ASA-4-722051: Group <GroupPolicy_VPN> User <jdoe> IP <203.0.113.5> IPv4 Address <10.10.10.20> IPv6 address <::> assigned to session",
+		"Apr 21 2023 16:30:00: %ASA-4-113019: Group = GroupPolicy_VPN, Username = jdoe, IP = 203.0.113.5, Session disconnected. Session Type: SSL, Duration: 0h:59m:14s, Bytes xmt: 1234, Bytes rcv: 5678, Reason: User Requested",
+		"Apr 21 2023 16:31:00: %ASA-6-113005: AAA user authentication Rejected : reason = AAA failure : server = 10.0.0.1 : user = admin : user IP = 198.51.100.7",
+		"Apr 21 2023 16:32:00: %ASA-6-302013: Built inbound TCP connection 12345 for outside:192.168.1.100/54321 (192.168.1.100/54321) to inside:10.0.0.50/443 (10.0.0.50/443)",
+	}, "\n")
+
+	filePath := filepath.Join(t.TempDir(), "asa.log")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	events, err := (&CiscoASAParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 7 {
+		t.Fatalf("Expected 7 events, got %d", len(events))
+	}
+
+	for i := 1; i <= 4; i++ {
+		if events[i].EventType != "CiscoASAVPN" || events[i].User != "jdoe" || events[i].Host != "203.0.113.5" {
+			t.Errorf("[%d]: unexpected VPN attribution: %s user=%q host=%q", i, events[i].EventType, events[i].User, events[i].Host)
+		}
+	}
+	if !strings.HasSuffix(events[2].Message, "[vpn session 1]") {
+		t.Errorf("Expected WebVPN start to join session 1, got %q", events[2].Message)
+	}
+	if !strings.HasSuffix(events[4].Message, "[vpn session 1, assigned 10.10.10.20]") {
+		t.Errorf("Expected disconnect to carry the assigned address, got %q", events[4].Message)
+	}
+	if events[5].User != "admin" || events[5].Host != "198.51.100.7" || events[5].Score != 0.5 {
+		t.Errorf("Unexpected AAA rejection: user=%q host=%q score=%v", events[5].User, events[5].Host, events[5].Score)
+	}
+	if events[6].EventType != "CiscoASA" || events[6].User != "" {
+		t.Errorf("Expected a plain firewall event, got %s user=%q", events[6].EventType, events[6].User)
+	}
+}
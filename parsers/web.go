@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -20,20 +19,144 @@ type WebAccessParser struct{}
 var (
 	// Combined Log Format: 127.0.0.1 - - [21/Apr/2023:15:30:45 +0000] "GET /path HTTP/1.1" 200 1234 "referer" "user-agent"
 	clfPattern = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+\[([^\]]+)\]\s+"([^"]+)"\s+(\d{3})\s+(\d+|-)(?:\s+"([^"]*)"\s+"([^"]*)")?.*$`)
+
+	// nginx log_format variables: $remote_addr or ${remote_addr}
+	nginxVariablePattern = regexp.MustCompile(`\$(?:(\w+)|\{(\w+)\})`)
 )
 
+// webLogFormat is a user-provided nginx log_format compiled to a line pattern
+type webLogFormat struct {
+	format  string
+	pattern *regexp.Regexp
+	fields  []string // Variable name of each capture group
+}
+
+// customWebLogFormat is set once at startup (before parsing begins) by SetNginxLogFormat
+var customWebLogFormat *webLogFormat
+
+// SetNginxLogFormat configures a custom nginx log_format for access logs, for example:
+// `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_user_agent" $request_time`
+// Lines that do not match it fall back to Combined Log Format. An empty format clears it.
+func SetNginxLogFormat(format string) error {
+	if strings.TrimSpace(format) == "" {
+		customWebLogFormat = nil
+		return nil
+	}
+	compiled, err := compileNginxLogFormat(format)
+	if err != nil {
+		return err
+	}
+	customWebLogFormat = compiled
+	return nil
+}
+
+// compileNginxLogFormat turns a log_format string into an anchored regex
+// Each variable captures up to the first character of the literal text that follows it
+func compileNginxLogFormat(format string) (*webLogFormat, error) {
+	locs := nginxVariablePattern.FindAllStringSubmatchIndex(format, -1)
+	if len(locs) == 0 {
+		return nil, fmt.Errorf("log format contains no $variables: %q", format)
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	fields := make([]string, 0, len(locs))
+	hasTime := false
+	prevEnd := 0
+	for i, loc := range locs {
+		pattern.WriteString(regexp.QuoteMeta(format[prevEnd:loc[0]]))
+
+		name := ""
+		if loc[2] >= 0 {
+			name = format[loc[2]:loc[3]]
+		} else {
+			name = format[loc[4]:loc[5]]
+		}
+		fields = append(fields, name)
+		if name == "time_local" || name == "time_iso8601" || name == "msec" {
+			hasTime = true
+		}
+
+		nextLiteral := len(format)
+		if i+1 < len(locs) {
+			nextLiteral = locs[i+1][0]
+		}
+		switch {
+		case loc[1] < nextLiteral:
+			pattern.WriteString(fmt.Sprintf(`([^\x%02x]*)`, format[loc[1]]))
+		case loc[1] == len(format):
+			pattern.WriteString(`(.*)`)
+		default:
+			pattern.WriteString(`(.*?)`)
+		}
+		prevEnd = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(format[prevEnd:]))
+	pattern.WriteString("$")
+
+	if !hasTime {
+		return nil, fmt.Errorf("log format has no $time_local, $time_iso8601 or $msec variable: %q", format)
+	}
+	compiled, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile log format %q: %w", format, err)
+	}
+	return &webLogFormat{format: format, pattern: compiled, fields: fields}, nil
+}
+
+// match returns the variables of a line matching the format, or nil
+func (f *webLogFormat) match(line string) map[string]string {
+	matches := f.pattern.FindStringSubmatch(line)
+	if matches == nil {
+		return nil
+	}
+	values := make(map[string]string, len(f.fields))
+	for i, name := range f.fields {
+		if value := matches[i+1]; value != "-" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// timestamp extracts the request time from $time_local, $time_iso8601 or $msec
+func (f *webLogFormat) timestamp(values map[string]string) time.Time {
+	if v := values["time_local"]; v != "" {
+		if t, err := parseLogTime("02/Jan/2006:15:04:05 -0700", v); err == nil {
+			return t
+		}
+	}
+	if v := values["time_iso8601"]; v != "" {
+		if t, err := parseLogTime(time.RFC3339, v); err == nil {
+			return t
+		}
+	}
+	if v := values["msec"]; v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.UnixMilli(int64(secs * 1000)).UTC()
+		}
+	}
+	return time.Time{}
+}
+
 // CanParse checks if this parser can handle the given file
 func (p *WebAccessParser) CanParse(filePath string) bool {
 	baseName := strings.ToLower(filepath.Base(filePath))
-	return baseName == "access.log" ||
+	if baseName == "access.log" ||
 		strings.HasPrefix(baseName, "access.log.") ||
+		strings.HasPrefix(baseName, "localhost_access_log") ||
 		strings.Contains(baseName, "apache") ||
-		strings.Contains(baseName, "nginx")
+		strings.Contains(baseName, "nginx") {
+		return true
+	}
+
+	// Logs written with the configured custom log_format, under any name
+	return customWebLogFormat != nil && countHeaderMatches(filePath, customWebLogFormat.pattern) > 0
 }
 
 // Parse parses a web access log file and returns a slice of events
 func (p *WebAccessParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -51,6 +174,7 @@ func (p *WebAccessParser) Parse(filePath string) ([]*core.Event, error) {
 
 	// Apache format: 02/Jan/2006:15:04:05 -0700
 	const timeLayout = "02/Jan/2006:15:04:05 -0700"
+	customFormat := customWebLogFormat
 
 	for scanner.Scan() {
 		lineNum++
@@ -62,6 +186,14 @@ func (p *WebAccessParser) Parse(filePath string) ([]*core.Event, error) {
 		// Truncate line for regex matching to prevent ReDoS on extremely long lines
 		lineForRegex := truncateLine(line)
 
+		// Try the configured log_format first
+		if customFormat != nil {
+			if values := customFormat.match(lineForRegex); values != nil {
+				events = append(events, customWebAccessEvent(customFormat, values, source, lineNum, filePath))
+				continue
+			}
+		}
+
 		// Parse Line
 		matches := clfPattern.FindStringSubmatch(lineForRegex)
 
@@ -86,7 +218,7 @@ func (p *WebAccessParser) Parse(filePath string) ([]*core.Event, error) {
 			// 	userAgent = matches[9]
 			// }
 
-			timestamp, err := time.Parse(timeLayout, timeStr)
+			timestamp, err := parseLogTime(timeLayout, timeStr)
 			// Don't use time.Now() as fallback - affects forensic timeline accuracy
 			// Leave timestamp as zero value if parsing fails
 			_ = err // Acknowledge potential parse error, timestamp stays zero
@@ -141,3 +273,27 @@ func (p *WebAccessParser) Parse(filePath string) ([]*core.Event, error) {
 	fmt.Printf("Parsed Web Access file: %s (found %d events)\n", filePath, len(events))
 	return events, nil
 }
+
+// customWebAccessEvent builds an access event from the variables of a custom log_format line
+func customWebAccessEvent(format *webLogFormat, values map[string]string, source string, lineNum int, filePath string) *core.Event {
+	method := values["request_method"]
+	path := firstNonEmpty(values, "request_uri", "uri")
+	if reqParts := strings.Fields(values["request"]); len(reqParts) > 0 {
+		method = reqParts[0]
+		if len(reqParts) > 1 {
+			path = reqParts[1]
+		}
+	}
+	status, _ := strconv.Atoi(values["status"])
+
+	return core.NewEvent(
+		format.timestamp(values),
+		source,
+		"WebAccess",
+		lineNum,
+		values["remote_user"],
+		firstNonEmpty(values, "remote_addr", "http_x_forwarded_for"),
+		fmt.Sprintf("%s %s (Status: %d)", method, path, status),
+		filePath,
+	)
+}
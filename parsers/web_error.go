@@ -0,0 +1,247 @@
+package parsers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for web and application server error logs
+var (
+	// Apache 2.2: [Fri Apr 21 15:30:45 2023] [error] [client 203.0.113.5] File does not exist: /var/www/html/shell.php
+	// Apache 2.4: [Fri Apr 21 15:30:45.123456 2023] [core:error] [pid 1234:tid 140234] [client 203.0.113.5:54321] AH00128: File does not exist: ...
+	apacheErrorPattern = regexp.MustCompile(`^\[(\w{3} \w{3} +\d{1,2} \d{2}:\d{2}:\d{2}(?:\.\d+)? \d{4})\] \[(?:([\w.-]+):)?(\w+)\](?: \[pid (\d+)(?::tid \d+)?\])?\s*(.*)$`)
+
+	// [client 203.0.113.5:54321] anywhere in an Apache error message
+	apacheClientPattern = regexp.MustCompile(`\[client ([^\]]+)\]\s*`)
+
+	// Nginx: 2023/04/21 15:30:45 [error] 1234#1234: *5678 open() "/usr/share/nginx/html/shell.php" failed (2: No such file or directory), client: 203.0.113.5, ...
+	nginxErrorPattern = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[(\w+)\] (\d+)#\d+: (?:\*\d+ )?(.*)$`)
+
+	// ", client: 203.0.113.5" in an Nginx error message
+	nginxClientPattern = regexp.MustCompile(`, client: ([^,]+)`)
+
+	// Tomcat JULI OneLineFormatter: 21-Apr-2023 15:30:45.123 SEVERE [http-nio-8080-exec-1] org.apache.catalina.core.StandardWrapperValve.invoke message
+	tomcatOneLinePattern = regexp.MustCompile(`^(\d{2}-\w{3}-\d{4} \d{2}:\d{2}:\d{2}(?:\.\d+)?) (\w+) \[([^\]]*)\] (\S+) ?(.*)$`)
+
+	// JULI SimpleFormatter, two lines: "Apr 21, 2023 3:30:45 PM org.apache.catalina.startup.Catalina start" then "INFO: Server startup in 1234 ms"
+	tomcatSimplePattern      = regexp.MustCompile(`^(\w{3} \d{1,2}, \d{4} \d{1,2}:\d{2}:\d{2} [AP]M) (\S+)(?: (\S+))?$`)
+	tomcatSimpleLevelPattern = regexp.MustCompile(`^(SEVERE|WARNING|INFO|CONFIG|FINE|FINER|FINEST): (.*)$`)
+
+	// Log4j/Logback application logs: 2023-04-21 15:30:45,123 ERROR [main] com.example.App - message
+	javaAppLogPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?)\s+\[?(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL|SEVERE)\]?\s+(?:\[([^\]]*)\]\s+)?(\S+)\s+(?:- )?(.*)$`)
+
+	// Tomcat log file names: catalina.out, catalina.2023-04-21.log, localhost.2023-04-21.log, manager.*.log
+	tomcatFileNamePattern = regexp.MustCompile(`^(?:catalina\.out|(?:catalina|localhost|manager|host-manager)\.\d{4}-\d{2}-\d{2}\.log)(?:\.\d+)?$`)
+)
+
+// countHeaderMatches counts the header lines of a file matching pattern
+func countHeaderMatches(filePath string, pattern *regexp.Regexp) int {
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, line := range lines {
+		if pattern.MatchString(line) {
+			count++
+		}
+	}
+	return count
+}
+
+// stripPort removes a trailing :port from an IPv4 address or hostname
+func stripPort(address string) string {
+	if i := strings.LastIndexByte(address, ':'); i > 0 && strings.Count(address, ":") == 1 {
+		return address[:i]
+	}
+	return address
+}
+
+// ============================================================================
+// Apache Error Log Parser
+// ============================================================================
+
+// ApacheErrorLogParser implements the Parser interface for Apache httpd error_log files (2.2 and 2.4 formats)
+type ApacheErrorLogParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *ApacheErrorLogParser) CanParse(filePath string) bool {
+	matches := countHeaderMatches(filePath, apacheErrorPattern)
+	if strings.Contains(strings.ToLower(filepath.Base(filePath)), "error") {
+		return matches > 0
+	}
+	return matches >= 3
+}
+
+// Parse parses an Apache error log and returns a slice of events
+func (p *ApacheErrorLogParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 200))
+	source := filepath.Base(filePath)
+
+	err := scanLogRecords(filePath, apacheErrorPattern.MatchString, func(record *logRecord) {
+		matches := apacheErrorPattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			events = append(events, core.NewEvent(time.Time{}, source, "ApacheErrorRaw", record.lineNum, "", "", record.withContinuation(record.line), filePath))
+			return
+		}
+
+		timestamp, _ := parseLogTime("Mon Jan 2 15:04:05.999999 2006", strings.Join(strings.Fields(matches[1]), " "))
+		module, level, pid, text := matches[2], matches[3], matches[4], matches[5]
+
+		client := ""
+		if m := apacheClientPattern.FindStringSubmatch(text); m != nil {
+			client = stripPort(m[1])
+			text = strings.Replace(text, m[0], "", 1)
+		}
+
+		msgParts := []string{}
+		if module != "" {
+			msgParts = append(msgParts, fmt.Sprintf("[%s:%s]", module, level))
+		} else {
+			msgParts = append(msgParts, fmt.Sprintf("[%s]", level))
+		}
+		msgParts = append(msgParts, text)
+		if pid != "" {
+			msgParts = append(msgParts, fmt.Sprintf("(pid %s)", pid))
+		}
+
+		event := core.NewEvent(timestamp, source, "ApacheError", record.lineNum, "", client, record.withContinuation(strings.Join(msgParts, " ")), filePath)
+		event.Tags = append(event.Tags, "level:"+level)
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed Apache error log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// Nginx Error Log Parser
+// ============================================================================
+
+// NginxErrorLogParser implements the Parser interface for Nginx error.log files
+type NginxErrorLogParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *NginxErrorLogParser) CanParse(filePath string) bool {
+	matches := countHeaderMatches(filePath, nginxErrorPattern)
+	if strings.Contains(strings.ToLower(filepath.Base(filePath)), "error") {
+		return matches > 0
+	}
+	return matches >= 3
+}
+
+// Parse parses an Nginx error log and returns a slice of events
+func (p *NginxErrorLogParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 250))
+	source := filepath.Base(filePath)
+
+	err := scanLogRecords(filePath, nginxErrorPattern.MatchString, func(record *logRecord) {
+		matches := nginxErrorPattern.FindStringSubmatch(truncateLine(record.line))
+		if matches == nil {
+			events = append(events, core.NewEvent(time.Time{}, source, "NginxErrorRaw", record.lineNum, "", "", record.withContinuation(record.line), filePath))
+			return
+		}
+
+		timestamp, _ := parseLogTime("2006/01/02 15:04:05", matches[1])
+		level, pid, text := matches[2], matches[3], matches[4]
+
+		client := ""
+		if m := nginxClientPattern.FindStringSubmatch(text); m != nil {
+			client = m[1]
+		}
+
+		msg := fmt.Sprintf("[%s] %s (pid %s)", level, text, pid)
+		event := core.NewEvent(timestamp, source, "NginxError", record.lineNum, "", client, record.withContinuation(msg), filePath)
+		event.Tags = append(event.Tags, "level:"+level)
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed Nginx error log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// ============================================================================
+// Tomcat / Java Application Log Parser
+// ============================================================================
+
+// TomcatLogParser implements the Parser interface for Tomcat catalina/localhost logs and
+// Log4j/Logback-style Java application logs, attaching multi-line stack traces to their record
+type TomcatLogParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *TomcatLogParser) CanParse(filePath string) bool {
+	if tomcatFileNamePattern.MatchString(strings.ToLower(filepath.Base(filePath))) {
+		return true
+	}
+	return countHeaderMatches(filePath, tomcatOneLinePattern) >= 3 || countHeaderMatches(filePath, tomcatSimplePattern) >= 3
+}
+
+// isTomcatRecordStart reports whether a line begins a new Tomcat or Java log record
+func isTomcatRecordStart(line string) bool {
+	return tomcatOneLinePattern.MatchString(line) || tomcatSimplePattern.MatchString(line) || javaAppLogPattern.MatchString(line)
+}
+
+// Parse parses a Tomcat log file and returns a slice of events
+func (p *TomcatLogParser) Parse(filePath string) ([]*core.Event, error) {
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 200))
+	source := filepath.Base(filePath)
+
+	err := scanLogRecords(filePath, isTomcatRecordStart, func(record *logRecord) {
+		line := truncateLine(record.line)
+		var timestamp time.Time
+		var level, thread, logger, text string
+
+		if m := tomcatOneLinePattern.FindStringSubmatch(line); m != nil {
+			timestamp, _ = parseLogTime("02-Jan-2006 15:04:05.999", m[1])
+			level, thread, logger, text = m[2], m[3], m[4], m[5]
+		} else if m := tomcatSimplePattern.FindStringSubmatch(line); m != nil {
+			timestamp, _ = parseLogTime("Jan 2, 2006 3:04:05 PM", m[1])
+			logger = m[2]
+			if m[3] != "" {
+				logger += "." + m[3]
+			}
+			// The level and message are on the first continuation line
+			if len(record.continuation) > 0 {
+				if lm := tomcatSimpleLevelPattern.FindStringSubmatch(record.continuation[0]); lm != nil {
+					level, text = lm[1], lm[2]
+					record.continuation = record.continuation[1:]
+				}
+			}
+		} else if m := javaAppLogPattern.FindStringSubmatch(line); m != nil {
+			timestamp, _ = parseLogTime("2006-01-02 15:04:05.999", strings.Replace(strings.Replace(m[1], "T", " ", 1), ",", ".", 1))
+			level, thread, logger, text = m[2], m[3], m[4], m[5]
+		} else {
+			// Startup banners and stdout noise in catalina.out
+			events = append(events, core.NewEvent(time.Time{}, source, "TomcatLogRaw", record.lineNum, "", "", record.withContinuation(record.line), filePath))
+			return
+		}
+
+		msg := fmt.Sprintf("[%s] %s: %s", level, logger, text)
+		if thread != "" {
+			msg = fmt.Sprintf("[%s] [%s] %s: %s", level, thread, logger, text)
+		}
+		event := core.NewEvent(timestamp, source, "TomcatLog", record.lineNum, "", "", record.withContinuation(msg), filePath)
+		event.Tags = append(event.Tags, "level:"+strings.ToLower(level))
+		if len(record.continuation) > 0 {
+			event.Tags = append(event.Tags, "stacktrace")
+		}
+		events = append(events, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Parsed Tomcat log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
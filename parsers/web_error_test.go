@@ -0,0 +1,112 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebServerErrorLogs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"error_log": strings.Join([]string{
+			`[Fri Apr 21 15:30:45 2023] [error] [client 203.0.113.5] File does not exist: /var/www/html/shell.php`,
+			`[Fri Apr 21 15:30:46.123456 2023] [php7:error] [pid 1234:tid 140234] [client 203.0.113.5:54321] PHP Warning:  system(): Unable to fork [whoami] in /var/www/html/up.php on line 3, referer: http://example.com/`,
+		}, "\n"),
+		"error.log": `2023/04/21 15:30:47 [error] 1234#1234: *5678 open() "/usr/share/nginx/html/cmd.jsp" failed (2: No such file or directory), client: 198.51.100.7, server: localhost, request: "GET /cmd.jsp HTTP/1.1", host: "example.com"`,
+		"catalina.2023-04-21.log": strings.Join([]string{
+			`21-Apr-2023 15:30:48.123 SEVERE [http-nio-8080-exec-1] org.apache.catalina.core.StandardWrapperValve.invoke Servlet.service() for servlet [jsp] threw exception`,
+			`java.lang.RuntimeException: Cannot run program "cmd.exe"`,
+			"\tat java.lang.ProcessBuilder.start(ProcessBuilder.java:1048)",
+			"\tat org.apache.jsp.shell_jsp._jspService(shell_jsp.java:12)",
+			`21-Apr-2023 15:30:49.000 INFO [main] org.apache.catalina.startup.Catalina.start Server startup in [1234] milliseconds`,
+		}, "\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		index     int
+		count     int
+		eventType string
+		host      string
+		message   string
+		timestamp time.Time
+	}{
+		{"error_log", 0, 2, "ApacheError", "203.0.113.5", "[error] File does not exist: /var/www/html/shell.php",
+			time.Date(2023, 4, 21, 15, 30, 45, 0, time.UTC)},
+		{"error_log", 1, 2, "ApacheError", "203.0.113.5", "[php7:error] PHP Warning:  system(): Unable to fork [whoami] in /var/www/html/up.php on line 3, referer: http://example.com/ (pid 1234)",
+			time.Date(2023, 4, 21, 15, 30, 46, 123456000, time.UTC)},
+		{"error.log", 0, 1, "NginxError", "198.51.100.7", `[error] open() "/usr/share/nginx/html/cmd.jsp" failed (2: No such file or directory), client: 198.51.100.7, server: localhost, request: "GET /cmd.jsp HTTP/1.1", host: "example.com" (pid 1234)`,
+			time.Date(2023, 4, 21, 15, 30, 47, 0, time.UTC)},
+		{"catalina.2023-04-21.log", 0, 2, "TomcatLog", "", "[SEVERE] [http-nio-8080-exec-1] org.apache.catalina.core.StandardWrapperValve.invoke: Servlet.service() for servlet [jsp] threw exception\n" +
+			"java.lang.RuntimeException: Cannot run program \"cmd.exe\"\n\tat java.lang.ProcessBuilder.start(ProcessBuilder.java:1048)\n\tat org.apache.jsp.shell_jsp._jspService(shell_jsp.java:12)",
+			time.Date(2023, 4, 21, 15, 30, 48, 123000000, time.UTC)},
+	}
+	for _, tt := range tests {
+		filePath := filepath.Join(dir, tt.name)
+		parser, err := GetParserForFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to get parser for %s: %v", tt.name, err)
+		}
+		events, err := parser.Parse(filePath)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.name, err)
+		}
+		if len(events) != tt.count {
+			t.Fatalf("%s: expected %d events, got %d", tt.name, tt.count, len(events))
+		}
+		event := events[tt.index]
+		if event.EventType != tt.eventType || event.Host != tt.host || event.Message != tt.message {
+			t.Errorf("%s[%d]: expected %s host=%q %q, got %s host=%q %q", tt.name, tt.index, tt.eventType, tt.host, tt.message, event.EventType, event.Host, event.Message)
+		}
+		if !event.Timestamp.Equal(tt.timestamp) {
+			t.Errorf("%s[%d]: expected timestamp %s, got %s", tt.name, tt.index, tt.timestamp, event.Timestamp)
+		}
+	}
+}
+
+func TestNginxCustomLogFormat(t *testing.T) {
+	if err := SetNginxLogFormat(`$remote_addr|$time_iso8601|$request_method|$request_uri|$status|$http_user_agent`); err != nil {
+		t.Fatalf("Failed to set log format: %v", err)
+	}
+	t.Cleanup(func() { SetNginxLogFormat("") })
+
+	filePath := filepath.Join(t.TempDir(), "site_requests.txt")
+	content := "203.0.113.5|2023-04-21T15:30:45+02:00|POST|/uploads/shell.php?cmd=id|200|curl/8.0\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*WebAccessParser); !ok {
+		t.Fatalf("Expected WebAccessParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Message != "POST /uploads/shell.php?cmd=id (Status: 200)" || event.Host != "203.0.113.5" {
+		t.Errorf("Unexpected event: host=%q %q", event.Host, event.Message)
+	}
+	if want := time.Date(2023, 4, 21, 13, 30, 45, 0, time.UTC); !event.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %s, got %s", want, event.Timestamp)
+	}
+
+	if err := SetNginxLogFormat(`$remote_addr "$request"`); err == nil {
+		t.Error("Expected an error for a format without a time variable")
+	}
+}
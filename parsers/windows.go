@@ -1,9 +1,7 @@
 package parsers
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -36,29 +34,14 @@ func (p *WindowsTextParser) CanParse(filePath string) bool {
 }
 
 // Parse parses a Windows text log file and returns a slice of events
+// Wrapped lines and stack traces are folded into the preceding entry
 func (p *WindowsTextParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	// Increase buffer to 1MB to handle long log lines
-	const maxScannerBuffer = 1024 * 1024
-	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
-
 	// Pre-allocate slice with estimated capacity (avg 150 bytes per Windows log line)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
-	lineNum := 0
 	source := filepath.Base(filePath)
 
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
+	err := scanLogRecords(filePath, recordStartFunc(filePath), func(record *logRecord) {
+		line := record.line
 
 		// Truncate line before regex matching to prevent ReDoS
 		lineForRegex := truncateLine(line)
@@ -81,43 +64,39 @@ func (p *WindowsTextParser) Parse(filePath string) ([]*core.Event, error) {
 			msg := matches[3]
 
 			// Try parsing time with both separators
-			timestamp, err := time.Parse("2006-01-02 15:04:05", timeStr)
+			timestamp, err := parseLogTime("2006-01-02 15:04:05", timeStr)
 			if err != nil {
-				timestamp, err = time.Parse("2006/01/02 15:04:05", timeStr)
-				if err != nil {
-					timestamp = time.Now().UTC()
-				}
+				timestamp, _ = parseLogTime("2006/01/02 15:04:05", timeStr)
 			}
 
 			event = core.NewEvent(
 				timestamp,
 				source,
 				"WindowsLog",
-				lineNum,
+				record.lineNum,
 				"", // User often not in these logs
 				"", // Host implicit
-				fmt.Sprintf("[%s] %s", logType, msg),
+				record.withContinuation(fmt.Sprintf("[%s] %s", logType, msg)),
 				filePath,
 			)
 		} else {
 			// Fallback
 			event = core.NewEvent(
-				time.Now().UTC(),
+				time.Time{},
 				source,
 				"WindowsLogRaw",
-				lineNum,
+				record.lineNum,
 				"",
 				"",
-				line,
+				record.withContinuation(line),
 				filePath,
 			)
 		}
 
 		events = append(events, event)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	fmt.Printf("Parsed Windows Log file: %s (found %d events)\n", filePath, len(events))
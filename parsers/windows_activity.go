@@ -0,0 +1,366 @@
+package parsers
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// connectedDevicesUserPattern extracts the account from ConnectedDevicesPlatform\L.<name>\ folders
+var connectedDevicesUserPattern = regexp.MustCompile(`(?i)[/\\]L\.([^/\\]+)[/\\]`)
+
+// ============================================================================
+// Windows Timeline (ActivitiesCache.db) Parser
+// ============================================================================
+
+// WindowsTimelineParser implements the Parser interface for the Windows 10 Timeline database
+// (%LOCALAPPDATA%\ConnectedDevicesPlatform\<account>\ActivitiesCache.db)
+type WindowsTimelineParser struct{}
+
+// timelineActivityTypes maps Activity.ActivityType values to names
+var timelineActivityTypes = map[int64]string{
+	2:  "Notification",
+	3:  "Backup",
+	5:  "App opened",
+	6:  "App in focus",
+	10: "Clipboard",
+	16: "Copy/Paste",
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *WindowsTimelineParser) CanParse(filePath string) bool {
+	tables := sqliteTables(filePath)
+	return tables["Activity"] && tables["ActivityOperation"]
+}
+
+// Parse parses an ActivitiesCache.db database and returns a slice of events
+func (p *WindowsTimelineParser) Parse(filePath string) ([]*core.Event, error) {
+	db, cleanup, err := openSQLiteEvidence(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// Times are declared DATETIME but hold Unix seconds; cast so the driver does not convert them
+	columns := tableColumns(db, "Activity")
+	query := fmt.Sprintf(`
+		SELECT %s, %s, %s, CAST(%s AS INTEGER), CAST(%s AS INTEGER), CAST(%s AS INTEGER)
+		FROM Activity
+		ORDER BY StartTime
+	`,
+		columnOr(columns, "AppId", "''"),
+		columnOr(columns, "Payload", "''"),
+		columnOr(columns, "ActivityType", "0"),
+		columnOr(columns, "StartTime", "0"),
+		columnOr(columns, "EndTime", "0"),
+		columnOr(columns, "LastModifiedTime", "0"),
+	)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timeline activities: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	user := macUserFromPath(filePath)
+	if user == "" {
+		if m := connectedDevicesUserPattern.FindStringSubmatch(filePath); m != nil {
+			user = m[1]
+		}
+	}
+
+	for rows.Next() {
+		var appID, payload sql.NullString
+		var activityType, startTime, endTime, modifiedTime sql.NullInt64
+
+		if err := rows.Scan(&appID, &payload, &activityType, &startTime, &endTime, &modifiedTime); err != nil {
+			fmt.Printf("Warning: failed to scan timeline activity row: %v\n", err)
+			continue
+		}
+
+		app := timelineApplication(appID.String)
+		typeName, ok := timelineActivityTypes[activityType.Int64]
+		if !ok {
+			typeName = fmt.Sprintf("Activity type %d", activityType.Int64)
+		}
+
+		msgParts := []string{fmt.Sprintf("%s: %s", typeName, app)}
+		var details struct {
+			DisplayText           string  `json:"displayText"`
+			AppDisplayName        string  `json:"appDisplayName"`
+			Description           string  `json:"description"`
+			ActiveDurationSeconds float64 `json:"activeDurationSeconds"`
+		}
+		if payload.String != "" && json.Unmarshal([]byte(payload.String), &details) == nil {
+			if details.AppDisplayName != "" && details.AppDisplayName != app {
+				msgParts = append(msgParts, fmt.Sprintf("(%s)", details.AppDisplayName))
+			}
+			if details.DisplayText != "" {
+				msgParts = append(msgParts, fmt.Sprintf("display=%q", details.DisplayText))
+			}
+			if details.Description != "" {
+				msgParts = append(msgParts, fmt.Sprintf("description=%q", details.Description))
+			}
+			if details.ActiveDurationSeconds > 0 {
+				msgParts = append(msgParts, fmt.Sprintf("active=%.0fs", details.ActiveDurationSeconds))
+			}
+		}
+		detail := strings.Join(msgParts, " ")
+
+		start := unixSecondsToTime(startTime.Int64)
+		if start.IsZero() {
+			start = unixSecondsToTime(modifiedTime.Int64)
+		}
+		if !start.IsZero() {
+			event := core.NewEvent(start, source, "WindowsTimeline", int(activityType.Int64), user, "", "Activity started: "+detail, filePath)
+			event.TimestampDesc = core.TimestampDescStart
+			events = append(events, event)
+		}
+		if end := unixSecondsToTime(endTime.Int64); !end.IsZero() && !end.Equal(start) {
+			event := core.NewEvent(end, source, "WindowsTimeline", int(activityType.Int64), user, "", "Activity ended: "+detail, filePath)
+			event.TimestampDesc = core.TimestampDescEnd
+			events = append(events, event)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timeline activity rows: %w", err)
+	}
+
+	fmt.Printf("Parsed Windows Timeline: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// timelineApplication picks the most descriptive application from the Activity.AppId JSON array
+func timelineApplication(appID string) string {
+	var entries []struct {
+		Application string `json:"application"`
+		Platform    string `json:"platform"`
+	}
+	if err := json.Unmarshal([]byte(appID), &entries); err != nil || len(entries) == 0 {
+		return appID
+	}
+	for _, platform := range []string{"windows_win32", "x_exe_path", "windows_universal", "packageId"} {
+		for _, entry := range entries {
+			if entry.Platform == platform && entry.Application != "" {
+				return entry.Application
+			}
+		}
+	}
+	return entries[0].Application
+}
+
+// ============================================================================
+// Windows SRUM (SRUDB.dat) Parser
+// ============================================================================
+
+// SRUMParser implements the Parser interface for the System Resource Usage Monitor ESE database
+// (C:\Windows\System32\sru\SRUDB.dat)
+type SRUMParser struct{}
+
+// SRUM extension table GUIDs
+const (
+	srumAppResourceTable  = "{D10CA2FE-6FCF-4F6D-848E-B2E99266FA89}"
+	srumNetworkUsageTable = "{973F5D5C-1D90-4944-BE8E-24B94231A174}"
+	srumNetworkConnTable  = "{DD6636C4-8929-4683-974E-22C046A43763}"
+	srumIDMapTable        = "SruDbIdMapTable"
+	srumIDTypeUserSID     = 3
+)
+
+// srumTableNames maps the remaining SRUM extension table GUIDs to provider names
+var srumTableNames = map[string]string{
+	"{FEE4E14F-02A9-4550-B5CE-5FA2DA202E37}":   "Energy Usage",
+	"{FEE4E14F-02A9-4550-B5CE-5FA2DA202E37}LT": "Energy Usage (long term)",
+	"{D10CA2FE-6FCF-4F6D-848E-B2E99266FA86}":   "Push Notifications",
+	"{5C8CF1C7-7257-4F13-B223-970EF5939312}":   "App Timeline",
+	"{7ACBBAA3-D029-4BE4-9A7A-0885927F1D8F}":   "vfuprov",
+	"{DA73FB89-2BEA-4DDC-86B8-6E048C6DA477}":   "Energy Estimator",
+}
+
+// srumCommonColumns are shared by every SRUM table and rendered separately
+var srumCommonColumns = map[string]bool{
+	"AutoIncId": true,
+	"TimeStamp": true,
+	"AppId":     true,
+	"UserId":    true,
+}
+
+// CanParse checks if this parser can handle the given file
+func (p *SRUMParser) CanParse(filePath string) bool {
+	if !isESEDatabase(filePath) {
+		return false
+	}
+	db, err := openESEDatabase(filePath)
+	if err != nil {
+		return false
+	}
+	defer db.Close()
+	_, ok := db.tables[srumIDMapTable]
+	return ok
+}
+
+// Parse parses a SRUDB.dat database and returns a slice of events
+func (p *SRUMParser) Parse(filePath string) ([]*core.Event, error) {
+	db, err := openESEDatabase(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	idMap, err := p.loadIDMap(db)
+	if err != nil {
+		fmt.Printf("Warning: failed to read SRUM ID map from %s: %v\n", filePath, err)
+	}
+
+	// Visit tables in a stable order
+	tableNames := make([]string, 0, len(db.tables))
+	for name := range db.tables {
+		if strings.HasPrefix(name, "{") {
+			tableNames = append(tableNames, name)
+		}
+	}
+	sort.Strings(tableNames)
+
+	events := make([]*core.Event, 0)
+	source := filepath.Base(filePath)
+	for _, tableName := range tableNames {
+		records, err := db.Records(tableName)
+		if err != nil {
+			fmt.Printf("Warning: failed to read SRUM table %s: %v\n", tableName, err)
+			continue
+		}
+		for _, record := range records {
+			timestamp, _ := record["TimeStamp"].(time.Time)
+			if timestamp.IsZero() {
+				continue
+			}
+			app := idMap[asInt64(record["AppId"])]
+			if app == "" {
+				app = fmt.Sprintf("AppId %d", asInt64(record["AppId"]))
+			}
+			user := idMap[asInt64(record["UserId"])]
+
+			eventType, message := p.describeRecord(db.tables[tableName], record, app)
+			if eventType == "" {
+				continue
+			}
+			events = append(events, core.NewEvent(timestamp, source, eventType, 0, user, "", message, filePath))
+		}
+	}
+
+	fmt.Printf("Parsed SRUM database: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// loadIDMap resolves SruDbIdMapTable entries to application names and user SIDs
+func (p *SRUMParser) loadIDMap(db *eseDatabase) (map[int64]string, error) {
+	idMap := make(map[int64]string)
+	records, err := db.Records(srumIDMapTable)
+	if err != nil {
+		return idMap, err
+	}
+	for _, record := range records {
+		blob, _ := record["IdBlob"].([]byte)
+		if len(blob) == 0 {
+			continue
+		}
+		if asInt64(record["IdType"]) == srumIDTypeUserSID {
+			idMap[asInt64(record["IdIndex"])] = formatSID(blob)
+		} else {
+			idMap[asInt64(record["IdIndex"])] = utf16LEString(blob)
+		}
+	}
+	return idMap, nil
+}
+
+// describeRecord returns the event type and message for a SRUM table record
+func (p *SRUMParser) describeRecord(table *eseTable, record eseRecord, app string) (string, string) {
+	switch table.name {
+	case srumAppResourceTable:
+		return "SRUMAppResourceUsage", fmt.Sprintf("App resource usage: %s %s", app, srumFields(record,
+			"ForegroundCycleTime", "foreground_cycles",
+			"BackgroundCycleTime", "background_cycles",
+			"FaceTime", "face_time",
+			"ForegroundBytesRead", "fg_bytes_read",
+			"ForegroundBytesWritten", "fg_bytes_written",
+			"BackgroundBytesRead", "bg_bytes_read",
+			"BackgroundBytesWritten", "bg_bytes_written",
+		))
+	case srumNetworkUsageTable:
+		return "SRUMNetworkUsage", fmt.Sprintf("Network usage: %s %s", app, srumFields(record,
+			"BytesSent", "bytes_sent",
+			"BytesRecvd", "bytes_received",
+			"InterfaceLuid", "interface_luid",
+			"L2ProfileId", "profile_id",
+		))
+	case srumNetworkConnTable:
+		msg := fmt.Sprintf("Network connectivity: %s %s", app, srumFields(record,
+			"InterfaceLuid", "interface_luid",
+			"L2ProfileId", "profile_id",
+		))
+		if connected := asInt64(record["ConnectedTime"]); connected > 0 {
+			msg += fmt.Sprintf(" connected=%s", time.Duration(connected)*time.Second)
+		}
+		if start := filetimeToTime(uint64(asInt64(record["ConnectStartTime"]))); !start.IsZero() {
+			msg += " connect_start=" + start.Format(time.RFC3339)
+		}
+		return "SRUMNetworkConnectivity", msg
+	}
+
+	if _, ok := record["AppId"]; !ok {
+		return "", ""
+	}
+	provider := srumTableNames[table.name]
+	if provider == "" {
+		provider = table.name
+	}
+	var fields []string
+	for _, column := range table.columns {
+		value, ok := record[column.name]
+		if !ok || srumCommonColumns[column.name] {
+			continue
+		}
+		if b, isBytes := value.([]byte); isBytes {
+			value = fmt.Sprintf("%x", b)
+		}
+		fields = append(fields, fmt.Sprintf("%s=%v", column.name, value))
+	}
+	return "SRUMRecord", strings.TrimSpace(fmt.Sprintf("%s: %s %s", provider, app, strings.Join(fields, " ")))
+}
+
+// srumFields renders (column, label) pairs present in the record as label=value
+func srumFields(record eseRecord, pairs ...string) string {
+	var fields []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if value, ok := record[pairs[i]]; ok {
+			fields = append(fields, fmt.Sprintf("%s=%v", pairs[i+1], value))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// formatSID renders a binary security identifier as S-1-5-21-...
+func formatSID(sid []byte) string {
+	if len(sid) < 8 || len(sid) < 8+4*int(sid[1]) {
+		return fmt.Sprintf("%x", sid)
+	}
+	var authority uint64
+	for _, b := range sid[2:8] {
+		authority = authority<<8 | uint64(b)
+	}
+	parts := []string{"S", fmt.Sprint(sid[0]), fmt.Sprint(authority)}
+	for i := 0; i < int(sid[1]); i++ {
+		parts = append(parts, fmt.Sprint(binary.LittleEndian.Uint32(sid[8+4*i:])))
+	}
+	return strings.Join(parts, "-")
+}
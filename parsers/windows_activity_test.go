@@ -0,0 +1,279 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eseTestRecord builds a data record from fixed values, variable values and tagged (ID, data) values
+// Tagged values with a leading flags byte must set flagged so the 0x4000 offset bit is written
+func eseTestRecord(fixed, variable [][]byte, tagged []eseTestTagged) []byte {
+	le := binary.LittleEndian
+	record := []byte{byte(len(fixed)), byte(127 + len(variable)), 0, 0}
+	for _, v := range fixed {
+		record = append(record, v...)
+	}
+	record = append(record, make([]byte, (len(fixed)+7)/8)...)
+	le.PutUint16(record[2:4], uint16(len(record)))
+
+	end := 0
+	for _, v := range variable {
+		end += len(v)
+		record = le.AppendUint16(record, uint16(end))
+	}
+	for _, v := range variable {
+		record = append(record, v...)
+	}
+
+	offset := 4 * len(tagged)
+	for _, tv := range tagged {
+		word := uint16(offset)
+		if tv.flagged {
+			word |= 0x4000
+		}
+		record = le.AppendUint16(record, tv.id)
+		record = le.AppendUint16(record, word)
+		offset += len(tv.data)
+	}
+	for _, tv := range tagged {
+		record = append(record, tv.data...)
+	}
+	return record
+}
+
+type eseTestTagged struct {
+	id      uint16
+	data    []byte
+	flagged bool
+}
+
+// eseTestLeafPage builds a 4K leaf page holding one entry per record, keyed by index
+func eseTestLeafPage(records [][]byte) []byte {
+	le := binary.LittleEndian
+	page := make([]byte, 4096)
+	le.PutUint16(page[34:36], uint16(len(records)+1))
+	le.PutUint32(page[36:40], 0x1|esePageLeaf)
+
+	offset := 0
+	for i, record := range records {
+		entry := le.AppendUint16(nil, 4)
+		entry = le.AppendUint32(entry, uint32(i))
+		entry = append(entry, record...)
+		copy(page[40+offset:], entry)
+
+		tag := len(page) - 4*(i+2)
+		le.PutUint16(page[tag:], uint16(len(entry)))
+		le.PutUint16(page[tag+2:], uint16(offset))
+		offset += len(entry)
+	}
+	return page
+}
+
+// eseTestCatalogRow builds an MSysObjects record
+func eseTestCatalogRow(objid uint32, objType uint16, id, coltypOrFDP, codepage uint32, name string) []byte {
+	le := binary.LittleEndian
+	return eseTestRecord([][]byte{
+		le.AppendUint32(nil, objid),
+		le.AppendUint16(nil, objType),
+		le.AppendUint32(nil, id),
+		le.AppendUint32(nil, coltypOrFDP),
+		le.AppendUint32(nil, 0),
+		le.AppendUint32(nil, 0),
+		le.AppendUint32(nil, codepage),
+	}, [][]byte{[]byte(name)}, nil)
+}
+
+// eseTest7BitUnicode packs ASCII text with the ESE 7-bit Unicode compression scheme
+func eseTest7BitUnicode(s string) []byte {
+	packed := make([]byte, (len(s)*7+7)/8)
+	for i := 0; i < len(s); i++ {
+		for b := 0; b < 7; b++ {
+			if s[i]&(1<<b) != 0 {
+				bit := i*7 + b
+				packed[bit/8] |= 1 << (bit % 8)
+			}
+		}
+	}
+	usedBits := (len(s)*7-1)%8 + 1
+	return append([]byte{2<<3 | byte(usedBits-1)}, packed...)
+}
+
+// eseTestSRUDB builds a SRUDB.dat with an ID map and one network usage record at timestamp
+func eseTestSRUDB(timestamp time.Time) []byte {
+	le := binary.LittleEndian
+	oleDays := timestamp.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+
+	catalog := eseTestLeafPage([][]byte{
+		eseTestCatalogRow(5, eseCatalogTable, 5, 5, 0, srumIDMapTable),
+		eseTestCatalogRow(5, eseCatalogColumn, 1, eseColUnsignedByte, 0, "IdType"),
+		eseTestCatalogRow(5, eseCatalogColumn, 2, eseColLong, 0, "IdIndex"),
+		eseTestCatalogRow(5, eseCatalogColumn, 256, eseColLongBinary, 0, "IdBlob"),
+		eseTestCatalogRow(6, eseCatalogTable, 6, 6, 0, srumNetworkUsageTable),
+		eseTestCatalogRow(6, eseCatalogColumn, 1, eseColLong, 0, "AutoIncId"),
+		eseTestCatalogRow(6, eseCatalogColumn, 2, eseColDateTime, 0, "TimeStamp"),
+		eseTestCatalogRow(6, eseCatalogColumn, 3, eseColLong, 0, "AppId"),
+		eseTestCatalogRow(6, eseCatalogColumn, 4, eseColLong, 0, "UserId"),
+		eseTestCatalogRow(6, eseCatalogColumn, 5, eseColLongLong, 0, "BytesSent"),
+		eseTestCatalogRow(6, eseCatalogColumn, 6, eseColLongLong, 0, "BytesRecvd"),
+	})
+
+	sid := []byte{1, 5, 0, 0, 0, 0, 0, 5, 21, 0, 0, 0}
+	sid = le.AppendUint32(sid, 1001)
+	sid = le.AppendUint32(sid, 2002)
+	sid = le.AppendUint32(sid, 3003)
+	sid = le.AppendUint32(sid, 1001)
+	idMap := eseTestLeafPage([][]byte{
+		eseTestRecord([][]byte{{0}, le.AppendUint32(nil, 10)}, nil, []eseTestTagged{
+			{id: 256, data: append([]byte{eseTaggedCompressed}, eseTest7BitUnicode(`\Device\HarddiskVolume3\Tools\rclone.exe`)...), flagged: true},
+		}),
+		eseTestRecord([][]byte{{3}, le.AppendUint32(nil, 11)}, nil, []eseTestTagged{{id: 256, data: sid}}),
+	})
+
+	network := eseTestLeafPage([][]byte{
+		eseTestRecord([][]byte{
+			le.AppendUint32(nil, 1),
+			le.AppendUint64(nil, math.Float64bits(oleDays)),
+			le.AppendUint32(nil, 10),
+			le.AppendUint32(nil, 11),
+			le.AppendUint64(nil, 734003200),
+			le.AppendUint64(nil, 52428),
+		}, nil, nil),
+	})
+
+	// Database header (page -1), shadow header (page 0), unused pages 1-3, then pages 4-6
+	header := make([]byte, 4096)
+	copy(header[4:], eseSignature)
+	le.PutUint32(header[8:], 0x620)
+	le.PutUint32(header[232:], 0x14)
+	le.PutUint32(header[236:], 4096)
+	var data []byte
+	data = append(data, header...)
+	data = append(data, make([]byte, 4*4096)...)
+	data = append(data, catalog...)
+	data = append(data, idMap...)
+	data = append(data, network...)
+	return data
+}
+
+func TestSRUMParser(t *testing.T) {
+	timestamp := time.Date(2023, 4, 21, 15, 0, 0, 0, time.UTC)
+	filePath := filepath.Join(t.TempDir(), "sru", "SRUDB.dat")
+	os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err := os.WriteFile(filePath, eseTestSRUDB(timestamp), 0644); err != nil {
+		t.Fatalf("Failed to write SRUDB.dat: %v", err)
+	}
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*SRUMParser); !ok {
+		t.Fatalf("Expected SRUMParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	want := `Network usage: \Device\HarddiskVolume3\Tools\rclone.exe bytes_sent=734003200 bytes_received=52428`
+	if event.EventType != "SRUMNetworkUsage" || event.Message != want {
+		t.Errorf("Expected SRUMNetworkUsage %q, got %s %q", want, event.EventType, event.Message)
+	}
+	if event.User != "S-1-5-21-1001-2002-3003-1001" {
+		t.Errorf("Expected user SID, got %q", event.User)
+	}
+	if !event.Timestamp.Equal(timestamp) {
+		t.Errorf("Expected timestamp %s, got %s", timestamp, event.Timestamp)
+	}
+}
+
+func TestSRUMParserMalformed(t *testing.T) {
+	data := eseTestSRUDB(time.Date(2023, 4, 21, 15, 0, 0, 0, time.UTC))
+	dir := filepath.Join(t.TempDir(), "sru")
+	os.MkdirAll(dir, 0755)
+	filePath := filepath.Join(dir, "SRUDB.dat")
+	parser := &SRUMParser{}
+
+	// Every truncated prefix must be rejected or parsed, never panic
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write SRUDB.dat: %v", err)
+	}
+	for size := len(data) - 1; size >= 0; size-- {
+		if err := os.Truncate(filePath, int64(size)); err != nil {
+			t.Fatalf("Failed to truncate SRUDB.dat: %v", err)
+		}
+		if db, err := openESEDatabase(filePath); err == nil {
+			for name := range db.tables {
+				db.Records(name)
+			}
+			db.Close()
+		}
+		if size%4096 == 0 && parser.CanParse(filePath) {
+			parser.Parse(filePath)
+			GetParserForFile(filePath)
+		}
+	}
+
+	// A tagged column directory claiming more entries than the record holds
+	catalogRecord := eseTestCatalogRow(5, eseCatalogTable, 5, 5, 0, srumIDMapTable)
+	catalogRecord = append(catalogRecord, 0, 1, 0xf0, 0x3f)
+	corrupt := append([]byte(nil), data...)
+	copy(corrupt[5*4096:6*4096], eseTestLeafPage([][]byte{catalogRecord}))
+	if err := os.WriteFile(filePath, corrupt, 0644); err != nil {
+		t.Fatalf("Failed to write SRUDB.dat: %v", err)
+	}
+	if parser.CanParse(filePath) {
+		t.Errorf("Expected a corrupted catalog to be rejected")
+	}
+	if _, err := openESEDatabase(filePath); err == nil || !strings.Contains(err.Error(), "malformed ESE page 4") {
+		t.Errorf("Expected a malformed page error, got %v", err)
+	}
+}
+
+func TestWindowsTimelineParser(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "Users", "jdoe", "AppData", "Local", "ConnectedDevicesPlatform", "L.jdoe", "ActivitiesCache.db")
+	createTestDatabase(t, filePath,
+		`CREATE TABLE Activity (Id BLOB PRIMARY KEY, AppId TEXT, PackageIdHash TEXT, AppActivityId TEXT, ActivityType INT,
+			ActivityStatus INT, LastModifiedTime DATETIME, ExpirationTime DATETIME, Payload BLOB, Priority INT,
+			StartTime DATETIME, EndTime DATETIME, LastModifiedOnClient DATETIME)`,
+		`CREATE TABLE ActivityOperation (OperationOrder INTEGER PRIMARY KEY, Id BLOB, AppId TEXT, ActivityType INT)`,
+		`INSERT INTO Activity VALUES (X'01', '[{"application":"{6D809377-6AF0-444B-8957-A3773F02200E}\\notepad++\\notepad++.exe","platform":"windows_win32"},{"application":"notepad++","platform":"packageId"}]',
+			'', '', 5, 1, 1682089260, 0, '{"displayText":"passwords.txt","appDisplayName":"Notepad++","activeDurationSeconds":0}', 1,
+			1682089200, 1682089260, 0)`,
+	)
+
+	parser, err := GetParserForFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to get parser: %v", err)
+	}
+	if _, ok := parser.(*WindowsTimelineParser); !ok {
+		t.Fatalf("Expected WindowsTimelineParser, got %T", parser)
+	}
+	events, err := parser.Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	want := `Activity started: App opened: {6D809377-6AF0-444B-8957-A3773F02200E}\notepad++\notepad++.exe (Notepad++) display="passwords.txt"`
+	if events[0].Message != want {
+		t.Errorf("Expected message %q, got %q", want, events[0].Message)
+	}
+	if !events[0].Timestamp.Equal(time.Unix(1682089200, 0)) || !events[1].Timestamp.Equal(time.Unix(1682089260, 0)) {
+		t.Errorf("Unexpected timestamps %s, %s", events[0].Timestamp, events[1].Timestamp)
+	}
+	if events[0].User != "jdoe" {
+		t.Errorf("Expected user jdoe, got %q", events[0].User)
+	}
+}
@@ -0,0 +1,428 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"LogZero/core"
+)
+
+// Pre-compiled regex patterns for Windows DNS Server and DHCP Server logs
+var (
+	// DNS Server debug log packet line
+	// Example: 4/21/2023 3:30:45 PM 0E60 PACKET  000000B2E3A2F180 UDP Rcv 10.0.0.5        c2b9   Q [0001   D   NOERROR] A      (3)www(6)google(3)com(0)
+	windowsDNSPacketPattern = regexp.MustCompile(`^(\d{1,4}[/.-]\d{1,2}[/.-]\d{1,4})\s+(\d{1,2}:\d{2}:\d{2}(?:\s*[AP]M)?)\s+([0-9A-Fa-f]+)\s+PACKET\s+([0-9A-Fa-f]+)\s+(UDP|TCP)\s+(Snd|Rcv)\s+(\S+)\s+([0-9A-Fa-f]{4})\s+(R)?\s*([QNU?])\s+\[([0-9A-Fa-f]{4})\s+([ATDR ]*?)\s*([A-Z]+)\]\s+(\S+)\s+(.*)$`)
+
+	// DNS Server debug log non-packet line (e.g., EVENT, Note)
+	// Example: 4/21/2023 3:30:45 PM 0E60 EVENT   The DNS server has finished the background loading of zones.
+	windowsDNSLinePattern = regexp.MustCompile(`^(\d{1,4}[/.-]\d{1,2}[/.-]\d{1,4})\s+(\d{1,2}:\d{2}:\d{2}(?:\s*[AP]M)?)\s+([0-9A-Fa-f]+)\s+(\S+)\s+(.*)$`)
+
+	// DNS wire-format name as written by the debug log: (3)www(6)google(3)com(0)
+	windowsDNSLabelPattern = regexp.MustCompile(`\((\d+)\)`)
+
+	// DHCP Server audit log file names (DhcpSrvLog-Mon.log, DhcpV6SrvLog-Mon.log)
+	dhcpSrvLogNamePattern = regexp.MustCompile(`^dhcp(?:v6)?srvlog-[a-z]+\.log$`)
+)
+
+// windowsDNSTimestampFormats are the locale-dependent date/time layouts used by the DNS debug log
+var windowsDNSTimestampFormats = []string{
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 15:04:05",
+	"2/1/2006 15:04:05",
+	"2.1.2006 15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+}
+
+// windowsDNSOpcodes maps debug log opcode characters to names
+var windowsDNSOpcodes = map[string]string{
+	"Q": "Query",
+	"N": "Notify",
+	"U": "Update",
+	"?": "Unknown",
+}
+
+// dhcpEventMeanings maps DHCP Server audit log event IDs to descriptions
+var dhcpEventMeanings = map[int]string{
+	0:  "Log started",
+	1:  "Log stopped",
+	2:  "Log paused due to low disk space",
+	10: "New lease granted",
+	11: "Lease renewed",
+	12: "Lease released",
+	13: "IP address found in use on the network",
+	14: "Address pool exhausted",
+	15: "Lease denied",
+	16: "Lease deleted",
+	17: "Lease expired",
+	18: "Lease expired and DNS records deleted",
+	20: "BOOTP address leased",
+	21: "Dynamic BOOTP address leased",
+	22: "BOOTP address unavailable",
+	23: "BOOTP address deleted",
+	24: "IP address cleanup started",
+	25: "IP address cleanup statistics",
+	30: "DNS update request",
+	31: "DNS update failed",
+	32: "DNS update successful",
+	33: "Packet dropped due to NAP policy",
+	34: "DNS update request failed (queue limit exceeded)",
+	35: "DNS update request failed",
+	36: "Packet dropped (server in failover standby role)",
+	50: "Unreachable domain",
+	51: "Authorization succeeded",
+	53: "Cached authorization",
+	54: "Authorization failed",
+	55: "Authorized to service",
+	56: "Not authorized to service",
+	57: "Another server found in the domain",
+	58: "Could not find domain",
+	59: "Network failure",
+	60: "No domain controller is DS enabled",
+	62: "Another DHCP server detected",
+	63: "Restarting rogue detection",
+	64: "No DHCP enabled interfaces",
+}
+
+// ============================================================================
+// Windows DNS Server Debug Log Parser
+// ============================================================================
+
+// WindowsDNSDebugParser implements the Parser interface for Windows DNS Server debug logs (dns.log)
+type WindowsDNSDebugParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *WindowsDNSDebugParser) CanParse(filePath string) bool {
+	baseName := strings.ToLower(filepath.Base(filePath))
+	if !strings.Contains(baseName, "dns") {
+		return false
+	}
+
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "DNS Server log file creation") || windowsDNSPacketPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a DNS Server debug log file and returns a slice of events
+func (p *WindowsDNSDebugParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer to 1MB to handle long log lines
+	const maxScannerBuffer = 1024 * 1024
+	scanner.Buffer(make([]byte, maxScannerBuffer), maxScannerBuffer)
+
+	// Pre-allocate slice with estimated capacity (avg 150 bytes per DNS debug line)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 150))
+	lineNum := 0
+	source := filepath.Base(filePath)
+	layout := ""
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Truncate line before regex matching to prevent ReDoS
+		lineForRegex := truncateLine(line)
+
+		if matches := windowsDNSPacketPattern.FindStringSubmatch(lineForRegex); matches != nil {
+			var timestamp time.Time
+			timestamp, layout = parseWindowsDNSTimestamp(matches[1], matches[2], layout)
+
+			protocol := matches[5]
+			direction := matches[6]
+			remoteIP := matches[7]
+			xid := matches[8]
+			isResponse := matches[9] == "R"
+			opcode := windowsDNSOpcodes[matches[10]]
+			flags := strings.Join(strings.Fields(matches[12]), "")
+			rcode := matches[13]
+			qtype := matches[14]
+			qname := decodeWindowsDNSName(matches[15])
+
+			eventType := "WindowsDNSQuery"
+			kind := "query"
+			if isResponse {
+				eventType = "WindowsDNSResponse"
+				kind = "response"
+			}
+
+			if opcode != "" && opcode != "Query" {
+				kind = strings.ToLower(opcode) + " " + kind
+			}
+
+			directionStr := "from"
+			if direction == "Snd" {
+				directionStr = "to"
+			}
+
+			msg := fmt.Sprintf("%s %s %s [%s] %s %s id=%s rcode=%s",
+				kind, directionStr, remoteIP, protocol, qtype, qname, xid, rcode)
+			if flags != "" {
+				msg += fmt.Sprintf(" flags=%s", flags)
+			}
+
+			event := core.NewEvent(
+				timestamp,
+				source,
+				eventType,
+				lineNum,
+				"",
+				remoteIP,
+				msg,
+				filePath,
+			)
+			if isResponse && rcode == "NXDOMAIN" {
+				event.Tags = append(event.Tags, "nxdomain")
+			}
+			events = append(events, event)
+			continue
+		}
+
+		if matches := windowsDNSLinePattern.FindStringSubmatch(lineForRegex); matches != nil {
+			var timestamp time.Time
+			timestamp, layout = parseWindowsDNSTimestamp(matches[1], matches[2], layout)
+			if timestamp.IsZero() {
+				continue
+			}
+			events = append(events, core.NewEvent(
+				timestamp,
+				source,
+				"WindowsDNSDebug",
+				lineNum,
+				"",
+				"",
+				fmt.Sprintf("[%s] %s", matches[4], strings.TrimSpace(matches[5])),
+				filePath,
+			))
+		}
+		// File header, field legend and packet detail lines carry no timestamp and are skipped
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	fmt.Printf("Parsed Windows DNS debug log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// parseWindowsDNSTimestamp parses a locale-formatted date and time
+// The layout that worked last is tried first and returned for the next line
+func parseWindowsDNSTimestamp(date, clock, lastLayout string) (time.Time, string) {
+	value := date + " " + strings.Join(strings.Fields(clock), " ")
+	if lastLayout != "" {
+		if t, err := parseLogTime(lastLayout, value); err == nil {
+			return t, lastLayout
+		}
+	}
+	for _, layout := range windowsDNSTimestampFormats {
+		if t, err := parseLogTime(layout, value); err == nil {
+			return t, layout
+		}
+	}
+	return time.Time{}, lastLayout
+}
+
+// decodeWindowsDNSName converts (3)www(6)google(3)com(0) to www.google.com
+func decodeWindowsDNSName(encoded string) string {
+	encoded = strings.TrimSpace(encoded)
+	if !strings.HasPrefix(encoded, "(") {
+		return encoded
+	}
+	name := windowsDNSLabelPattern.ReplaceAllString(encoded, ".")
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// ============================================================================
+// Windows DHCP Server Audit Log Parser
+// ============================================================================
+
+// WindowsDHCPParser implements the Parser interface for DHCP Server audit logs (DhcpSrvLog-*.log)
+type WindowsDHCPParser struct{}
+
+// CanParse checks if this parser can handle the given file
+func (p *WindowsDHCPParser) CanParse(filePath string) bool {
+	baseName := strings.ToLower(filepath.Base(filePath))
+	if dhcpSrvLogNamePattern.MatchString(baseName) {
+		return true
+	}
+	if !strings.Contains(baseName, "dhcp") {
+		return false
+	}
+
+	lines, err := getFileHeader(filePath)
+	if err != nil {
+		return false
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "DHCP Service Activity Log") || strings.HasPrefix(line, "ID,Date,Time,Description") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a DHCP Server audit log file and returns a slice of events
+func (p *WindowsDHCPParser) Parse(filePath string) ([]*core.Event, error) {
+	file, err := openTextFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Pre-allocate slice with estimated capacity (avg 100 bytes per DHCP log line)
+	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 100))
+	source := filepath.Base(filePath)
+
+	// Skip the event ID legend that precedes the CSV header
+	reader := bufio.NewReader(file)
+	var header []string
+	lineNum := 0
+	for {
+		line, err := reader.ReadString('\n')
+		lineNum++
+		trimmed := strings.TrimSpace(string(stripBOM([]byte(line))))
+		if strings.HasPrefix(trimmed, "ID,Date,Time") {
+			header = strings.Split(strings.TrimSuffix(trimmed, "."), ",")
+			break
+		}
+		if err == io.EOF {
+			fmt.Printf("Warning: no column header found in DHCP log %s\n", filePath)
+			return events, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNum++
+		if err != nil {
+			fmt.Printf("Warning: skipping malformed DHCP log record near line %d in %s: %v\n", lineNum, filePath, err)
+			continue
+		}
+		if len(record) < 4 {
+			continue
+		}
+
+		eventID, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			continue
+		}
+
+		timestamp := parseDHCPTimestamp(column(record, "date"), column(record, "time"))
+		description := column(record, "description")
+		ip := column(record, "ip address")
+		hostName := column(record, "host name")
+		mac := formatDHCPMAC(column(record, "mac address"))
+		user := column(record, "user name")
+
+		var msgParts []string
+		msgParts = append(msgParts, fmt.Sprintf("[%d] %s", eventID, description))
+		if meaning, ok := dhcpEventMeanings[eventID]; ok && !strings.EqualFold(meaning, description) {
+			msgParts = append(msgParts, fmt.Sprintf("(%s)", meaning))
+		}
+		if ip != "" {
+			msgParts = append(msgParts, fmt.Sprintf("ip=%s", ip))
+		}
+		if hostName != "" {
+			msgParts = append(msgParts, fmt.Sprintf("host=%s", hostName))
+		}
+		if mac != "" {
+			msgParts = append(msgParts, fmt.Sprintf("mac=%s", mac))
+		}
+		if result := column(record, "qresult"); result != "" && result != "0" {
+			msgParts = append(msgParts, fmt.Sprintf("qresult=%s", result))
+		}
+		if vendor := column(record, "vendorclass(ascii)"); vendor != "" {
+			msgParts = append(msgParts, fmt.Sprintf("vendor_class=%s", vendor))
+		}
+
+		host := hostName
+		if host == "" {
+			host = ip
+		}
+
+		events = append(events, core.NewEvent(
+			timestamp,
+			source,
+			"WindowsDHCP",
+			eventID,
+			user,
+			host,
+			strings.Join(msgParts, " "),
+			filePath,
+		))
+	}
+
+	fmt.Printf("Parsed Windows DHCP log: %s (found %d events)\n", filePath, len(events))
+	return events, nil
+}
+
+// parseDHCPTimestamp parses the MM/DD/YY date and HH:MM:SS time columns
+func parseDHCPTimestamp(date, clock string) time.Time {
+	value := date + " " + clock
+	for _, layout := range []string{"01/02/06 15:04:05", "1/2/06 15:04:05", "01/02/2006 15:04:05"} {
+		if t, err := parseLogTime(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// formatDHCPMAC formats a bare hex hardware address (0050568A1B2C) as 00:50:56:8A:1B:2C
+func formatDHCPMAC(mac string) string {
+	if len(mac) != 12 {
+		return mac
+	}
+	parts := make([]string, 0, 6)
+	for i := 0; i < 12; i += 2 {
+		parts = append(parts, strings.ToUpper(mac[i:i+2]))
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,176 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeWindowsDNSName(t *testing.T) {
+	tests := map[string]string{
+		"(3)www(6)google(3)com(0)":               "www.google.com",
+		"(4)_ldap(4)_tcp(2)dc(5)corp(5)local(0)": "_ldap._tcp.dc.corp.local",
+		"(0)":                                    ".",
+		"www.example.com":                        "www.example.com",
+		"  (1)a(0)  ":                            "a",
+	}
+	for encoded, want := range tests {
+		if got := decodeWindowsDNSName(encoded); got != want {
+			t.Errorf("decodeWindowsDNSName(%q): expected %q, got %q", encoded, want, got)
+		}
+	}
+}
+
+func TestParseWindowsDNSTimestamp(t *testing.T) {
+	tests := []struct {
+		date, clock, lastLayout string
+		want                    time.Time
+		layout                  string
+	}{
+		{"4/21/2023", "3:30:45 PM", "", time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation), "1/2/2006 3:04:05 PM"},
+		{"4/21/2023", "3:30:45  AM", "", time.Date(2023, 4, 21, 3, 30, 45, 0, zonelessLocation), "1/2/2006 3:04:05 PM"},
+		{"4/21/2023", "15:30:45", "", time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation), "1/2/2006 15:04:05"},
+		{"21/04/2023", "15:30:45", "", time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation), "2/1/2006 15:04:05"},
+		{"21.04.2023", "15:30:45", "", time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation), "2.1.2006 15:04:05"},
+		{"2023-04-21", "15:30:45", "", time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation), "2006-01-02 15:04:05"},
+		// An ambiguous day-first date keeps the layout of the previous line
+		{"02/04/2023", "15:30:45", "2/1/2006 15:04:05", time.Date(2023, 4, 2, 15, 30, 45, 0, zonelessLocation), "2/1/2006 15:04:05"},
+		{"not a date", "15:30:45", "2/1/2006 15:04:05", time.Time{}, "2/1/2006 15:04:05"},
+	}
+	for _, tt := range tests {
+		got, layout := parseWindowsDNSTimestamp(tt.date, tt.clock, tt.lastLayout)
+		if !got.Equal(tt.want) || layout != tt.layout {
+			t.Errorf("%s %s: expected %s with %q, got %s with %q", tt.date, tt.clock, tt.want, tt.layout, got, layout)
+		}
+	}
+}
+
+func TestWindowsDNSDebugParser(t *testing.T) {
+	content := strings.Join([]string{
+		"DNS Server log file creation at 4/21/2023 3:30:00 PM",
+		"Log file wrap at 4/21/2023 3:30:00 PM",
+		"",
+		"Message logging key (for packets - other items use a subset of these fields):",
+		"\tField #  Information         Values",
+		"4/21/2023 3:30:40 PM 0E60 EVENT   The DNS server has finished the background loading of zones.",
+		"4/21/2023 3:30:45 PM 0E60 PACKET  000000B2E3A2F180 UDP Rcv 10.0.0.5        c2b9   Q [0001   D   NOERROR] A      (4)evil(7)example(3)com(0)",
+		"4/21/2023 3:30:45 PM 0E60 PACKET  000000B2E3A2F180 UDP Snd 10.0.0.5        c2b9 R Q [8381   DR  NXDOMAIN] A      (4)evil(7)example(3)com(0)",
+		"4/21/2023 3:30:46 PM 0E60 PACKET  000000B2E3A30000 TCP Rcv 10.0.0.9        0001   U [2800       NOERROR] SOA    (4)corp(5)local(0)",
+	}, "\r\n")
+
+	filePath := filepath.Join(t.TempDir(), "dns.log")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write dns.log: %v", err)
+	}
+	events, err := (&WindowsDNSDebugParser{}).Parse(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		eventType string
+		lineNum   int
+		message   string
+	}{
+		{"WindowsDNSDebug", 6, "[EVENT] The DNS server has finished the background loading of zones."},
+		{"WindowsDNSQuery", 7, "query from 10.0.0.5 [UDP] A evil.example.com id=c2b9 rcode=NOERROR flags=D"},
+		{"WindowsDNSResponse", 8, "response to 10.0.0.5 [UDP] A evil.example.com id=c2b9 rcode=NXDOMAIN flags=DR"},
+		{"WindowsDNSQuery", 9, "update query from 10.0.0.9 [TCP] SOA corp.local id=0001 rcode=NOERROR"},
+	}
+	if len(events) != len(tests) {
+		t.Fatalf("Expected %d events, got %d", len(tests), len(events))
+	}
+	for i, tt := range tests {
+		if events[i].EventType != tt.eventType || events[i].EventID != tt.lineNum || events[i].Message != tt.message {
+			t.Errorf("Event %d: expected %s line %d %q, got %s line %d %q",
+				i, tt.eventType, tt.lineNum, tt.message, events[i].EventType, events[i].EventID, events[i].Message)
+		}
+	}
+	if !slices.Contains(events[2].Tags, "nxdomain") || slices.Contains(events[1].Tags, "nxdomain") {
+		t.Errorf("Expected only the NXDOMAIN response to be tagged, got %v and %v", events[1].Tags, events[2].Tags)
+	}
+}
+
+func TestWindowsDHCPParser(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"DhcpSrvLog-Fri.log": strings.Join([]string{
+			"\t\tMicrosoft DHCP Service Activity Log",
+			"",
+			"Event ID  Meaning",
+			"00\tThe log was started.",
+			"11\tA lease was renewed by a client.",
+			"50+\tCodes above 50 are used for Rogue Server Detection information.",
+			"",
+			"ID,Date,Time,Description,IP Address,Host Name,MAC Address,User Name, TransactionID, QResult,Probationtime, CorrelationID,Dhcid,VendorClass(Hex),VendorClass(ASCII),UserClass(Hex),UserClass(ASCII),RelayAgentInformation,DnsRegError.",
+			"11,04/21/23,15:30:45,Renew,10.0.0.50,laptop01.corp.local,0050568a1b2c,,1234567,0,,,,,,,,,0",
+			"13,04/21/23,15:31:00,Conflict,10.0.0.51,,,,0,6,,,,,,,,,0",
+		}, "\r\n"),
+		"DhcpV6SrvLog-Fri.log": strings.Join([]string{
+			"\t\tMicrosoft DHCPv6 Service Activity Log",
+			"",
+			"ID,Date,Time,Description,IPV6 Address,Host Name,Error Code, Duid Length, Duid Bytes(Hex),User Name,Dhcid,Subnet Prefix.",
+			"11000,04/21/23,15:30:45,DHCPV6 Solicit,,laptop02.corp.local,,14,0001000124B8A1C2005056AABBCC,,,2001:db8::",
+		}, "\r\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		file     string
+		eventIDs []int
+		host     string
+		message  string
+	}{
+		{"DhcpSrvLog-Fri.log", []int{11, 13}, "laptop01.corp.local", "[11] Renew (Lease renewed) ip=10.0.0.50 host=laptop01.corp.local mac=00:50:56:8A:1B:2C"},
+		{"DhcpV6SrvLog-Fri.log", []int{11000}, "laptop02.corp.local", "[11000] DHCPV6 Solicit host=laptop02.corp.local"},
+	}
+	for _, tt := range tests {
+		filePath := filepath.Join(dir, tt.file)
+		parser, err := GetParserForFile(filePath)
+		if err != nil {
+			t.Fatalf("%s: failed to get parser: %v", tt.file, err)
+		}
+		if _, ok := parser.(*WindowsDHCPParser); !ok {
+			t.Fatalf("%s: expected WindowsDHCPParser, got %T", tt.file, parser)
+		}
+		events, err := parser.Parse(filePath)
+		if err != nil {
+			t.Fatalf("%s: failed to parse: %v", tt.file, err)
+		}
+		if len(events) != len(tt.eventIDs) {
+			t.Fatalf("%s: expected %d events, got %d", tt.file, len(tt.eventIDs), len(events))
+		}
+		for i, id := range tt.eventIDs {
+			if events[i].EventID != id {
+				t.Errorf("%s: expected event ID %d, got %d", tt.file, id, events[i].EventID)
+			}
+		}
+		if events[0].Host != tt.host || events[0].Message != tt.message {
+			t.Errorf("%s: expected host=%q %q, got host=%q %q", tt.file, tt.host, tt.message, events[0].Host, events[0].Message)
+		}
+		if want := time.Date(2023, 4, 21, 15, 30, 45, 0, zonelessLocation); !events[0].Timestamp.Equal(want) {
+			t.Errorf("%s: expected %s, got %s", tt.file, want, events[0].Timestamp)
+		}
+	}
+}
+
+func TestFormatDHCPMAC(t *testing.T) {
+	tests := map[string]string{
+		"0050568a1b2c":      "00:50:56:8A:1B:2C",
+		"0050568A1B2C":      "00:50:56:8A:1B:2C",
+		"00-50-56-8A-1B-2C": "00-50-56-8A-1B-2C",
+		"":                  "",
+	}
+	for mac, want := range tests {
+		if got := formatDHCPMAC(mac); got != want {
+			t.Errorf("formatDHCPMAC(%q): expected %q, got %q", mac, want, got)
+		}
+	}
+}
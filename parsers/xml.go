@@ -100,7 +100,7 @@ func (p *WindowsXMLEventParser) CanParse(filePath string) bool {
 
 // detectWindowsEventXML checks if file contains Windows Event Log XML structure
 func (p *WindowsXMLEventParser) detectWindowsEventXML(filePath string) bool {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return false
 	}
@@ -125,7 +125,7 @@ func (p *WindowsXMLEventParser) detectWindowsEventXML(filePath string) bool {
 
 // Parse parses a Windows Event Log XML file and returns a slice of events
 func (p *WindowsXMLEventParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -136,7 +136,7 @@ func (p *WindowsXMLEventParser) Parse(filePath string) ([]*core.Event, error) {
 	source := filepath.Base(filePath)
 
 	// Try streaming parse for large files with multiple events
-	decoder := xml.NewDecoder(file)
+	decoder := newXMLDecoder(file)
 
 	// Track counts for summary
 	eventCount := 0
@@ -188,7 +188,7 @@ func (p *WindowsXMLEventParser) convertWindowsXMLEvent(xmlEvent *windowsXMLEvent
 	}
 
 	// Parse timestamp
-	timestamp := time.Now().UTC()
+	var timestamp time.Time
 	if xmlEvent.System.TimeCreated.SystemTime != "" {
 		// Try multiple timestamp formats
 		formats := []string{
@@ -199,7 +199,7 @@ func (p *WindowsXMLEventParser) convertWindowsXMLEvent(xmlEvent *windowsXMLEvent
 			"2006-01-02T15:04:05Z",
 		}
 		for _, format := range formats {
-			if parsed, err := time.Parse(format, xmlEvent.System.TimeCreated.SystemTime); err == nil {
+			if parsed, err := parseLogTime(format, xmlEvent.System.TimeCreated.SystemTime); err == nil {
 				timestamp = parsed
 				break
 			}
@@ -440,7 +440,7 @@ func (p *ScheduledTaskXMLParser) CanParse(filePath string) bool {
 
 // detectScheduledTaskXML checks if file contains Scheduled Task XML structure
 func (p *ScheduledTaskXMLParser) detectScheduledTaskXML(filePath string) bool {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return false
 	}
@@ -465,7 +465,7 @@ func (p *ScheduledTaskXMLParser) detectScheduledTaskXML(filePath string) bool {
 
 // Parse parses a Scheduled Task XML file and returns a slice of events
 func (p *ScheduledTaskXMLParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -478,7 +478,7 @@ func (p *ScheduledTaskXMLParser) Parse(filePath string) ([]*core.Event, error) {
 	}
 
 	var task scheduledTask
-	if err := xml.Unmarshal(data, &task); err != nil {
+	if err := newXMLDecoder(strings.NewReader(string(data))).Decode(&task); err != nil {
 		return nil, fmt.Errorf("failed to parse scheduled task XML: %w", err)
 	}
 
@@ -495,20 +495,7 @@ func (p *ScheduledTaskXMLParser) convertScheduledTask(task *scheduledTask, fileP
 	source := filepath.Base(filePath)
 
 	// Parse registration date for timestamp
-	timestamp := time.Now().UTC()
-	if task.RegistrationInfo.Date != "" {
-		formats := []string{
-			time.RFC3339,
-			"2006-01-02T15:04:05",
-			"2006-01-02T15:04:05.9999999",
-		}
-		for _, format := range formats {
-			if parsed, err := time.Parse(format, task.RegistrationInfo.Date); err == nil {
-				timestamp = parsed
-				break
-			}
-		}
-	}
+	timestamp, _ := parseTaskTime(task.RegistrationInfo.Date)
 
 	// Extract user from Author or Principal
 	user := task.RegistrationInfo.Author
@@ -569,6 +556,24 @@ func (p *ScheduledTaskXMLParser) convertScheduledTask(task *scheduledTask, fileP
 	return events
 }
 
+// parseTaskTime parses a task's registration date or a trigger's start boundary
+func parseTaskTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	formats := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04:05.9999999",
+	}
+	for _, format := range formats {
+		if parsed, err := parseLogTime(format, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // buildRegistrationMessage creates a message for task registration
 func (p *ScheduledTaskXMLParser) buildRegistrationMessage(task *scheduledTask) string {
 	var parts []string
@@ -630,61 +635,64 @@ func (p *ScheduledTaskXMLParser) buildExecMessage(exec *taskExec, uri string) st
 }
 
 // extractTriggerEvents creates events for each trigger type
+// Each trigger is recorded at the task's registration time, and again at its start boundary when it has one.
 func (p *ScheduledTaskXMLParser) extractTriggerEvents(task *scheduledTask, timestamp time.Time, source, user, filePath string) []*core.Event {
 	// Pre-allocate slice with estimated capacity (avg 1KB per XML event)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 1024))
 	eventID := 100 // Start trigger events at ID 100
 
+	addTrigger := func(eventType, msg, startBoundary string) {
+		event := core.NewEvent(timestamp, source, eventType, eventID, user, "", msg, filePath)
+		events = append(events, event)
+		if start, ok := parseTaskTime(startBoundary); ok {
+			events = append(events, event.WithTimestamp(start, core.TimestampDescScheduledStart))
+		}
+		eventID++
+	}
+
 	// Logon triggers
 	for _, trigger := range task.Triggers.LogonTrigger {
 		msg := fmt.Sprintf("Logon Trigger | Enabled: %s | StartBoundary: %s", trigger.Enabled, trigger.StartBoundary)
 		if trigger.UserId != "" {
 			msg += fmt.Sprintf(" | UserId: %s", trigger.UserId)
 		}
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:LogonTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:LogonTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Boot triggers
 	for _, trigger := range task.Triggers.BootTrigger {
 		msg := fmt.Sprintf("Boot Trigger | Enabled: %s | Delay: %s", trigger.Enabled, trigger.Delay)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:BootTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:BootTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Calendar triggers
 	for _, trigger := range task.Triggers.CalendarTrigger {
 		msg := fmt.Sprintf("Calendar Trigger | Enabled: %s | StartBoundary: %s", trigger.Enabled, trigger.StartBoundary)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:CalendarTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:CalendarTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Time triggers
 	for _, trigger := range task.Triggers.TimeTrigger {
 		msg := fmt.Sprintf("Time Trigger | Enabled: %s | StartBoundary: %s", trigger.Enabled, trigger.StartBoundary)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:TimeTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:TimeTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Event triggers (often used in malware)
 	for _, trigger := range task.Triggers.EventTrigger {
 		msg := fmt.Sprintf("Event Trigger | Enabled: %s | Subscription: %s", trigger.Enabled, trigger.Subscription)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:EventTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:EventTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Registration triggers
 	for _, trigger := range task.Triggers.RegistrationTrigger {
 		msg := fmt.Sprintf("Registration Trigger | Enabled: %s | StartBoundary: %s", trigger.Enabled, trigger.StartBoundary)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:RegistrationTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:RegistrationTrigger", msg, trigger.StartBoundary)
 	}
 
 	// Idle triggers
 	for _, trigger := range task.Triggers.IdleTrigger {
 		msg := fmt.Sprintf("Idle Trigger | Enabled: %s | StartBoundary: %s", trigger.Enabled, trigger.StartBoundary)
-		events = append(events, core.NewEvent(timestamp, source, "ScheduledTask:IdleTrigger", eventID, user, "", msg, filePath))
-		eventID++
+		addTrigger("ScheduledTask:IdleTrigger", msg, trigger.StartBoundary)
 	}
 
 	return events
@@ -762,7 +770,7 @@ func (p *SysmonXMLParser) CanParse(filePath string) bool {
 
 // detectSysmonXML checks if file contains Sysmon XML structure
 func (p *SysmonXMLParser) detectSysmonXML(filePath string) bool {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return false
 	}
@@ -790,7 +798,7 @@ func (p *SysmonXMLParser) detectSysmonXML(filePath string) bool {
 
 // Parse parses a Sysmon XML file and returns a slice of events
 func (p *SysmonXMLParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -805,30 +813,28 @@ func (p *SysmonXMLParser) Parse(filePath string) ([]*core.Event, error) {
 
 	content := string(buf[:n])
 
-	// Reset file position
-	if _, err := file.Seek(0, 0); err != nil {
-		return nil, fmt.Errorf("failed to seek file: %w", err)
-	}
+	// Put the header back in front of the rest of the file
+	reader := io.MultiReader(strings.NewReader(content), file)
 
 	// Determine if this is a config file or exported events
 	if strings.Contains(content, "<Sysmon") && strings.Contains(content, "<EventFiltering") {
-		return p.parseSysmonConfig(file, filePath)
+		return p.parseSysmonConfig(reader, filePath)
 	} else if strings.Contains(content, "Microsoft-Windows-Sysmon") || strings.Contains(content, "<Event") {
-		return p.parseSysmonEvents(file, filePath)
+		return p.parseSysmonEvents(reader, filePath)
 	}
 
 	return nil, fmt.Errorf("unable to determine Sysmon XML type")
 }
 
 // parseSysmonConfig parses a Sysmon configuration file
-func (p *SysmonXMLParser) parseSysmonConfig(file *os.File, filePath string) ([]*core.Event, error) {
+func (p *SysmonXMLParser) parseSysmonConfig(file io.Reader, filePath string) ([]*core.Event, error) {
 	data, err := io.ReadAll(file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	var config sysmonConfig
-	if err := xml.Unmarshal(data, &config); err != nil {
+	if err := newXMLDecoder(strings.NewReader(string(data))).Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to parse Sysmon config XML: %w", err)
 	}
 
@@ -843,7 +849,7 @@ func (p *SysmonXMLParser) convertSysmonConfig(config *sysmonConfig, filePath str
 	// Pre-allocate slice with estimated capacity (avg 1KB per XML event)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 1024))
 	source := filepath.Base(filePath)
-	timestamp := time.Now().UTC()
+	var timestamp time.Time
 
 	// Get file modification time as approximate config time
 	if fi, err := os.Stat(filePath); err == nil {
@@ -904,6 +910,7 @@ func (p *SysmonXMLParser) convertSysmonConfig(config *sysmonConfig, filePath str
 		}
 	}
 
+	markFileMtime(events...)
 	return events
 }
 
@@ -941,13 +948,13 @@ func (p *SysmonXMLParser) createRuleEvent(timestamp time.Time, source, filePath,
 }
 
 // parseSysmonEvents parses exported Sysmon events in Windows Event XML format
-func (p *SysmonXMLParser) parseSysmonEvents(file *os.File, filePath string) ([]*core.Event, error) {
+func (p *SysmonXMLParser) parseSysmonEvents(file io.Reader, filePath string) ([]*core.Event, error) {
 	// Pre-allocate slice with estimated capacity (avg 1KB per XML event)
 	events := make([]*core.Event, 0, estimateLineCapacity(filePath, 1024))
 	source := filepath.Base(filePath)
 
 	// Use streaming parser for potentially large event exports
-	decoder := xml.NewDecoder(file)
+	decoder := newXMLDecoder(file)
 
 	eventCount := 0
 	errorCount := 0
@@ -998,7 +1005,7 @@ func (p *SysmonXMLParser) convertSysmonEvent(xmlEvent *windowsXMLEvent, source,
 	}
 
 	// Parse timestamp
-	timestamp := time.Now().UTC()
+	var timestamp time.Time
 	if xmlEvent.System.TimeCreated.SystemTime != "" {
 		formats := []string{
 			time.RFC3339Nano,
@@ -1006,7 +1013,7 @@ func (p *SysmonXMLParser) convertSysmonEvent(xmlEvent *windowsXMLEvent, source,
 			"2006-01-02T15:04:05.9999999Z",
 		}
 		for _, format := range formats {
-			if parsed, err := time.Parse(format, xmlEvent.System.TimeCreated.SystemTime); err == nil {
+			if parsed, err := parseLogTime(format, xmlEvent.System.TimeCreated.SystemTime); err == nil {
 				timestamp = parsed
 				break
 			}
@@ -1145,7 +1152,7 @@ func (p *GenericXMLParser) CanParse(filePath string) bool {
 
 // Parse parses a generic XML file and attempts to extract events
 func (p *GenericXMLParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -1156,13 +1163,13 @@ func (p *GenericXMLParser) Parse(filePath string) ([]*core.Event, error) {
 	source := filepath.Base(filePath)
 
 	// Get file modification time for timestamp
-	timestamp := time.Now().UTC()
+	var timestamp time.Time
 	if fi, err := os.Stat(filePath); err == nil {
 		timestamp = fi.ModTime().UTC()
 	}
 
 	// Use streaming parser to extract elements
-	decoder := xml.NewDecoder(file)
+	decoder := newXMLDecoder(file)
 	elementCount := 0
 	depth := 0
 	var currentPath []string
@@ -1223,6 +1230,7 @@ func (p *GenericXMLParser) Parse(filePath string) ([]*core.Event, error) {
 		}
 	}
 
+	markFileMtime(events...)
 	fmt.Printf("Parsed Generic XML file: %s (found %d elements)\n", filePath, len(events))
 	return events, nil
 }
@@ -1245,7 +1253,7 @@ func parseXMLTimestamp(timeStr string) (time.Time, error) {
 	}
 
 	for _, format := range formats {
-		if parsed, err := time.Parse(format, timeStr); err == nil {
+		if parsed, err := parseLogTime(format, timeStr); err == nil {
 			return parsed, nil
 		}
 	}
@@ -1255,7 +1263,7 @@ func parseXMLTimestamp(timeStr string) (time.Time, error) {
 
 // detectXMLType attempts to identify the type of XML file from content
 func detectXMLType(filePath string) string {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return "unknown"
 	}
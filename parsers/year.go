@@ -0,0 +1,187 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"LogZero/core"
+)
+
+// ============================================================================
+// Year Inference for Year-less Timestamps
+// ============================================================================
+
+// yearlessPlaceholder is the year given to timestamps recorded without one until yearInferrer.apply
+// runs; it is a leap year no real log uses, so Feb 29 still parses
+const yearlessPlaceholder = 4
+
+// rolloverGap is how far a year-less timestamp must jump backwards to count as a new year
+const rolloverGap = 180 * 24 * time.Hour
+
+// boundSlack allows for the unknown timezone when comparing wall clocks with file times
+const boundSlack = 24 * time.Hour
+
+// Pre-compiled regex patterns for year anchors and rotation suffixes
+var (
+	// Kernel boot message with the RTC date: rtc_cmos 00:00: setting system clock to 2023-04-21T15:30:45 UTC (1682091045)
+	kernelClockPattern = regexp.MustCompile(`setting system clock to (\d{4}-\d{2}-\d{2})[T ](\d{2}:\d{2}:\d{2})`)
+
+	// logrotate dateext suffixes: messages-20230421, syslog.2023-04-21.gz
+	rotationDatePattern = regexp.MustCompile(`[-._](\d{4})-?(\d{2})-?(\d{2})(?:\.(?:gz|bz2|xz|zst))?$`)
+
+	// Numbered rotation suffixes: syslog.1, messages.2.gz
+	rotationNumberPattern = regexp.MustCompile(`^(.+)\.(\d{1,3})(\.(?:gz|bz2|xz|zst))?$`)
+)
+
+// assumeYear is set once at startup (before parsing begins) by SetAssumeYear
+var assumeYear int
+
+// SetAssumeYear sets the year of the first year-less timestamp in every file; later ones still roll over
+// Zero restores inference from file contents and metadata.
+func SetAssumeYear(year int) error {
+	if year != 0 && (year < 1970 || year > 9999) {
+		return fmt.Errorf("year %d out of range", year)
+	}
+	assumeYear = year
+	return nil
+}
+
+// yearInferrer assigns years to a file's year-less timestamps once all of its events are known
+// Events are added in file order; events with a full year serve as anchors for their neighbours.
+type yearInferrer struct {
+	filePath string
+	yearless []*core.Event
+	anchors  []yearAnchor
+}
+
+// yearAnchor is a timestamp with a known year, seen after the given number of year-less events
+type yearAnchor struct {
+	position  int
+	timestamp time.Time
+}
+
+func newYearInferrer(filePath string) *yearInferrer {
+	return &yearInferrer{filePath: filePath}
+}
+
+// add records an event in file order
+func (y *yearInferrer) add(event *core.Event) {
+	if m := kernelClockPattern.FindStringSubmatch(event.Message); m != nil {
+		if t, err := time.Parse("2006-01-02 15:04:05", m[1]+" "+m[2]); err == nil {
+			y.anchors = append(y.anchors, yearAnchor{position: len(y.yearless), timestamp: t})
+		}
+	}
+	switch {
+	case event.Timestamp.IsZero():
+	case event.Timestamp.Year() == yearlessPlaceholder:
+		y.yearless = append(y.yearless, event)
+	default:
+		y.anchors = append(y.anchors, yearAnchor{position: len(y.yearless), timestamp: event.Timestamp})
+	}
+}
+
+// apply sets the year of every year-less event and tags it with how the year was chosen
+func (y *yearInferrer) apply() {
+	if len(y.yearless) == 0 {
+		return
+	}
+
+	// A large backwards jump between consecutive records is a year rollover
+	offsets := make([]int, len(y.yearless))
+	for i := 1; i < len(y.yearless); i++ {
+		offsets[i] = offsets[i-1]
+		if y.yearless[i].Timestamp.Before(y.yearless[i-1].Timestamp.Add(-rolloverGap)) {
+			offsets[i]++
+		}
+	}
+
+	base, method := y.baseYear(offsets)
+	for i, event := range y.yearless {
+		event.Timestamp = withYear(event.Timestamp, base+offsets[i])
+		event.TimestampQuality = core.TimestampInferred
+		event.Tags = append(event.Tags, "year_inferred:"+method)
+	}
+}
+
+// baseYear picks the year of the first year-less record
+// An explicit --assume-year wins, then anchors in the file, then the rotation suffix, then the file's mtime.
+func (y *yearInferrer) baseYear(offsets []int) (int, string) {
+	if assumeYear != 0 {
+		return assumeYear, "assumed"
+	}
+
+	if len(y.anchors) > 0 {
+		anchor := y.anchors[0]
+		ref := max(anchor.position-1, 0)
+		target := wallClock(anchor.timestamp)
+		best := 0
+		var bestDistance time.Duration = -1
+		for candidate := target.Year() - offsets[ref] - 1; candidate <= target.Year()-offsets[ref]+1; candidate++ {
+			distance := withYear(y.yearless[ref].Timestamp, candidate+offsets[ref]).Sub(target).Abs()
+			if bestDistance < 0 || distance < bestDistance {
+				best, bestDistance = candidate, distance
+			}
+		}
+		return best, "anchor"
+	}
+
+	// The last record was written no later than the file was rotated or last modified
+	last := len(y.yearless) - 1
+	bound, method := yearUpperBound(y.filePath)
+	base := wallClock(bound).Year() - offsets[last]
+	if withYear(y.yearless[last].Timestamp, base+offsets[last]).After(wallClock(bound).Add(boundSlack)) {
+		base--
+	}
+	return base, method
+}
+
+// yearUpperBound returns the latest time a file's records can have and where it came from
+func yearUpperBound(filePath string) (time.Time, string) {
+	base := filepath.Base(filePath)
+	if m := rotationDatePattern.FindStringSubmatch(base); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		if month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+			return time.Date(year, time.Month(month), day, 23, 59, 59, 0, time.UTC), "rotation"
+		}
+	}
+
+	bound := time.Now().UTC()
+	method := "current"
+	if info, err := os.Stat(filePath); err == nil {
+		bound, method = info.ModTime().UTC(), "mtime"
+	}
+
+	// A numbered rotation ends before its newer sibling (syslog.2 before syslog.1 before syslog) was last written
+	if m := rotationNumberPattern.FindStringSubmatch(base); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		newer := m[1]
+		if n > 1 {
+			newer = fmt.Sprintf("%s.%d", m[1], n-1)
+		}
+		for _, name := range []string{newer, newer + m[3]} {
+			if info, err := os.Stat(filepath.Join(filepath.Dir(filePath), name)); err == nil && info.ModTime().Before(bound) {
+				bound, method = info.ModTime().UTC(), "rotation"
+				break
+			}
+		}
+	}
+	return bound, method
+}
+
+// withYear returns t with its year replaced, keeping its wall clock and location
+func withYear(t time.Time, year int) time.Time {
+	_, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.Nanosecond(), t.Location())
+}
+
+// wallClock returns t's UTC wall clock in the zone-less location, for comparison with year-less timestamps
+func wallClock(t time.Time) time.Time {
+	return inTimezone(t.UTC(), zonelessLocation)
+}
@@ -0,0 +1,106 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"LogZero/core"
+)
+
+func TestYearInference(t *testing.T) {
+	dir := t.TempDir()
+	rollover := "Dec 31 23:59:58 web01 sshd[100]: Accepted password for jdoe\nJan  1 00:00:02 web01 sshd[100]: session closed\n"
+	files := []struct {
+		name    string
+		content string
+		mtime   time.Time
+	}{
+		{"syslog", rollover, time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC)},
+		{"anchored.log", "2021-12-31T10:00:00Z web01 cron: started\n" + rollover, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"kern.log", "Dec 31 23:00:00 web01 kernel: [0.512] rtc_cmos 00:00: setting system clock to 2019-12-31T23:00:00 UTC (1577833200)\n" + rollover,
+			time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"messages-20220105", rollover, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"auth.log", rollover, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"auth.log.1", rollover, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", f.name, err)
+		}
+		if err := os.Chtimes(path, f.mtime, f.mtime); err != nil {
+			t.Fatalf("Failed to set mtime of %s: %v", f.name, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		firstYear int
+		tag       string
+	}{
+		{"syslog", 2022, "year_inferred:mtime"},
+		{"anchored.log", 2021, "year_inferred:anchor"},
+		{"kern.log", 2019, "year_inferred:anchor"},
+		{"messages-20220105", 2021, "year_inferred:rotation"},
+		{"auth.log.1", 2020, "year_inferred:rotation"},
+	}
+	for _, tt := range tests {
+		events, err := (&LinuxSyslogParser{}).Parse(filepath.Join(dir, tt.name))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", tt.name, err)
+		}
+		last := events[len(events)-1]
+		previous := events[len(events)-2]
+		if previous.Timestamp.Year() != tt.firstYear || last.Timestamp.Year() != tt.firstYear+1 {
+			t.Errorf("%s: expected rollover from %d, got %s then %s", tt.name, tt.firstYear, previous.Timestamp, last.Timestamp)
+		}
+		if !slices.Contains(last.Tags, tt.tag) {
+			t.Errorf("%s: expected tag %s, got %v", tt.name, tt.tag, last.Tags)
+		}
+		if tt.name == "anchored.log" && slices.Contains(events[0].Tags, tt.tag) {
+			t.Errorf("%s: dated anchor line should not be tagged as inferred", tt.name)
+		}
+	}
+
+	if err := SetAssumeYear(2015); err != nil {
+		t.Fatalf("Failed to set assumed year: %v", err)
+	}
+	t.Cleanup(func() { SetAssumeYear(0) })
+	events, err := (&LinuxSyslogParser{}).Parse(filepath.Join(dir, "syslog"))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if events[0].Timestamp.Year() != 2015 || events[1].Timestamp.Year() != 2016 || !slices.Contains(events[0].Tags, "year_inferred:assumed") {
+		t.Errorf("Expected assumed year with rollover, got %s then %s %v", events[0].Timestamp, events[1].Timestamp, events[0].Tags)
+	}
+	if err := SetAssumeYear(20); err == nil {
+		t.Error("Expected an error for an out-of-range year")
+	}
+}
+
+func TestTimestampQuality(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syslog")
+	content := "2023-04-21T15:30:45Z web01 cron: started\nApr 21 15:31:00 web01 sshd[100]: Accepted password for jdoe\nkernel panic - not syncing\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	events, err := (&LinuxSyslogParser{}).Parse(path)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected := []string{core.TimestampExact, core.TimestampInferred, core.TimestampMissing}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, quality := range expected {
+		if events[i].TimestampQuality != quality {
+			t.Errorf("Event %d: expected quality %s, got %s", i, quality, events[i].TimestampQuality)
+		}
+	}
+	if !events[2].Timestamp.IsZero() {
+		t.Errorf("Expected a zero timestamp for an unparseable line, got %s", events[2].Timestamp)
+	}
+}
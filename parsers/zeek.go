@@ -3,7 +3,6 @@ package parsers
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -52,7 +51,7 @@ func (p *ZeekParser) CanParse(filePath string) bool {
 
 // hasZeekHeaders checks if a file contains Zeek-specific header lines
 func (p *ZeekParser) hasZeekHeaders(filePath string) bool {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return false
 	}
@@ -84,7 +83,7 @@ func (p *ZeekParser) hasZeekHeaders(filePath string) bool {
 
 // Parse parses a Zeek log file and returns a slice of events
 func (p *ZeekParser) Parse(filePath string) ([]*core.Event, error) {
-	file, err := os.Open(filePath)
+	file, err := openTextFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}